@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -15,8 +16,19 @@ import (
 	"time"
 )
 
+// DefaultCacheDir returns the directory where responses are cached when no
+// explicit cache directory was configured.
+func DefaultCacheDir() string {
+	return filepath.Join(os.TempDir(), "instill-cli-cache")
+}
+
 func NewCachedClient(httpClient *http.Client, cacheTTL time.Duration) *http.Client {
-	cacheDir := filepath.Join(os.TempDir(), "instill-cli-cache")
+	return NewCachedClientInDir(httpClient, cacheTTL, DefaultCacheDir())
+}
+
+// NewCachedClientInDir is NewCachedClient with the cache rooted at an
+// explicit directory.
+func NewCachedClientInDir(httpClient *http.Client, cacheTTL time.Duration, cacheDir string) *http.Client {
 	return &http.Client{
 		Transport: CacheResponse(cacheTTL, cacheDir)(httpClient.Transport),
 	}
@@ -54,6 +66,36 @@ func CacheResponse(ttl time.Duration, dir string) ClientOption {
 					res.Request = req
 					return res, nil
 				}
+
+				// a stale entry can still be revalidated cheaply: send the
+				// validators the server gave us and serve the cached body
+				// again on 304 Not Modified
+				if stale, err := fs.readStale(key); err == nil {
+					if etag := stale.Header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == "" {
+						req.Header.Set("If-None-Match", etag)
+					}
+					if lastModified := stale.Header.Get("Last-Modified"); lastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+						req.Header.Set("If-Modified-Since", lastModified)
+					}
+					if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+						res, err := tr.RoundTrip(req)
+						if err != nil {
+							return res, err
+						}
+						if res.StatusCode == http.StatusNotModified {
+							if res.Body != nil {
+								res.Body.Close()
+							}
+							fs.touch(key)
+							stale.Request = req
+							return stale, nil
+						}
+						if isCacheableResponse(res) {
+							_ = fs.store(key, res)
+						}
+						return res, nil
+					}
+				}
 			}
 
 			res, err := tr.RoundTrip(req)
@@ -65,6 +107,74 @@ func CacheResponse(ttl time.Duration, dir string) ClientOption {
 	}
 }
 
+// ClearCache removes cached responses under dir, defaulting to
+// DefaultCacheDir when dir is empty. Only files laid out like cache entries
+// (sharded hex digests) are removed, so unrelated files in the directory
+// survive. It reports the number of entries removed and the bytes freed.
+func ClearCache(dir string) (entries int, freed int64, err error) {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+
+	var shardDirs []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(rel, string(filepath.Separator))
+		if d.IsDir() {
+			if len(parts) <= 2 && (path == dir || isHexString(parts[len(parts)-1])) {
+				if path != dir {
+					shardDirs = append(shardDirs, path)
+				}
+				return nil
+			}
+			return filepath.SkipDir
+		}
+		if len(parts) != 3 || !isHexString(parts[2]) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		entries++
+		freed += info.Size()
+		return nil
+	})
+	if err != nil {
+		return entries, freed, err
+	}
+
+	// prune shard directories that are empty now, deepest first
+	for i := len(shardDirs) - 1; i >= 0; i-- {
+		_ = os.Remove(shardDirs[i])
+	}
+	return entries, freed, nil
+}
+
+func isHexString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 func copyStream(r io.ReadCloser) (io.ReadCloser, io.ReadCloser) {
 	b := &bytes.Buffer{}
 	nr := io.TeeReader(r, b)
@@ -113,6 +223,24 @@ func (fs *fileStorage) filePath(key string) string {
 }
 
 func (fs *fileStorage) read(key string) (*http.Response, error) {
+	res, age, err := fs.readWithAge(key)
+	if err != nil {
+		return nil, err
+	}
+	if age > fs.ttl {
+		return nil, errors.New("cache expired")
+	}
+	return res, nil
+}
+
+// readStale returns a cached response regardless of its age, for conditional
+// revalidation of expired entries.
+func (fs *fileStorage) readStale(key string) (*http.Response, error) {
+	res, _, err := fs.readWithAge(key)
+	return res, err
+}
+
+func (fs *fileStorage) readWithAge(key string) (*http.Response, time.Duration, error) {
 	cacheFile := fs.filePath(key)
 
 	fs.mu.RLock()
@@ -120,28 +248,33 @@ func (fs *fileStorage) read(key string) (*http.Response, error) {
 
 	f, err := os.Open(cacheFile)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer f.Close()
 
 	stat, err := f.Stat()
 	if err != nil {
-		return nil, err
-	}
-
-	age := time.Since(stat.ModTime())
-	if age > fs.ttl {
-		return nil, errors.New("cache expired")
+		return nil, 0, err
 	}
 
 	body := &bytes.Buffer{}
 	_, err = io.Copy(body, f)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	res, err := http.ReadResponse(bufio.NewReader(body), nil)
-	return res, err
+	return res, time.Since(stat.ModTime()), err
+}
+
+// touch marks a revalidated cache entry as fresh again by updating its
+// modification time.
+func (fs *fileStorage) touch(key string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	now := time.Now()
+	_ = os.Chtimes(fs.filePath(key), now, now)
 }
 
 func (fs *fileStorage) store(key string, res *http.Response) error {
@@ -155,11 +288,12 @@ func (fs *fileStorage) store(key string, res *http.Response) error {
 		return err
 	}
 
-	f, err := os.OpenFile(cacheFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	// write to a temporary file and rename into place so an aborted response
+	// (e.g. Ctrl-C mid-download) never leaves a truncated cache entry behind
+	f, err := os.CreateTemp(filepath.Dir(cacheFile), ".tmp-*")
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
 	var origBody io.ReadCloser
 	if res.Body != nil {
@@ -170,5 +304,16 @@ func (fs *fileStorage) store(key string, res *http.Response) error {
 	if origBody != nil {
 		res.Body = origBody
 	}
-	return err
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0600); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), cacheFile)
 }
@@ -2,11 +2,15 @@ package api
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -75,3 +79,126 @@ func Test_CacheResponse(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "5: GET http://example.com/error", res)
 }
+
+func Test_CacheResponse_conditionalRequest(t *testing.T) {
+	requests := 0
+	var gotIfNoneMatch string
+	fakeHTTP := funcTripper{
+		roundTrip: func(req *http.Request) (*http.Response, error) {
+			requests++
+			gotIfNoneMatch = req.Header.Get("If-None-Match")
+			if gotIfNoneMatch == `"v1"` {
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Etag": []string{`"v1"`}},
+				Body:       io.NopCloser(bytes.NewBufferString("fresh body")),
+			}, nil
+		},
+	}
+
+	cacheDir := filepath.Join(t.TempDir(), "instill-cli-cache")
+	ttl := 50 * time.Millisecond
+	httpClient := NewHTTPClient(ReplaceTripper(fakeHTTP), CacheResponse(ttl, cacheDir))
+
+	do := func() string {
+		req, err := http.NewRequest("GET", "http://example.com/path", nil)
+		require.NoError(t, err)
+		res, err := httpClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	// the initial request populates the cache
+	assert.Equal(t, "fresh body", do())
+	assert.Equal(t, 1, requests)
+
+	// within the TTL the cache answers without a request
+	assert.Equal(t, "fresh body", do())
+	assert.Equal(t, 1, requests)
+
+	// after expiry the entry is revalidated with If-None-Match, and the 304
+	// serves the cached body again
+	time.Sleep(ttl + 10*time.Millisecond)
+	assert.Equal(t, "fresh body", do())
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, `"v1"`, gotIfNoneMatch)
+
+	// the revalidation made the entry fresh again
+	assert.Equal(t, "fresh body", do())
+	assert.Equal(t, 2, requests)
+}
+
+func Test_fileStorage_store_incompleteResponse(t *testing.T) {
+	fs := fileStorage{
+		dir: t.TempDir(),
+		ttl: time.Minute,
+		mu:  &sync.RWMutex{},
+	}
+
+	body := io.MultiReader(
+		bytes.NewBufferString("partial"),
+		iotest.ErrReader(errors.New("connection reset")),
+	)
+	res := &http.Response{
+		StatusCode: 200,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Body:       io.NopCloser(body),
+	}
+
+	key := "deadbeef"
+	require.Error(t, fs.store(key, res))
+
+	// neither the cache entry nor a temporary file may survive a failed write
+	_, err := os.Stat(fs.filePath(key))
+	assert.True(t, os.IsNotExist(err))
+	leftovers, err := filepath.Glob(filepath.Join(fs.dir, "*", "*", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func Test_ClearCache(t *testing.T) {
+	dir := t.TempDir()
+
+	// two well-formed cache entries plus files that must survive a clear
+	entry1 := filepath.Join(dir, "ab", "cd", "0123456789abcdef")
+	entry2 := filepath.Join(dir, "ef", "01", "fedcba9876543210")
+	unrelated := filepath.Join(dir, "notes.txt")
+	nested := filepath.Join(dir, "keep", "data.bin")
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry1), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry2), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(nested), 0755))
+	require.NoError(t, os.WriteFile(entry1, []byte("12345"), 0600))
+	require.NoError(t, os.WriteFile(entry2, []byte("123"), 0600))
+	require.NoError(t, os.WriteFile(unrelated, []byte("hello"), 0600))
+	require.NoError(t, os.WriteFile(nested, []byte("hello"), 0600))
+
+	entries, freed, err := ClearCache(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, entries)
+	assert.Equal(t, int64(8), freed)
+
+	for _, path := range []string{entry1, entry2, filepath.Dir(entry1), filepath.Dir(entry2)} {
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "expected %s to be removed", path)
+	}
+	for _, path := range []string{unrelated, nested} {
+		_, err := os.Stat(path)
+		assert.NoError(t, err, "expected %s to survive", path)
+	}
+}
+
+func Test_ClearCache_missingDir(t *testing.T) {
+	entries, freed, err := ClearCache(filepath.Join(t.TempDir(), "does-not-exist"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, entries)
+	assert.Equal(t, int64(0), freed)
+}
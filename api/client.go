@@ -69,16 +69,42 @@ func AddHeaderFunc(name string, getValue func(*http.Request) (string, error)) Cl
 	}
 }
 
+// AddDefaultHeaders turns a RoundTripper into one that adds every header from
+// the map that the request does not already carry, so explicitly set headers
+// always win over the defaults.
+func AddDefaultHeaders(getHeaders func(*http.Request) (map[string]string, error)) ClientOption {
+	return func(tr http.RoundTripper) http.RoundTripper {
+		return &funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+			headers, err := getHeaders(req)
+			if err != nil {
+				return nil, err
+			}
+			for name, value := range headers {
+				if req.Header.Get(name) == "" {
+					req.Header.Set(name, value)
+				}
+			}
+			return tr.RoundTrip(req)
+		}}
+	}
+}
+
 // VerboseLog enables request/response logging within a RoundTripper
 func VerboseLog(out io.Writer, logTraffic bool, colorize bool) ClientOption {
+	return VerboseLogWithBodies(out, logTraffic, logTraffic, colorize)
+}
+
+// VerboseLogWithBodies enables request/response logging with separate control
+// over headers and bodies.
+func VerboseLogWithBodies(out io.Writer, logHeaders, logBodies, colorize bool) ClientOption {
 	logger := &httpretty.Logger{
 		Time:            true,
 		TLS:             false,
 		Colors:          colorize,
-		RequestHeader:   logTraffic,
-		RequestBody:     logTraffic,
-		ResponseHeader:  logTraffic,
-		ResponseBody:    logTraffic,
+		RequestHeader:   logHeaders,
+		RequestBody:     logBodies,
+		ResponseHeader:  logHeaders,
+		ResponseBody:    logBodies,
 		Formatters:      []httpretty.Formatter{&httpretty.JSONFormatter{}},
 		MaxResponseBody: 10000,
 	}
@@ -118,6 +144,8 @@ type HTTPError struct {
 	StatusCode int
 	RequestURL *url.URL
 	Message    string
+	RequestID  string
+	Body       []byte
 	Errors     []HTTPErrorItem
 }
 
@@ -129,12 +157,16 @@ type HTTPErrorItem struct {
 }
 
 func (err HTTPError) Error() string {
+	var requestID string
+	if err.RequestID != "" {
+		requestID = fmt.Sprintf(" (request id %s)", err.RequestID)
+	}
 	if msgs := strings.SplitN(err.Message, "\n", 2); len(msgs) > 1 {
-		return fmt.Sprintf("HTTP %d: %s (%s)\n%s", err.StatusCode, msgs[0], err.RequestURL, msgs[1])
+		return fmt.Sprintf("HTTP %d: %s (%s)%s\n%s", err.StatusCode, msgs[0], err.RequestURL, requestID, msgs[1])
 	} else if err.Message != "" {
-		return fmt.Sprintf("HTTP %d: %s (%s)", err.StatusCode, err.Message, err.RequestURL)
+		return fmt.Sprintf("HTTP %d: %s (%s)%s", err.StatusCode, err.Message, err.RequestURL, requestID)
 	}
-	return fmt.Sprintf("HTTP %d (%s)", err.StatusCode, err.RequestURL)
+	return fmt.Sprintf("HTTP %d (%s)%s", err.StatusCode, err.RequestURL, requestID)
 }
 
 // REST performs a REST request and parses the response.
@@ -184,11 +216,11 @@ func HandleHTTPError(resp *http.Response) error {
 	httpError := HTTPError{
 		StatusCode: resp.StatusCode,
 		RequestURL: resp.Request.URL,
+		RequestID:  resp.Header.Get(RequestIDHeader),
 	}
-
-	if !jsonTypeRE.MatchString(resp.Header.Get("Content-Type")) {
-		httpError.Message = resp.Status
-		return httpError
+	// servers that do not echo the ID still leave the one generated client-side
+	if httpError.RequestID == "" {
+		httpError.RequestID = resp.Request.Header.Get(RequestIDHeader)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -196,6 +228,12 @@ func HandleHTTPError(resp *http.Response) error {
 		httpError.Message = err.Error()
 		return httpError
 	}
+	httpError.Body = body
+
+	if !jsonTypeRE.MatchString(resp.Header.Get("Content-Type")) {
+		httpError.Message = resp.Status
+		return httpError
+	}
 
 	var parsedBody struct {
 		Message string `json:"message"`
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestHandleHTTPError(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.instill.tech/base/v1alpha/users/me")
+
+	tests := []struct {
+		name          string
+		response      *http.Response
+		wantMessage   string
+		wantRequestID string
+		wantBody      string
+		wantError     string
+	}{
+		{
+			name: "JSON message with request id",
+			response: &http.Response{
+				StatusCode: 404,
+				Header: http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+					"X-Request-Id": []string{"abc123"},
+				},
+				Body: io.NopCloser(bytes.NewBufferString(`{"message": "not found"}`)),
+			},
+			wantMessage:   "not found",
+			wantRequestID: "abc123",
+			wantBody:      `{"message": "not found"}`,
+			wantError:     "HTTP 404: not found (https://api.instill.tech/base/v1alpha/users/me) (request id abc123)",
+		},
+		{
+			name: "non-JSON response",
+			response: &http.Response{
+				StatusCode: 502,
+				Status:     "502 Bad Gateway",
+				Header:     http.Header{"Content-Type": []string{"text/html"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`<html>bad gateway</html>`)),
+			},
+			wantMessage: "502 Bad Gateway",
+			wantBody:    `<html>bad gateway</html>`,
+			wantError:   "HTTP 502: 502 Bad Gateway (https://api.instill.tech/base/v1alpha/users/me)",
+		},
+		{
+			name: "no message",
+			response: &http.Response{
+				StatusCode: 500,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			},
+			wantBody:  `{}`,
+			wantError: "HTTP 500 (https://api.instill.tech/base/v1alpha/users/me)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.response.Request = &http.Request{URL: requestURL}
+
+			err := HandleHTTPError(tt.response)
+			httpErr, ok := err.(HTTPError)
+			if !ok {
+				t.Fatalf("expected HTTPError, got %T", err)
+			}
+
+			if httpErr.StatusCode != tt.response.StatusCode {
+				t.Errorf("StatusCode: %d", httpErr.StatusCode)
+			}
+			if httpErr.Message != tt.wantMessage {
+				t.Errorf("Message: %q, wants %q", httpErr.Message, tt.wantMessage)
+			}
+			if httpErr.RequestID != tt.wantRequestID {
+				t.Errorf("RequestID: %q, wants %q", httpErr.RequestID, tt.wantRequestID)
+			}
+			if string(httpErr.Body) != tt.wantBody {
+				t.Errorf("Body: %q, wants %q", httpErr.Body, tt.wantBody)
+			}
+			if httpErr.Error() != tt.wantError {
+				t.Errorf("Error(): %q, wants %q", httpErr.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit holds the rate-limit state parsed from HTTP response headers, so
+// callers can throttle proactively instead of only reacting to 429 responses.
+type RateLimit struct {
+	// Limit is the request quota for the current window, or 0 when unknown.
+	Limit int
+	// Remaining is the number of requests left in the current window, or -1
+	// when unknown.
+	Remaining int
+	// Reset is the time at which the quota replenishes, or the zero time when
+	// unknown.
+	Reset time.Time
+}
+
+// HasReset reports whether the server communicated when the quota resets.
+func (rl RateLimit) HasReset() bool {
+	return !rl.Reset.IsZero()
+}
+
+// ParseRateLimit extracts rate-limit information from the `Retry-After`,
+// `X-RateLimit-Limit`, `X-RateLimit-Remaining`, and `X-RateLimit-Reset`
+// response headers. Missing headers leave the corresponding fields at their
+// "unknown" values.
+func ParseRateLimit(resp *http.Response) RateLimit {
+	rl := RateLimit{Remaining: -1}
+
+	if v := resp.Header.Get("X-RateLimit-Limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			rl.Limit = n
+		}
+	}
+	if v := resp.Header.Get("X-RateLimit-Remaining"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			rl.Remaining = n
+		}
+	}
+	if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+		// unix epoch seconds
+		if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rl.Reset = time.Unix(sec, 0)
+		}
+	}
+	if rl.Reset.IsZero() {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			// either delay seconds or an HTTP date
+			if sec, err := strconv.ParseInt(v, 10, 64); err == nil {
+				rl.Reset = time.Now().Add(time.Duration(sec) * time.Second)
+			} else if at, err := http.ParseTime(v); err == nil {
+				rl.Reset = at
+			}
+		}
+	}
+
+	return rl
+}
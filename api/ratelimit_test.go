@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		headers       http.Header
+		wantLimit     int
+		wantRemaining int
+		wantHasReset  bool
+		wantReset     time.Time
+	}{
+		{
+			name:          "no headers",
+			headers:       http.Header{},
+			wantLimit:     0,
+			wantRemaining: -1,
+			wantHasReset:  false,
+		},
+		{
+			name: "x-ratelimit headers",
+			headers: http.Header{
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"12"},
+				"X-Ratelimit-Reset":     []string{"1700000000"},
+			},
+			wantLimit:     100,
+			wantRemaining: 12,
+			wantHasReset:  true,
+			wantReset:     time.Unix(1700000000, 0),
+		},
+		{
+			name: "retry-after HTTP date",
+			headers: http.Header{
+				"Retry-After": []string{"Wed, 15 Nov 2023 12:00:00 GMT"},
+			},
+			wantLimit:     0,
+			wantRemaining: -1,
+			wantHasReset:  true,
+			wantReset:     time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "reset wins over retry-after",
+			headers: http.Header{
+				"X-Ratelimit-Reset": []string{"1700000000"},
+				"Retry-After":       []string{"30"},
+			},
+			wantLimit:     0,
+			wantRemaining: -1,
+			wantHasReset:  true,
+			wantReset:     time.Unix(1700000000, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := ParseRateLimit(&http.Response{Header: tt.headers})
+			assert.Equal(t, tt.wantLimit, rl.Limit)
+			assert.Equal(t, tt.wantRemaining, rl.Remaining)
+			assert.Equal(t, tt.wantHasReset, rl.HasReset())
+			if tt.wantHasReset && !tt.wantReset.IsZero() {
+				assert.True(t, rl.Reset.Equal(tt.wantReset), "Reset = %v, want %v", rl.Reset, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestParseRateLimit_retryAfterSeconds(t *testing.T) {
+	rl := ParseRateLimit(&http.Response{Header: http.Header{
+		"Retry-After": []string{"30"},
+	}})
+	assert.True(t, rl.HasReset())
+	remaining := time.Until(rl.Reset)
+	assert.Greater(t, remaining, 25*time.Second)
+	assert.LessOrEqual(t, remaining, 30*time.Second)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader carries the per-request correlation ID, so client logs can
+// be matched with server logs.
+const RequestIDHeader = "X-Request-Id"
+
+// AddRequestID turns a RoundTripper into one that tags every request with a
+// fresh UUID in the X-Request-Id header. A header already set by the caller is
+// kept as-is. In verbose mode the ID shows up with the other logged request
+// headers.
+func AddRequestID() ClientOption {
+	return func(tr http.RoundTripper) http.RoundTripper {
+		return &funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(RequestIDHeader) == "" {
+				if id := generateRequestID(); id != "" {
+					req.Header.Set(RequestIDHeader, id)
+				}
+			}
+			return tr.RoundTrip(req)
+		}}
+	}
+}
+
+// generateRequestID returns a random version 4 UUID, or "" when no randomness
+// is available.
+func generateRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
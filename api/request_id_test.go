@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddRequestID(t *testing.T) {
+	var seen []string
+	tr := funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		seen = append(seen, req.Header.Get(RequestIDHeader))
+		return &http.Response{
+			StatusCode: 200,
+			Request:    req,
+			Body:       io.NopCloser(&bytes.Buffer{}),
+		}, nil
+	}}
+	client := NewHTTPClient(
+		ClientOption(func(http.RoundTripper) http.RoundTripper { return tr }),
+		AddRequestID(),
+	)
+
+	uuidRE := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for i := 0; i < 2; i++ {
+		res, err := client.Get("https://example.com/path")
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	require.Len(t, seen, 2)
+	assert.Regexp(t, uuidRE, seen[0])
+	assert.Regexp(t, uuidRE, seen[1])
+	assert.NotEqual(t, seen[0], seen[1], "request IDs must be unique across requests")
+
+	// a caller-provided ID is preserved
+	req, err := http.NewRequest("GET", "https://example.com/path", nil)
+	require.NoError(t, err)
+	req.Header.Set(RequestIDHeader, "caller-set")
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, "caller-set", seen[2])
+}
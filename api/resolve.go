@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// DialContextFunc matches the DialContext field of http.Transport.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+type resolveOverridesKey struct{}
+
+// WithResolveOverrides returns a context carrying "host:port" to "addr:port"
+// dial overrides. Transports whose DialContext is wrapped with
+// ResolveDialContext apply them when dialing, for requests made with this
+// context only; nothing is ever persisted.
+func WithResolveOverrides(ctx context.Context, overrides map[string]string) context.Context {
+	return context.WithValue(ctx, resolveOverridesKey{}, overrides)
+}
+
+// ResolveDialContext wraps a DialContext so that addresses overridden via
+// WithResolveOverrides are dialed at their replacement address. The request
+// URL is left untouched, so TLS verification and SNI still use the original
+// host.
+func ResolveDialContext(next DialContextFunc) DialContextFunc {
+	if next == nil {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		next = dialer.DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if overrides, ok := ctx.Value(resolveOverridesKey{}).(map[string]string); ok {
+			if replacement, ok := overrides[addr]; ok {
+				addr = replacement
+			}
+		}
+		return next(ctx, network, addr)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResolveDialContext(t *testing.T) {
+	var dialed string
+	next := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return nil, nil
+	}
+	dial := ResolveDialContext(next)
+
+	t.Run("overridden address", func(t *testing.T) {
+		ctx := WithResolveOverrides(context.Background(), map[string]string{
+			"api.instill.tech:443": "127.0.0.1:443",
+		})
+		_, err := dial(ctx, "tcp", "api.instill.tech:443")
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:443", dialed)
+	})
+
+	t.Run("address without an override", func(t *testing.T) {
+		ctx := WithResolveOverrides(context.Background(), map[string]string{
+			"api.instill.tech:443": "127.0.0.1:443",
+		})
+		_, err := dial(ctx, "tcp", "other.example.com:443")
+		require.NoError(t, err)
+		assert.Equal(t, "other.example.com:443", dialed)
+	})
+
+	t.Run("context without overrides", func(t *testing.T) {
+		_, err := dial(context.Background(), "tcp", "api.instill.tech:443")
+		require.NoError(t, err)
+		assert.Equal(t, "api.instill.tech:443", dialed)
+	})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how transient request failures are retried. The delay
+// before each retry grows exponentially from Base up to Cap; with Jitter
+// enabled, each delay is instead drawn uniformly from [0, delay] ("full
+// jitter") so that many clients retrying at once do not do so in lockstep.
+type RetryPolicy struct {
+	MaxRetries int
+	Base       time.Duration
+	Cap        time.Duration
+	Jitter     bool
+
+	// overridable in tests for determinism
+	rng   func(n int64) int64
+	sleep func(time.Duration)
+}
+
+// DefaultRetryPolicy mirrors the built-in defaults of the http_retry_* config
+// keys.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		Base:       250 * time.Millisecond,
+		Cap:        4 * time.Second,
+		Jitter:     true,
+	}
+}
+
+// backoff returns the delay to wait before the given retry attempt, starting
+// at zero for the first retry.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.Base
+	for i := 0; i < attempt && delay < p.Cap; i++ {
+		delay *= 2
+	}
+	if delay > p.Cap {
+		delay = p.Cap
+	}
+	if delay <= 0 {
+		return 0
+	}
+	if p.Jitter {
+		rng := p.rng
+		if rng == nil {
+			rng = rand.Int63n
+		}
+		delay = time.Duration(rng(int64(delay) + 1))
+	}
+	return delay
+}
+
+// RetryAttempt records one retried request attempt: the failure that
+// triggered the retry and how long the client waited before retrying.
+type RetryAttempt struct {
+	Reason string
+	Delay  time.Duration
+}
+
+type retryRecorderKey struct{}
+
+// WithRetryRecorder returns a copy of ctx under which the Retry middleware
+// appends every retried attempt to the returned slice, so that callers can
+// surface retry activity after a request completes.
+func WithRetryRecorder(ctx context.Context) (context.Context, *[]RetryAttempt) {
+	attempts := &[]RetryAttempt{}
+	return context.WithValue(ctx, retryRecorderKey{}, attempts), attempts
+}
+
+// Retry produces a RoundTripper that retries idempotent requests which fail
+// with a connection error or a retryable server status, sleeping between
+// attempts according to the policy.
+func Retry(policy RetryPolicy) ClientOption {
+	return func(tr http.RoundTripper) http.RoundTripper {
+		return &funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+			if policy.MaxRetries < 1 || !isIdempotent(req) {
+				return tr.RoundTrip(req)
+			}
+
+			sleep := policy.sleep
+			if sleep == nil {
+				sleep = time.Sleep
+			}
+
+			var res *http.Response
+			var err error
+			for attempt := 0; ; attempt++ {
+				res, err = tr.RoundTrip(req)
+				if attempt >= policy.MaxRetries {
+					break
+				}
+				if err == nil && !retryableStatus(res.StatusCode) {
+					break
+				}
+				if req.Context().Err() != nil {
+					break
+				}
+				if res != nil {
+					// drain the failed response so its connection can be reused
+					_, _ = io.Copy(io.Discard, res.Body)
+					res.Body.Close()
+				}
+				delay := policy.backoff(attempt)
+				if attempts, ok := req.Context().Value(retryRecorderKey{}).(*[]RetryAttempt); ok {
+					var reason string
+					if err != nil {
+						reason = err.Error()
+					} else {
+						reason = fmt.Sprintf("HTTP %d", res.StatusCode)
+					}
+					*attempts = append(*attempts, RetryAttempt{Reason: reason, Delay: delay})
+				}
+				sleep(delay)
+			}
+			return res, err
+		}}
+	}
+}
+
+func isIdempotent(req *http.Request) bool {
+	return req.Method == "GET" || req.Method == "HEAD"
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_RetryPolicy_backoff_noJitter(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 5,
+		Base:       100 * time.Millisecond,
+		Cap:        800 * time.Millisecond,
+	}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		800 * time.Millisecond,
+	}
+	for attempt, w := range want {
+		if got := policy.backoff(attempt); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+}
+
+func Test_RetryPolicy_backoff_fullJitter(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 4,
+		Base:       100 * time.Millisecond,
+		Cap:        800 * time.Millisecond,
+		Jitter:     true,
+		rng:        rand.New(rand.NewSource(1)).Int63n,
+	}
+
+	// with full jitter each delay is drawn uniformly from [0, bound], where
+	// the bounds follow the exponential sequence base, 2*base, ... up to cap
+	expected := rand.New(rand.NewSource(1))
+	bounds := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+	}
+	for attempt, bound := range bounds {
+		want := time.Duration(expected.Int63n(int64(bound) + 1))
+		got := policy.backoff(attempt)
+		if got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+		if got < 0 || got > bound {
+			t.Errorf("backoff(%d) = %v, outside [0, %v]", attempt, got, bound)
+		}
+	}
+}
+
+func Test_Retry_serverErrors(t *testing.T) {
+	var attempts int
+	tr := funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		attempts++
+		status := http.StatusServiceUnavailable
+		if attempts == 3 {
+			status = http.StatusOK
+		}
+		return &http.Response{
+			StatusCode: status,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}}
+
+	var slept []time.Duration
+	policy := RetryPolicy{
+		MaxRetries: 3,
+		Base:       100 * time.Millisecond,
+		Cap:        800 * time.Millisecond,
+		sleep:      func(d time.Duration) { slept = append(slept, d) },
+	}
+
+	client := NewHTTPClient(ReplaceTripper(tr), Retry(policy))
+	res, err := client.Get("https://instill.tech/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	wantSlept := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if len(slept) != len(wantSlept) {
+		t.Fatalf("slept %v times, want %v", len(slept), len(wantSlept))
+	}
+	for i, w := range wantSlept {
+		if slept[i] != w {
+			t.Errorf("sleep %d = %v, want %v", i, slept[i], w)
+		}
+	}
+}
+
+func Test_Retry_recordsAttempts(t *testing.T) {
+	var attempts int
+	tr := funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 2 {
+			return nil, errors.New("connection reset")
+		}
+		status := http.StatusServiceUnavailable
+		if attempts == 3 {
+			status = http.StatusOK
+		}
+		return &http.Response{
+			StatusCode: status,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}}
+
+	policy := RetryPolicy{
+		MaxRetries: 3,
+		Base:       100 * time.Millisecond,
+		Cap:        800 * time.Millisecond,
+		sleep:      func(time.Duration) {},
+	}
+
+	ctx, recorded := WithRetryRecorder(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://instill.tech/health", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewHTTPClient(ReplaceTripper(tr), Retry(policy))
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	want := []RetryAttempt{
+		{Reason: "HTTP 503", Delay: 100 * time.Millisecond},
+		{Reason: "connection reset", Delay: 200 * time.Millisecond},
+	}
+	if len(*recorded) != len(want) {
+		t.Fatalf("recorded %d attempts, want %d", len(*recorded), len(want))
+	}
+	for i, w := range want {
+		if (*recorded)[i] != w {
+			t.Errorf("attempt %d = %+v, want %+v", i, (*recorded)[i], w)
+		}
+	}
+}
+
+func Test_Retry_connectionError(t *testing.T) {
+	var attempts int
+	tr := funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errors.New("connection reset")
+	}}
+
+	policy := RetryPolicy{
+		MaxRetries: 2,
+		Base:       time.Millisecond,
+		Cap:        time.Millisecond,
+		sleep:      func(time.Duration) {},
+	}
+
+	client := NewHTTPClient(ReplaceTripper(tr), Retry(policy))
+	//nolint:bodyclose // the stubbed transport never returns a response
+	_, err := client.Get("https://instill.tech/health")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func Test_Retry_nonIdempotent(t *testing.T) {
+	var attempts int
+	tr := funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}}
+
+	policy := DefaultRetryPolicy()
+	policy.sleep = func(time.Duration) { t.Error("sleep should not be called") }
+
+	client := NewHTTPClient(ReplaceTripper(tr), Retry(policy))
+	res, err := client.Post("https://instill.tech/health", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2/terminal"
@@ -63,6 +66,17 @@ func mainRun() exitCode {
 
 	hasDebug := os.Getenv("DEBUG") != ""
 
+	// honor --config-dir and --config before any configuration is read
+	if dir := earlyFlagValue(os.Args[1:], "--config-dir"); dir != "" {
+		config.SetConfigDir(dir)
+	}
+	if file := earlyFlagValue(os.Args[1:], "--config"); file != "" {
+		config.SetConfigFile(file)
+	}
+	if profile := earlyFlagValue(os.Args[1:], "--profile"); profile != "" {
+		config.SetProfile(profile)
+	}
+
 	cmdFactory := factory.New(buildVersion)
 	stderr := cmdFactory.IOStreams.ErrOut
 
@@ -97,6 +111,12 @@ func mainRun() exitCode {
 
 	cfg, err := cmdFactory.Config()
 	if err != nil {
+		var invalidConfig *config.InvalidConfigError
+		if errors.As(err, &invalidConfig) && invalidConfig.Filename != "" {
+			fmt.Fprintf(stderr, "your config file at %s is invalid: %s\n", invalidConfig.Filename, invalidConfig.Err)
+			fmt.Fprintln(stderr, "To fix it, run `inst config edit`; `inst config doctor` can help diagnose the problem.")
+			return exitError
+		}
 		fmt.Fprintf(stderr, "failed to read configuration:  %s\n", err)
 		return exitError
 	}
@@ -111,10 +131,54 @@ func mainRun() exitCode {
 		expandedArgs = []string{expandedArgs[1], "--help"}
 	}
 
+	// expand a matching alias before cobra dispatch
+	if newArgs, expanded, err := root.ExpandAlias(cfg, expandedArgs); err != nil {
+		fmt.Fprintf(stderr, "failed to expand alias: %s\n", err)
+		return exitError
+	} else if expanded {
+		expandedArgs = newArgs
+	}
+
 	cs := cmdFactory.IOStreams.ColorScheme()
 
 	authError := errors.New("authError")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// `--interactive`/`--no-interactive` override terminal auto-detection
+		// for prompts, color, and spinners, e.g. in CI runners or tmux
+		// sessions where detection is wrong
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		noInteractive, _ := cmd.Flags().GetBool("no-interactive")
+		if interactive && noInteractive {
+			return cmdutil.FlagErrorf("only one of `--interactive` or `--no-interactive` may be used")
+		}
+		if interactive || noInteractive {
+			cmdFactory.IOStreams.SetInteractive(interactive)
+		}
+
+		// `--no-prompt` forces non-interactive behavior regardless of the
+		// `prompt` config
+		if noPrompt, _ := cmd.Flags().GetBool("no-prompt"); noPrompt {
+			cmdFactory.IOStreams.SetNeverPrompt(true)
+		}
+
+		// `--quiet` silences informational stderr output; combined with
+		// `--no-prompt` it makes a command fully non-interactive and silent
+		if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+			cmdFactory.IOStreams.SetQuiet(true)
+		}
+
+		// `--log-format json` emits diagnostics as JSON lines for log collectors
+		if logFormat, _ := cmd.Flags().GetString("log-format"); logFormat != "" {
+			if err := cmdFactory.IOStreams.SetLogFormat(logFormat); err != nil {
+				return cmdutil.FlagErrorWrap(err)
+			}
+		}
+
+		// surface likely typos in the config file
+		for _, key := range cfg.UnknownKeys() {
+			cmdFactory.IOStreams.LogWarningf("warning: unknown config key %q", key)
+		}
+
 		// require that the user is authenticated before running most commands
 		if cmdutil.IsAuthCheckEnabled(cmd) && !cmdutil.CheckAuth(cfg) {
 			fmt.Fprintln(stderr, cs.Bold("Welcome to Instill CLI!"))
@@ -128,9 +192,26 @@ func mainRun() exitCode {
 
 	rootCmd.SetArgs(expandedArgs)
 
-	if cmd, err := rootCmd.ExecuteC(); err != nil {
+	// cancel in-flight HTTP requests on Ctrl-C or SIGTERM; the context is
+	// threaded through cobra into commands that make network calls
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if ctx.Err() != nil {
+		// an interrupt may have left the cursor hidden or colors enabled
+		cmdFactory.IOStreams.RestoreTerminal()
+	}
+	if err != nil {
+		var statusErr cmdutil.HTTPStatusError
 		if err == cmdutil.SilentError {
 			return exitError
+		} else if errors.As(err, &statusErr) {
+			// the error message was already printed by the command
+			return exitCode(statusErr.ExitCode())
+		} else if errors.Is(err, context.Canceled) {
+			fmt.Fprint(stderr, "\n")
+			return exitCancel
 		} else if cmdutil.IsUserCancellation(err) {
 			if errors.Is(err, terminal.InterruptErr) {
 				// ensure the next shell prompt will start on its own line
@@ -181,6 +262,20 @@ func mainRun() exitCode {
 	return exitOK
 }
 
+// earlyFlagValue extracts the value of a string flag before cobra parsing, so
+// it can influence process setup that happens ahead of command dispatch.
+func earlyFlagValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, name+"=") {
+			return strings.TrimPrefix(arg, name+"=")
+		}
+	}
+	return ""
+}
+
 // hasCommand returns true if args resolve to a built-in command
 func hasCommand(rootCmd *cobra.Command, args []string) bool {
 	c, _, err := rootCmd.Traverse(args)
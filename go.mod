@@ -37,7 +37,17 @@ require (
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require github.com/cheggaaa/pb v1.0.29 // indirect
+require (
+	github.com/cheggaaa/pb v1.0.29
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+	github.com/zalando/go-keyring v0.2.3
+)
+
+require (
+	github.com/alessio/shellescape v1.4.1 // indirect
+	github.com/danieljoos/wincred v1.2.0 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
+)
 
 require (
 	github.com/alecthomas/chroma/v2 v2.9.1 // indirect
@@ -75,7 +85,7 @@ require (
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
 	github.com/microcosm-cc/bluemonday v1.0.25 // indirect
-	github.com/pelletier/go-toml/v2 v2.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.1.0
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.4 // indirect
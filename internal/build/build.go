@@ -10,6 +10,13 @@ var Version = "DEV"
 // Date is dynamically set at build time in the Makefile.
 var Date = "" // YYYY-MM-DD
 
+// ExecutableName is the command name shown in usage strings, examples, and
+// generated completions. Downstream distributions that ship the binary under
+// a different name can override it at build time, e.g.
+//
+//	go build -ldflags "-X github.com/instill-ai/cli/internal/build.ExecutableName=mycli"
+var ExecutableName = "inst"
+
 func init() {
 	if Version == "DEV" {
 		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "(devel)" {
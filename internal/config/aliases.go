@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Aliases returns the command aliases stored under the top-level "aliases"
+// section of the config file.
+func (c *fileConfig) Aliases() (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, err := c.FindEntry("aliases")
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	aliases := map[string]string{}
+	content := entry.ValueNode.Content
+	for i := 0; i < len(content)-1; i += 2 {
+		aliases[content[i].Value] = content[i+1].Value
+	}
+	return aliases, nil
+}
+
+// SetAlias adds or updates a command alias.
+func (c *fileConfig) SetAlias(alias, expansion string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.FindEntry("aliases")
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		keyNode := &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Value: "aliases",
+		}
+		valueNode := &yaml.Node{Kind: yaml.MappingNode}
+		c.Root().Content = append(c.Root().Content, keyNode, valueNode)
+		entry = &ConfigEntry{KeyNode: keyNode, ValueNode: valueNode}
+	} else if err != nil {
+		return err
+	}
+
+	aliasesMap := ConfigMap{Root: entry.ValueNode}
+	// alias names may contain dots, so bypass the dotted-path handling of
+	// SetStringValue
+	return aliasesMap.SetEntry([]string{alias}, expansion)
+}
+
+// DeleteAlias removes a command alias, erroring when it does not exist.
+func (c *fileConfig) DeleteAlias(alias string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.FindEntry("aliases")
+	if err != nil {
+		return fmt.Errorf("no such alias %q", alias)
+	}
+
+	aliasesMap := ConfigMap{Root: entry.ValueNode}
+	if _, err := aliasesMap.FindEntry(alias); err != nil {
+		return fmt.Errorf("no such alias %q", alias)
+	}
+	aliasesMap.RemoveEntry(alias)
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliases(t *testing.T) {
+	cfg := MustNewFromString(`---
+aliases:
+  pl: api vdp/v1alpha/pipelines
+`)
+
+	aliases, err := cfg.Aliases()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"pl": "api vdp/v1alpha/pipelines"}, aliases)
+}
+
+func TestAliasesEmpty(t *testing.T) {
+	cfg := MustNewFromString(`editor: vim`)
+	aliases, err := cfg.Aliases()
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+}
+
+func TestSetAlias(t *testing.T) {
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	cfg := MustNewFromString(`editor: vim`)
+	assert.NoError(t, cfg.SetAlias("ml", "api model/v1alpha/models"))
+	assert.NoError(t, cfg.SetAlias("ml", "api model/v1alpha/models?visibility=public"))
+
+	aliases, err := cfg.Aliases()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ml": "api model/v1alpha/models?visibility=public"}, aliases)
+
+	assert.NoError(t, cfg.Write())
+	assert.Contains(t, mainBuf.String(), "aliases:")
+	assert.Contains(t, mainBuf.String(), "ml: api model/v1alpha/models?visibility=public")
+}
+
+func TestDeleteAlias(t *testing.T) {
+	cfg := MustNewFromString(`---
+aliases:
+  pl: api vdp/v1alpha/pipelines
+`)
+
+	assert.NoError(t, cfg.DeleteAlias("pl"))
+	aliases, err := cfg.Aliases()
+	assert.NoError(t, err)
+	assert.Empty(t, aliases)
+
+	assert.EqualError(t, cfg.DeleteAlias("nope"), `no such alias "nope"`)
+}
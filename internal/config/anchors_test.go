@@ -0,0 +1,33 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileConfig_Write_preservesAnchors(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/anchors.yml")
+	require.NoError(t, err)
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	c := MustNewFromString(string(fixture))
+
+	// a no-op Set of an existing value must not expand anchors or aliases
+	// elsewhere in the tree
+	assert.NoError(t, c.Set("", "editor", "vim"))
+	assert.NoError(t, c.Write())
+
+	assert.Equal(t, "editor: &ed vim\npager: *ed\n", mainBuf.String())
+	assert.Equal(t, `one.example.com: &defaults
+    protocol: https
+    api_hostname: one.example.com
+two.example.com: *defaults
+`, hostsBuf.String())
+}
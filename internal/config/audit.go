@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"os/user"
+	"time"
+)
+
+// auditEntry is one JSON line in the audit log enabled by the `audit_log`
+// config key. Secret values are redacted before being recorded.
+type auditEntry struct {
+	Time     string `json:"time"`
+	User     string `json:"user"`
+	Action   string `json:"action"`
+	Hostname string `json:"hostname,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+}
+
+// audit appends one entry to the audit log when the `audit_log` key points at
+// a file. Callers already hold c.mu, so the path is read through the unlocked
+// getter.
+func (c *fileConfig) audit(entry auditEntry) {
+	if filename, _ := c.get("", "audit_log"); filename != "" {
+		appendAuditEntry(filename, entry)
+	}
+}
+
+// appendAuditEntry stamps the entry with the current time and user and
+// appends it to the log. Logging is best effort: any failure is swallowed so
+// an unwritable log never blocks the config operation it records.
+func appendAuditEntry(filename string, entry auditEntry) {
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	entry.User = currentUsername()
+	if IsSensitiveKey(entry.Key) {
+		entry.Old = RedactSecret(entry.Old)
+		entry.New = RedactSecret(entry.New)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// currentUsername identifies the invoking user for audit entries, falling
+// back to the USER environment variable when the lookup fails.
+func currentUsername() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
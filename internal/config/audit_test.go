@@ -0,0 +1,114 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditEntries(t *testing.T, filename string) []auditEntry {
+	t.Helper()
+	f, err := os.Open(filename)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func Test_auditLog_set(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	cfg := MustNewFromString(heredoc.Docf(`
+		audit_log: %s
+		editor: ed
+	`, logFile))
+
+	require.NoError(t, cfg.Set("", "editor", "vim"))
+	require.NoError(t, cfg.Set("example.com", "access_token", "very-secret-token-abcd"))
+
+	entries := readAuditEntries(t, logFile)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "set", entries[0].Action)
+	assert.Equal(t, "editor", entries[0].Key)
+	assert.Equal(t, "ed", entries[0].Old)
+	assert.Equal(t, "vim", entries[0].New)
+	assert.NotEmpty(t, entries[0].User)
+	_, err := time.Parse(time.RFC3339, entries[0].Time)
+	assert.NoError(t, err)
+
+	// the secret never reaches the log in the clear
+	assert.Equal(t, "example.com", entries[1].Hostname)
+	assert.Equal(t, "access_token", entries[1].Key)
+	assert.Equal(t, "********abcd", entries[1].New)
+}
+
+func Test_auditLog_unsetHost(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	cfg := MustNewFromString(heredoc.Docf(`
+		audit_log: %s
+		hosts:
+		    example.com:
+		        api_version: v1alpha
+	`, logFile))
+
+	cfg.UnsetHost("example.com")
+
+	entries := readAuditEntries(t, logFile)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "unset_host", entries[0].Action)
+	assert.Equal(t, "example.com", entries[0].Hostname)
+}
+
+func Test_auditLog_saveTyped(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	defer stubConfig(fmt.Sprintf("audit_log: %s\n", logFile), "")()
+	cfg, err := ParseDefaultConfig()
+	require.NoError(t, err)
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	host := DefaultHostConfig()
+	host.APIHostname = "example.com"
+	require.NoError(t, cfg.SaveTyped(&host))
+
+	entries := readAuditEntries(t, logFile)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "save_host", entries[0].Action)
+	assert.Equal(t, "example.com", entries[0].Hostname)
+}
+
+func Test_auditLog_bestEffort(t *testing.T) {
+	// an unwritable log must never block the operation it records
+	cfg := MustNewFromString(heredoc.Doc(`
+		audit_log: /nonexistent-dir/audit.log
+	`))
+	assert.NoError(t, cfg.Set("", "editor", "vim"))
+}
+
+func Test_auditLog_disabledByDefault(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	cfg := NewBlankConfig()
+	require.NoError(t, cfg.Set("", "editor", "vim"))
+
+	_, err := os.Stat(logFile)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -21,24 +21,40 @@ const (
 	LOCAL_APP_DATA     = "LocalAppData"
 )
 
+// configDirOverride takes precedence over any environment variable; it is set
+// from the --config-dir flag.
+var configDirOverride string
+
+// SetConfigDir overrides the config directory for the rest of the process.
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
+// configFileOverride pins the main config file to one exact path; it is set
+// from the --config flag and wins over --config-dir and any environment
+// variable.
+var configFileOverride string
+
+// SetConfigFile overrides the main config file path for the rest of the
+// process. Writes go back to the same file.
+func SetConfigFile(path string) {
+	configFileOverride = path
+}
+
 // ConfigDir returns config dirpath with precedence:
-// 1. INSTILL_CONFIG_DIR
-// 2. XDG_CONFIG_HOME
-// 3. AppData (windows only)
-// 4. HOME
+// 1. --config-dir flag (via SetConfigDir)
+// 2. INSTILL_CONFIG_DIR
+// 3. XDG_CONFIG_HOME
+// 4. AppData (windows only)
+// 5. Library/Application Support (macOS only)
+// 6. HOME
 func ConfigDir() string {
-	var path string
-	if a := os.Getenv(INSTILL_CONFIG_DIR); a != "" {
-		path = a
-	} else if b := os.Getenv(XDG_CONFIG_HOME); b != "" {
-		path = filepath.Join(b, "instill")
-	} else if c := os.Getenv(APP_DATA); runtime.GOOS == "windows" && c != "" {
-		path = filepath.Join(c, "Instill CLI")
-	} else {
-		d, _ := os.UserHomeDir()
-		path = filepath.Join(d, ".config", "instill")
+	if configDirOverride != "" {
+		return configDirOverride
 	}
 
+	path := configDirForPlatform(runtime.GOOS)
+
 	// If the path does not exist and the INSTILL_CONFIG_DIR flag is not set try
 	// migrating config from default paths.
 	if !dirExists(path) && os.Getenv(INSTILL_CONFIG_DIR) == "" {
@@ -48,6 +64,28 @@ func ConfigDir() string {
 	return path
 }
 
+// configDirForPlatform resolves the config directory for the given platform.
+// Explicit overrides (INSTILL_CONFIG_DIR, then XDG_CONFIG_HOME) win on every
+// platform; otherwise the platform's conventional location is used: %AppData%
+// on Windows, ~/Library/Application Support on macOS, and ~/.config elsewhere.
+// The platform is a parameter so each branch can be exercised in tests.
+func configDirForPlatform(goos string) string {
+	if a := os.Getenv(INSTILL_CONFIG_DIR); a != "" {
+		return a
+	}
+	if b := os.Getenv(XDG_CONFIG_HOME); b != "" {
+		return filepath.Join(b, "instill")
+	}
+	if c := os.Getenv(APP_DATA); goos == "windows" && c != "" {
+		return filepath.Join(c, "Instill CLI")
+	}
+	home, _ := os.UserHomeDir()
+	if goos == "darwin" {
+		return filepath.Join(home, "Library", "Application Support", "instill")
+	}
+	return filepath.Join(home, ".config", "instill")
+}
+
 // StateDir returns state dirpath with precedence:
 // 1. XDG_CONFIG_HOME
 // 2. LocalAppData (windows only)
@@ -153,12 +191,29 @@ func fileExists(path string) bool {
 	return err == nil && !f.IsDir()
 }
 
+// ConfigFile returns the path of the main config file. YAML is the default
+// format; an existing config.toml is picked up when no config.yml is present.
 func ConfigFile() string {
-	return filepath.Join(ConfigDir(), "config.yml")
+	if configFileOverride != "" {
+		return configFileOverride
+	}
+	dir := ConfigDir()
+	yml := filepath.Join(dir, "config.yml")
+	if toml := filepath.Join(dir, "config.toml"); !fileExists(yml) && fileExists(toml) {
+		return toml
+	}
+	return yml
 }
 
+// HostsConfigFile returns the path of the per-host config file, applying the
+// same format detection as ConfigFile.
 func HostsConfigFile() string {
-	return filepath.Join(ConfigDir(), "hosts.yml")
+	dir := ConfigDir()
+	yml := filepath.Join(dir, "hosts.yml")
+	if toml := filepath.Join(dir, "hosts.toml"); !fileExists(yml) && fileExists(toml) {
+		return toml
+	}
+	return yml
 }
 
 func ParseDefaultConfig() (Config, error) {
@@ -200,15 +255,36 @@ var BackupConfigFile = func(filename string) error {
 	return os.Rename(filename, filename+".bak")
 }
 
+// InvalidConfigError reports a configuration that could not be parsed, so
+// callers can distinguish a corrupt config file from other failures and point
+// the user at the file to fix.
+type InvalidConfigError struct {
+	// Filename is the path of the offending file; empty when the
+	// configuration did not come from a file.
+	Filename string
+	Err      error
+}
+
+func (e *InvalidConfigError) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("invalid configuration: %s", e.Err)
+	}
+	return fmt.Sprintf("invalid config file %s: %s", e.Filename, e.Err)
+}
+
+func (e *InvalidConfigError) Unwrap() error {
+	return e.Err
+}
+
 func parseConfigFile(filename string) ([]byte, *yaml.Node, error) {
 	data, err := ReadConfigFile(filename)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	root, err := parseConfigData(data)
+	root, err := parseConfigDataForFile(filename, data)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &InvalidConfigError{Filename: filename, Err: err}
 	}
 	return data, root, err
 }
@@ -311,6 +387,8 @@ func parseConfig(filename string) (Config, error) {
 		}
 	}
 
+	normalizeDeprecatedKeys(root, deprecationWarnOut)
+
 	return NewConfig(root), nil
 }
 
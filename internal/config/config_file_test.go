@@ -174,7 +174,7 @@ func Test_ConfigDir(t *testing.T) {
 				"USERPROFILE":        tempDir,
 				"HOME":               tempDir,
 			},
-			output: filepath.Join(tempDir, ".config", "instill"),
+			output: homeConfigDirFor(runtime.GOOS, tempDir),
 		},
 		{
 			name: "INSTILL_CONFIG_DIR specified",
@@ -248,13 +248,123 @@ func Test_ConfigDir(t *testing.T) {
 	}
 }
 
+// homeConfigDirFor mirrors the per-platform fallback under the home directory.
+func homeConfigDirFor(goos, home string) string {
+	if goos == "darwin" {
+		return filepath.Join(home, "Library", "Application Support", "instill")
+	}
+	return filepath.Join(home, ".config", "instill")
+}
+
+func Test_configDirForPlatform(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		goos   string
+		env    map[string]string
+		output string
+	}{
+		{
+			name: "INSTILL_CONFIG_DIR wins on every platform",
+			goos: "windows",
+			env: map[string]string{
+				"INSTILL_CONFIG_DIR": filepath.Join(tempDir, "override"),
+				"XDG_CONFIG_HOME":    tempDir,
+				"AppData":            tempDir,
+			},
+			output: filepath.Join(tempDir, "override"),
+		},
+		{
+			name: "XDG_CONFIG_HOME beats AppData",
+			goos: "windows",
+			env: map[string]string{
+				"XDG_CONFIG_HOME": tempDir,
+				"AppData":         tempDir,
+			},
+			output: filepath.Join(tempDir, "instill"),
+		},
+		{
+			name: "AppData on windows",
+			goos: "windows",
+			env: map[string]string{
+				"AppData": tempDir,
+			},
+			output: filepath.Join(tempDir, "Instill CLI"),
+		},
+		{
+			name: "AppData is ignored off windows",
+			goos: "linux",
+			env: map[string]string{
+				"AppData": tempDir,
+				"HOME":    tempDir,
+			},
+			output: filepath.Join(tempDir, ".config", "instill"),
+		},
+		{
+			name: "Application Support on macOS",
+			goos: "darwin",
+			env: map[string]string{
+				"HOME": tempDir,
+			},
+			output: filepath.Join(tempDir, "Library", "Application Support", "instill"),
+		},
+		{
+			name: "XDG_CONFIG_HOME on linux",
+			goos: "linux",
+			env: map[string]string{
+				"XDG_CONFIG_HOME": tempDir,
+			},
+			output: filepath.Join(tempDir, "instill"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"INSTILL_CONFIG_DIR", "XDG_CONFIG_HOME", "AppData", "HOME", "USERPROFILE"} {
+				t.Setenv(k, tt.env[k])
+			}
+			assert.Equal(t, tt.output, configDirForPlatform(tt.goos))
+		})
+	}
+}
+
+func Test_SetConfigDir(t *testing.T) {
+	defer SetConfigDir("")
+
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dirA, "config.yml"), []byte("editor: vim\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirB, "config.yml"), []byte("editor: emacs\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetConfigDir(dirA)
+	assert.Equal(t, dirA, ConfigDir())
+	cfg, err := ParseDefaultConfig()
+	assert.NoError(t, err)
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	SetConfigDir(dirB)
+	assert.Equal(t, dirB, ConfigDir())
+	cfg, err = ParseDefaultConfig()
+	assert.NoError(t, err)
+	val, err = cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "emacs", val)
+}
+
 func Test_configFile_Write_toDisk(t *testing.T) {
 	configDir := filepath.Join(t.TempDir(), ".config", "instill")
 	_ = os.MkdirAll(configDir, 0755)
 	os.Setenv(INSTILL_CONFIG_DIR, configDir)
 	defer os.Unsetenv(INSTILL_CONFIG_DIR)
 
-	cfg := NewFromString(`pager: less`)
+	cfg := MustNewFromString(`pager: less`)
 	err := cfg.Write()
 	if err != nil {
 		t.Fatal(err)
@@ -554,3 +664,48 @@ func Test_DataDir(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetConfigFile(t *testing.T) {
+	defer SetConfigFile("")
+	defer SetConfigDir("")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), []byte("editor: vim\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	exactFile := filepath.Join(t.TempDir(), "my-settings.yml")
+	if err := os.WriteFile(exactFile, []byte("editor: emacs\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// --config wins over --config-dir
+	SetConfigDir(dir)
+	SetConfigFile(exactFile)
+	assert.Equal(t, exactFile, ConfigFile())
+
+	cfg, err := ParseDefaultConfig()
+	assert.NoError(t, err)
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "emacs", val)
+
+	// writes go back to the exact file
+	assert.NoError(t, cfg.Set("", "editor", "nano"))
+	assert.NoError(t, cfg.Write())
+	data, err := os.ReadFile(exactFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "editor: nano")
+}
+
+func Test_SetConfigFile_invalidFile(t *testing.T) {
+	defer SetConfigFile("")
+
+	exactFile := filepath.Join(t.TempDir(), "broken.yml")
+	if err := os.WriteFile(exactFile, []byte("editor: [unclosed\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetConfigFile(exactFile)
+	_, err := ParseDefaultConfig()
+	assert.ErrorContains(t, err, "invalid config file "+exactFile)
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -27,8 +29,10 @@ func (cm *ConfigMap) Empty() bool {
 	return cm.Root == nil || len(cm.Root.Content) == 0
 }
 
+// GetStringValue returns the value for a key; a dotted key such as
+// "git_protocol.push" traverses nested mappings.
 func (cm *ConfigMap) GetStringValue(key string) (string, error) {
-	entry, err := cm.FindEntry(key)
+	entry, err := cm.FindEntry(strings.Split(key, ".")...)
 	if err != nil {
 		return "", err
 	}
@@ -43,57 +47,123 @@ func (cm *ConfigMap) GetOptionalStringValue(key string) string {
 	return entry.ValueNode.Value
 }
 
+// SetStringValue writes the value for a key; a dotted key such as
+// "git_protocol.push" traverses nested mappings, creating them as needed.
 func (cm *ConfigMap) SetStringValue(key, value string) error {
-	entry, err := cm.FindEntry(key)
+	return cm.SetEntry(strings.Split(key, "."), value)
+}
 
-	var notFound *NotFoundError
+// SetEntry writes a value at a key path, creating intermediate mappings as
+// needed. It errors when an intermediate segment is already set to a scalar
+// value, or when the final segment addresses an existing mapping.
+func (cm *ConfigMap) SetEntry(path []string, value string) error {
+	if len(path) == 0 {
+		return errors.New("empty key path")
+	}
 
-	valueNode := entry.ValueNode
+	current := cm.Root
+	for depth, key := range path {
+		var valueNode *yaml.Node
+		content := current.Content
+		for i := 0; i < len(content)-1; i += 2 {
+			if content[i].Value == key {
+				valueNode = content[i+1]
+				break
+			}
+		}
 
-	if err != nil && errors.As(err, &notFound) {
-		keyNode := &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: key,
+		last := depth == len(path)-1
+		if valueNode == nil {
+			keyNode := &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Value: key,
+			}
+			if last {
+				valueNode = &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!str",
+					Value: "",
+				}
+			} else {
+				valueNode = &yaml.Node{
+					Kind: yaml.MappingNode,
+					Tag:  "!!map",
+				}
+			}
+			current.Content = append(current.Content, keyNode, valueNode)
 		}
-		valueNode = &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Tag:   "!!str",
-			Value: "",
+
+		if last {
+			if valueNode.Kind == yaml.MappingNode {
+				return fmt.Errorf("cannot set %q: it holds a mapping, not a value", strings.Join(path, "."))
+			}
+			valueNode.Value = value
+			return nil
 		}
 
-		cm.Root.Content = append(cm.Root.Content, keyNode, valueNode)
-	} else if err != nil {
-		return err
+		// an empty placeholder like "section:" parses as a null scalar; treat
+		// it as a mapping we can descend into
+		if valueNode.Kind == yaml.ScalarNode && valueNode.Tag == "!!null" {
+			valueNode.Kind = yaml.MappingNode
+			valueNode.Tag = "!!map"
+			valueNode.Value = ""
+		}
+		if valueNode.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %q: %q is already set to a value", strings.Join(path, "."), strings.Join(path[:depth+1], "."))
+		}
+		current = valueNode
 	}
 
-	valueNode.Value = value
-
 	return nil
 }
 
-func (cm *ConfigMap) FindEntry(key string) (ce *ConfigEntry, err error) {
-	err = nil
-
+// FindEntry locates a key in the mapping. Additional path segments descend
+// into nested mappings, so FindEntry("git_protocol", "push") resolves the
+// dotted key "git_protocol.push".
+func (cm *ConfigMap) FindEntry(path ...string) (ce *ConfigEntry, err error) {
 	ce = &ConfigEntry{}
+	if len(path) == 0 {
+		return ce, &NotFoundError{errors.New("not found")}
+	}
 
-	// Content slice goes [key1, value1, key2, value2, ...]
-	topLevelPairs := cm.Root.Content
-	for i, v := range topLevelPairs {
-		// Skip every other slice item since we only want to check against keys
-		if i%2 != 0 {
-			continue
+	current := cm.Root
+	for depth, key := range path {
+		found := false
+		// Content slice goes [key1, value1, key2, value2, ...]
+		content := current.Content
+		for i, v := range content {
+			// Skip every other slice item since we only want to check against keys
+			if i%2 != 0 {
+				continue
+			}
+			if v.Value == key {
+				ce.KeyNode = v
+				ce.Index = i
+				ce.ValueNode = nil
+				if i+1 < len(content) {
+					ce.ValueNode = content[i+1]
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ce, &NotFoundError{errors.New("not found")}
 		}
-		if v.Value == key {
-			ce.KeyNode = v
-			ce.Index = i
-			if i+1 < len(topLevelPairs) {
-				ce.ValueNode = topLevelPairs[i+1]
+
+		if depth < len(path)-1 {
+			if ce.ValueNode != nil && ce.ValueNode.Kind == yaml.ScalarNode && ce.ValueNode.Tag == "!!null" {
+				// an empty placeholder like "section:" holds no entries yet
+				return ce, &NotFoundError{errors.New("not found")}
+			}
+			if ce.ValueNode == nil || ce.ValueNode.Kind != yaml.MappingNode {
+				return ce, fmt.Errorf("expected %q to be a mapping, found a value", strings.Join(path[:depth+1], "."))
 			}
-			return
+			current = ce.ValueNode
 		}
 	}
 
-	return ce, &NotFoundError{errors.New("not found")}
+	return ce, nil
 }
 
 func (cm *ConfigMap) RemoveEntry(key string) {
@@ -61,3 +61,144 @@ same: logical
 	_ = yaml.Unmarshal([]byte(data), &root)
 	return root.Content[0]
 }
+
+func TestFindEntry_path(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []string
+		output  string
+		wantErr string
+	}{
+		{
+			name:   "nested key",
+			path:   []string{"section", "inner"},
+			output: "nested",
+		},
+		{
+			name:    "missing nested key",
+			path:    []string{"section", "missing"},
+			wantErr: "not found",
+		},
+		{
+			name:    "missing section",
+			path:    []string{"missing", "inner"},
+			wantErr: "not found",
+		},
+		{
+			name:    "scalar where a mapping is expected",
+			path:    []string{"valid", "inner"},
+			wantErr: `expected "valid" to be a mapping, found a value`,
+		},
+		{
+			name:    "empty section placeholder",
+			path:    []string{"blank", "inner"},
+			wantErr: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		cm := ConfigMap{Root: testNestedYaml()}
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := cm.FindEntry(tt.path...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, out.ValueNode.Value)
+		})
+	}
+}
+
+func TestSetEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "flat key",
+			path:  []string{"valid"},
+			value: "replaced",
+			want:  "blank:\nsection:\n    inner: nested\nvalid: replaced\n",
+		},
+		{
+			name:  "existing nested key",
+			path:  []string{"section", "inner"},
+			value: "replaced",
+			want:  "blank:\nsection:\n    inner: replaced\nvalid: present\n",
+		},
+		{
+			name:  "new nested key creates intermediate mappings",
+			path:  []string{"new", "deeply", "nested"},
+			value: "made",
+			want:  "blank:\nsection:\n    inner: nested\nvalid: present\nnew:\n    deeply:\n        nested: made\n",
+		},
+		{
+			name:  "empty section placeholder becomes a mapping",
+			path:  []string{"blank", "inner"},
+			value: "filled",
+			want:  "blank:\n    inner: filled\nsection:\n    inner: nested\nvalid: present\n",
+		},
+		{
+			name:    "scalar where a mapping is expected",
+			path:    []string{"valid", "inner"},
+			value:   "x",
+			wantErr: `cannot set "valid.inner": "valid" is already set to a value`,
+		},
+		{
+			name:    "mapping where a value is expected",
+			path:    []string{"section"},
+			value:   "x",
+			wantErr: `cannot set "section": it holds a mapping, not a value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := testNestedYaml()
+			cm := ConfigMap{Root: root}
+			err := cm.SetEntry(tt.path, tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			out, err := yaml.Marshal(root)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(out))
+		})
+	}
+}
+
+func Test_fileConfig_dottedKeys(t *testing.T) {
+	cfg := MustNewFromString("valid: present\n")
+
+	assert.NoError(t, cfg.Set("", "git_protocol.push", "ssh"))
+
+	val, err := cfg.Get("", "git_protocol.push")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh", val)
+
+	// flat keys keep working alongside nested ones
+	val, err = cfg.Get("", "valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "present", val)
+
+	_, err = cfg.Get("", "valid.push")
+	assert.EqualError(t, err, `expected "valid" to be a mapping, found a value`)
+}
+
+func testNestedYaml() *yaml.Node {
+	var root yaml.Node
+	var data = `
+blank:
+section:
+  inner: nested
+valid: present
+`
+	_ = yaml.Unmarshal([]byte(data), &root)
+	return root.Content[0]
+}
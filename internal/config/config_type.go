@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,20 +14,69 @@ type Config interface {
 	GetWithSource(string, string) (string, string, error)
 	Set(string, string, string) error
 	UnsetHost(string)
+	RenameHost(string, string) error
 	Hosts() ([]string, error)
+	Profiles() ([]string, error)
 	HostsTyped() ([]HostConfigTyped, error)
 	DefaultHostname() string
+	ResolveDefaultHostname() (string, error)
 	CheckWriteable(string, string) error
 	Write() error
 	SaveTyped(*HostConfigTyped) error
 	MakeConfigForHost(hostname string) *HostConfig
+	Aliases() (map[string]string, error)
+	SetAlias(alias, expansion string) error
+	DeleteAlias(alias string) error
+	UnknownKeys() []string
+	DefaultHeaders() (map[string]string, error)
+	GetInt(string, string) (int, error)
+	GetBool(string, string) (bool, error)
+}
+
+// OptionType describes how a config option's value is parsed and validated.
+type OptionType string
+
+const (
+	OptionTypeString OptionType = "string"
+	OptionTypeBool   OptionType = "bool"
+	OptionTypeInt    OptionType = "int"
+	OptionTypeEnum   OptionType = "enum"
+)
+
+// Description phrases the type for error messages, e.g. "an integer value".
+func (t OptionType) Description() string {
+	switch t {
+	case OptionTypeBool:
+		return "a boolean value"
+	case OptionTypeInt:
+		return "an integer value"
+	default:
+		return "a " + string(t) + " value"
+	}
 }
 
 type ConfigOption struct {
-	Key           string
-	Description   string
-	DefaultValue  string
+	Key          string
+	Description  string
+	DefaultValue string
+	// Type selects the validation applied to values; an empty Type means
+	// free-form string, and options with AllowedValues act as enums.
+	Type          OptionType
 	AllowedValues []string
+	// GlobalOnly marks keys that only take effect globally, so setting them
+	// per host would silently do nothing.
+	GlobalOnly bool
+}
+
+// IsGlobalOnly reports whether a key only takes effect globally and therefore
+// must not be set per host.
+func IsGlobalOnly(key string) bool {
+	for _, opt := range configOptions {
+		if key == opt.Key {
+			return opt.GlobalOnly
+		}
+	}
+	return false
 }
 
 var configOptions = []ConfigOption{
@@ -33,38 +84,176 @@ var configOptions = []ConfigOption{
 		Key:           "protocol",
 		Description:   "the protocol to use for git clone and push operations",
 		DefaultValue:  "https",
+		Type:          OptionTypeEnum,
 		AllowedValues: []string{"https", "ssh"},
 	},
 	{
 		Key:          "editor",
 		Description:  "the text editor program to use for authoring text",
 		DefaultValue: "",
+		GlobalOnly:   true,
 	},
 	{
 		Key:           "prompt",
 		Description:   "toggle interactive prompting in the terminal",
 		DefaultValue:  "enabled",
+		Type:          OptionTypeEnum,
 		AllowedValues: []string{"enabled", "disabled"},
+		GlobalOnly:    true,
+	},
+	{
+		Key:           "color",
+		Description:   "whether to colorize terminal output",
+		DefaultValue:  "auto",
+		Type:          OptionTypeEnum,
+		AllowedValues: []string{"auto", "always", "never"},
+		GlobalOnly:    true,
 	},
 	{
 		Key:          "pager",
 		Description:  "the terminal pager program to send standard output to",
 		DefaultValue: "",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "api_version",
+		Description:  "the API version to request via the Accept header on `inst api` calls",
+		DefaultValue: "",
+		GlobalOnly:   true,
+	},
+	{
+		Key:           "api_default_output",
+		Description:   "how `inst api` formats responses when neither --pretty nor --compact is passed; \"json\" keeps the TTY-aware default",
+		DefaultValue:  "json",
+		Type:          OptionTypeEnum,
+		AllowedValues: []string{"json", "pretty", "compact", "ndjson"},
+		GlobalOnly:    true,
+	},
+	{
+		Key:           "spinner",
+		Description:   "toggle the progress spinner shown during long operations",
+		DefaultValue:  "enabled",
+		Type:          OptionTypeEnum,
+		AllowedValues: []string{"enabled", "disabled"},
+		GlobalOnly:    true,
+	},
+	{
+		Key:          "auth_cache_ttl",
+		Description:  "how long a validated access token is trusted before re-validating, e.g. `5m`; `0` disables the cache",
+		DefaultValue: "5m",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "auth_wait",
+		Description:  "how long to keep retrying a request that was answered with HTTP 401 while a token is still being provisioned, e.g. `30s`; `0` fails immediately",
+		DefaultValue: "0",
+		GlobalOnly:   true,
 	},
 	{
 		Key:          "http_unix_socket",
 		Description:  "the path to a unix socket through which to make HTTP connection",
 		DefaultValue: "",
 	},
+	{
+		Key:          "http_max_idle_conns",
+		Description:  "the maximum number of idle HTTP connections to keep open across all hosts",
+		DefaultValue: "100",
+		Type:         OptionTypeInt,
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_idle_timeout",
+		Description:  "how long an idle HTTP connection is kept open for reuse, e.g. \"90s\" or \"2m\"",
+		DefaultValue: "90s",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_timeout",
+		Description:  "the per-host timeout for connectivity checks, e.g. \"10s\" or \"1m\"",
+		DefaultValue: "10s",
+	},
+	{
+		Key:          "http_force_http1",
+		Description:  "disable HTTP/2 and force HTTP/1.1, for proxies that break protocol negotiation",
+		DefaultValue: "false",
+		Type:         OptionTypeBool,
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_retry_base_ms",
+		Description:  "the base delay in milliseconds between retries of failed HTTP requests",
+		DefaultValue: "250",
+		Type:         OptionTypeInt,
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_retry_max_ms",
+		Description:  "the upper bound in milliseconds on the delay between HTTP retries",
+		DefaultValue: "4000",
+		Type:         OptionTypeInt,
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_retry_jitter",
+		Description:  "randomize retry delays (full jitter) so many clients do not retry in lockstep",
+		DefaultValue: "true",
+		Type:         OptionTypeBool,
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "http_max_response_bytes",
+		Description:  "the maximum response body size `inst api` reads, e.g. \"10MB\"; \"0\" disables the limit",
+		DefaultValue: "0",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "tls_client_cert",
+		Description:  "path to a PEM-encoded client certificate used for mutual TLS",
+		DefaultValue: "",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "tls_client_key",
+		Description:  "path to the PEM-encoded private key matching tls_client_cert",
+		DefaultValue: "",
+		GlobalOnly:   true,
+	},
 	{
 		Key:          "browser",
 		Description:  "the web browser to use for opening URLs",
 		DefaultValue: "",
+		GlobalOnly:   true,
 	},
 	{
 		Key:          "default_hostname",
-		Description:  "the default hostname to use for commands that require a hostname",
+		Description:  "the default hostname to use for commands that require a hostname; \"auto\" picks the sole configured host",
 		DefaultValue: "",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "accept_language",
+		Description:  "the preferred language for localized server messages, sent as the Accept-Language header",
+		DefaultValue: "",
+	},
+	{
+		Key:           "credential_store",
+		Description:   "where to store OAuth tokens: in the config file or in the OS keyring",
+		DefaultValue:  "file",
+		Type:          OptionTypeEnum,
+		AllowedValues: []string{"file", "keyring"},
+		GlobalOnly:    true,
+	},
+	{
+		Key:          "token_expiry_warn",
+		Description:  "warn when a host's access token expires within this window, e.g. \"24h\"; \"0\" disables the warning",
+		DefaultValue: "24h",
+		GlobalOnly:   true,
+	},
+	{
+		Key:          "audit_log",
+		Description:  "path to a file recording config mutations as JSON lines; empty disables the log",
+		DefaultValue: "",
+		GlobalOnly:   true,
 	},
 }
 
@@ -72,7 +261,29 @@ func ConfigOptions() []ConfigOption {
 	return configOptions
 }
 
+// EnvOverrides returns the environment variables that override the given
+// config key, in order of precedence. This is the single place mapping config
+// keys to their environment variable names.
+func EnvOverrides(key string) []string {
+	switch key {
+	case "editor":
+		return []string{"INSTILL_EDITOR", "GIT_EDITOR", "VISUAL", "EDITOR"}
+	case "browser":
+		return []string{"INSTILL_BROWSER", "BROWSER"}
+	case "pager":
+		return []string{"INSTILL_PAGER", "PAGER"}
+	default:
+		return []string{"INSTILL_" + strings.ToUpper(key)}
+	}
+}
+
 func ValidateKey(key string) error {
+	// any header name may live under default_headers; sensitive names are
+	// rejected by Set itself
+	if _, ok := defaultHeaderName(key); ok {
+		return nil
+	}
+
 	for _, configKey := range configOptions {
 		if key == configKey.Key {
 			return nil
@@ -84,33 +295,85 @@ func ValidateKey(key string) error {
 
 type InvalidValueError struct {
 	ValidValues []string
+	// ExpectedType is set instead of ValidValues when a typed option rejected
+	// the value, e.g. a non-integer for an int option.
+	ExpectedType OptionType
 }
 
 func (e InvalidValueError) Error() string {
 	return "invalid value"
 }
 
+// findOption returns the declared option for a key, or nil for unknown keys.
+func findOption(key string) *ConfigOption {
+	for i := range configOptions {
+		if configOptions[i].Key == key {
+			return &configOptions[i]
+		}
+	}
+	return nil
+}
+
 func ValidateValue(key, value string) error {
-	var validValues []string
+	opt := findOption(key)
+	if opt == nil {
+		return nil
+	}
 
-	for _, v := range configOptions {
-		if v.Key == key {
-			validValues = v.AllowedValues
-			break
+	normalized := strings.TrimSpace(value)
+	if len(opt.AllowedValues) > 0 {
+		for _, v := range opt.AllowedValues {
+			if strings.EqualFold(v, normalized) {
+				return nil
+			}
 		}
+		return &InvalidValueError{ValidValues: opt.AllowedValues}
 	}
 
-	if validValues == nil {
-		return nil
+	switch opt.Type {
+	case OptionTypeInt:
+		if _, err := strconv.Atoi(normalized); err != nil {
+			return &InvalidValueError{ExpectedType: OptionTypeInt}
+		}
+	case OptionTypeBool:
+		if _, err := strconv.ParseBool(strings.ToLower(normalized)); err != nil {
+			return &InvalidValueError{ExpectedType: OptionTypeBool}
+		}
+	}
+	return nil
+}
+
+// NormalizeValue maps a value to its canonical form — the spelling from
+// AllowedValues for enums, "true"/"false" for booleans, and decimal notation
+// for integers — ignoring case and surrounding whitespace. Values for
+// free-form keys, and values that do not parse, are returned verbatim.
+func NormalizeValue(key, value string) string {
+	opt := findOption(key)
+	if opt == nil {
+		return value
 	}
 
-	for _, v := range validValues {
-		if v == value {
-			return nil
+	normalized := strings.TrimSpace(value)
+	if len(opt.AllowedValues) > 0 {
+		for _, v := range opt.AllowedValues {
+			if strings.EqualFold(v, normalized) {
+				return v
+			}
 		}
+		return value
 	}
 
-	return &InvalidValueError{ValidValues: validValues}
+	switch opt.Type {
+	case OptionTypeInt:
+		if n, err := strconv.Atoi(normalized); err == nil {
+			return strconv.Itoa(n)
+		}
+	case OptionTypeBool:
+		if b, err := strconv.ParseBool(strings.ToLower(normalized)); err == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return value
 }
 
 func NewConfig(root *yaml.Node) Config {
@@ -120,13 +383,24 @@ func NewConfig(root *yaml.Node) Config {
 	}
 }
 
-// NewFromString initializes a Config from a yaml string
-func NewFromString(str string) Config {
+// NewFromString initializes a Config from a yaml string. A parse failure is
+// reported as an InvalidConfigError rather than a panic.
+func NewFromString(str string) (Config, error) {
 	root, err := parseConfigData([]byte(str))
+	if err != nil {
+		return nil, &InvalidConfigError{Err: err}
+	}
+	return NewConfig(root), nil
+}
+
+// MustNewFromString is NewFromString for callers that know their input is
+// valid, such as tests; it panics on a parse failure.
+func MustNewFromString(str string) Config {
+	cfg, err := NewFromString(str)
 	if err != nil {
 		panic(err)
 	}
-	return NewConfig(root)
+	return cfg
 }
 
 // NewBlankConfig initializes a config file pre-populated with comments and default values
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func Test_fileConfig_Set(t *testing.T) {
@@ -30,6 +31,40 @@ example.com:
 `, hostsBuf.String())
 }
 
+func Test_fileConfig_Set_newHost(t *testing.T) {
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	// a parsed config without a hosts section
+	c := MustNewFromString(heredoc.Doc(`
+		editor: vim
+	`))
+	assert.NoError(t, c.Set("my.instance", "protocol", "ssh"))
+	assert.NoError(t, c.Write())
+
+	assert.Contains(t, mainBuf.String(), "editor: vim")
+	assert.Equal(t, "my.instance:\n    protocol: ssh\n", hostsBuf.String())
+
+	// the created host entry must round-trip as valid YAML
+	var parsed map[string]map[string]string
+	assert.NoError(t, yaml.Unmarshal(hostsBuf.Bytes(), &parsed))
+	assert.Equal(t, "ssh", parsed["my.instance"]["protocol"])
+
+	value, err := c.Get("my.instance", "protocol")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh", value)
+}
+
+func Test_NewFromString_invalid(t *testing.T) {
+	cfg, err := NewFromString("editor: [vim\n")
+	assert.Nil(t, cfg)
+
+	var invalidConfig *InvalidConfigError
+	assert.ErrorAs(t, err, &invalidConfig)
+	assert.Contains(t, err.Error(), "invalid configuration:")
+}
+
 func Test_defaultConfig(t *testing.T) {
 	mainBuf := bytes.Buffer{}
 	hostsBuf := bytes.Buffer{}
@@ -85,6 +120,46 @@ func Test_ValidateValue(t *testing.T) {
 
 	err = ValidateValue("http_unix_socket", "really_anything/is/allowed/and/net.Dial\\(...\\)/will/ultimately/validate")
 	assert.NoError(t, err)
+
+	err = ValidateValue("protocol", "HTTPS")
+	assert.NoError(t, err)
+
+	err = ValidateValue("prompt", "  Enabled ")
+	assert.NoError(t, err)
+}
+
+func Test_ValidateValue_typed(t *testing.T) {
+	// int options reject anything strconv.Atoi does
+	assert.NoError(t, ValidateValue("http_retry_base_ms", "250"))
+	assert.NoError(t, ValidateValue("http_retry_base_ms", " -1 "))
+	err := ValidateValue("http_retry_base_ms", "soon")
+	var invalidValue *InvalidValueError
+	assert.ErrorAs(t, err, &invalidValue)
+	assert.Equal(t, OptionTypeInt, invalidValue.ExpectedType)
+	assert.EqualError(t, ValidateValue("http_max_idle_conns", "1.5"), "invalid value")
+
+	// bool options accept the strconv.ParseBool forms in any case
+	assert.NoError(t, ValidateValue("http_force_http1", "true"))
+	assert.NoError(t, ValidateValue("http_force_http1", "FALSE"))
+	assert.NoError(t, ValidateValue("http_retry_jitter", "1"))
+	err = ValidateValue("http_retry_jitter", "maybe")
+	assert.ErrorAs(t, err, &invalidValue)
+	assert.Equal(t, OptionTypeBool, invalidValue.ExpectedType)
+}
+
+func Test_NormalizeValue(t *testing.T) {
+	assert.Equal(t, "https", NormalizeValue("protocol", "HTTPS"))
+	assert.Equal(t, "enabled", NormalizeValue("prompt", "  Enabled "))
+	// free-form keys are stored verbatim
+	assert.Equal(t, " Vim ", NormalizeValue("editor", " Vim "))
+	// values that don't match any allowed value are left untouched
+	assert.Equal(t, "sshpps", NormalizeValue("protocol", "sshpps"))
+	// typed options canonicalize parseable values and keep the rest verbatim
+	assert.Equal(t, "250", NormalizeValue("http_retry_base_ms", " 250 "))
+	assert.Equal(t, "7", NormalizeValue("http_max_idle_conns", "007"))
+	assert.Equal(t, "true", NormalizeValue("http_force_http1", "True"))
+	assert.Equal(t, "false", NormalizeValue("http_retry_jitter", "0"))
+	assert.Equal(t, "soon", NormalizeValue("http_retry_base_ms", "soon"))
 }
 
 func Test_ValidateKey(t *testing.T) {
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHeadersKey is the top-level mapping whose entries are added to every
+// API request, e.g. `inst config set default_headers.X-Team platform`.
+const defaultHeadersKey = "default_headers"
+
+// sensitiveHeaderNames must never come from default_headers: credentials
+// belong in the host config, where they are resolved and refreshed properly.
+var sensitiveHeaderNames = []string{"authorization", "proxy-authorization", "cookie"}
+
+// IsSensitiveHeader reports whether a header name is off-limits for the
+// default_headers mapping.
+func IsSensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaderNames {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultHeaderName extracts the header name from a "default_headers.<name>"
+// key; the second return reports whether the key addresses the mapping at all.
+func defaultHeaderName(key string) (string, bool) {
+	if rest, found := strings.CutPrefix(key, defaultHeadersKey+"."); found && rest != "" {
+		return rest, true
+	}
+	return "", false
+}
+
+// validateDefaultHeaderKey rejects writes of sensitive header names under
+// default_headers. It runs at the Set chokepoint so no caller can sneak one
+// in.
+func validateDefaultHeaderKey(key string) error {
+	if name, ok := defaultHeaderName(key); ok && IsSensitiveHeader(name) {
+		return fmt.Errorf("the %q header cannot be set via %s", name, defaultHeadersKey)
+	}
+	return nil
+}
+
+// DefaultHeaders returns the default_headers mapping. Sensitive names that
+// were hand-edited into the file are skipped rather than sent.
+func (c *fileConfig) DefaultHeaders() (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, err := c.FindEntry(defaultHeadersKey)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if entry.ValueNode == nil || entry.ValueNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	headers := map[string]string{}
+	content := entry.ValueNode.Content
+	for i := 0; i < len(content)-1; i += 2 {
+		name := content[i].Value
+		if IsSensitiveHeader(name) {
+			continue
+		}
+		if value := content[i+1].Value; value != "" {
+			headers[name] = value
+		}
+	}
+	return headers, nil
+}
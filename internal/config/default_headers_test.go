@@ -0,0 +1,86 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileConfig_DefaultHeaders(t *testing.T) {
+	cfg := MustNewFromString(`
+default_headers:
+  X-Team: platform
+  X-Env: staging
+  Authorization: "bearer sneaky"
+  X-Empty: ""
+`)
+
+	headers, err := cfg.DefaultHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"X-Team": "platform",
+		"X-Env":  "staging",
+	}, headers)
+
+	// the mapping is structural, not an unknown key
+	assert.Empty(t, cfg.UnknownKeys())
+}
+
+func Test_fileConfig_DefaultHeaders_unset(t *testing.T) {
+	cfg := MustNewFromString(`editor: vim`)
+
+	headers, err := cfg.DefaultHeaders()
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+}
+
+func Test_fileConfig_setDefaultHeader(t *testing.T) {
+	cfg := MustNewFromString(``)
+
+	require.NoError(t, cfg.Set("", "default_headers.X-Team", "platform"))
+
+	headers, err := cfg.DefaultHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"X-Team": "platform"}, headers)
+
+	// the header persists as a nested mapping
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+	require.NoError(t, cfg.Write())
+	assert.Contains(t, mainBuf.String(), "default_headers:\n    X-Team: platform")
+}
+
+func Test_fileConfig_setDefaultHeader_sensitive(t *testing.T) {
+	cfg := MustNewFromString(``)
+
+	for _, name := range []string{"Authorization", "authorization", "Proxy-Authorization", "Cookie"} {
+		err := cfg.Set("", "default_headers."+name, "value")
+		assert.EqualError(t, err, `the "`+name+`" header cannot be set via default_headers`)
+	}
+
+	headers, err := cfg.DefaultHeaders()
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+}
+
+func Test_ValidateKey_defaultHeaders(t *testing.T) {
+	assert.NoError(t, ValidateKey("default_headers.X-Team"))
+	assert.Error(t, ValidateKey("default_headers."))
+	assert.Error(t, ValidateKey("default_headers"))
+}
+
+func Test_inMemoryConfig_DefaultHeaders(t *testing.T) {
+	cfg := NewInMemoryConfig(map[string]string{
+		"editor":                 "vim",
+		"default_headers.X-Team": "platform",
+	}, nil)
+
+	headers, err := cfg.DefaultHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"X-Team": "platform"}, headers)
+
+	assert.Error(t, cfg.Set("", "default_headers.Authorization", "value"))
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deprecatedKeys maps config keys that were renamed over time to their
+// current names. Deprecated keys are normalized in memory at load time, so
+// old config files keep working; the canonical names are persisted by the
+// next Write.
+var deprecatedKeys = map[string]string{
+	"git_protocol": "protocol",
+	"hostname":     "default_hostname",
+}
+
+// swappable for tests
+var deprecationWarnOut io.Writer = os.Stderr
+
+// normalizeDeprecatedKeys renames known-deprecated keys in a parsed config
+// tree, both at the top level and inside each host entry, warning once per
+// key. A deprecated key is left untouched when its current name is already
+// present, so an explicit canonical setting always wins.
+func normalizeDeprecatedKeys(root *yaml.Node, warnOut io.Writer) {
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return
+	}
+
+	warned := map[string]bool{}
+	rename := func(mapping *yaml.Node) {
+		content := mapping.Content
+		hasKey := func(name string) bool {
+			for i := 0; i < len(content)-1; i += 2 {
+				if content[i].Value == name {
+					return true
+				}
+			}
+			return false
+		}
+		for i := 0; i < len(content)-1; i += 2 {
+			replacement, ok := deprecatedKeys[content[i].Value]
+			if !ok {
+				continue
+			}
+			if hasKey(replacement) {
+				if !warned[content[i].Value] {
+					fmt.Fprintf(warnOut, "warning: the deprecated config key %q is ignored in favor of %q\n", content[i].Value, replacement)
+					warned[content[i].Value] = true
+				}
+				continue
+			}
+			if !warned[content[i].Value] {
+				fmt.Fprintf(warnOut, "warning: the config key %q is deprecated; use %q instead\n", content[i].Value, replacement)
+				warned[content[i].Value] = true
+			}
+			content[i].Value = replacement
+		}
+	}
+
+	top := root.Content[0]
+	rename(top)
+	for i := 0; i < len(top.Content)-1; i += 2 {
+		if top.Content[i].Value != "hosts" || top.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		hosts := top.Content[i+1].Content
+		for j := 0; j < len(hosts)-1; j += 2 {
+			if hosts[j+1].Kind == yaml.MappingNode {
+				rename(hosts[j+1])
+			}
+		}
+	}
+}
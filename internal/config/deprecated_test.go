@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseConfig_deprecatedKeys(t *testing.T) {
+	defer stubConfig(`---
+git_protocol: ssh
+hostname: api.instill.tech
+`, "")()
+	warnOut := &bytes.Buffer{}
+	oldWarnOut := deprecationWarnOut
+	deprecationWarnOut = warnOut
+	defer func() { deprecationWarnOut = oldWarnOut }()
+
+	config, err := parseConfig("config.yml")
+	require.NoError(t, err)
+
+	protocol, err := config.Get("", "protocol")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh", protocol)
+	hostname, err := config.Get("", "default_hostname")
+	assert.NoError(t, err)
+	assert.Equal(t, "api.instill.tech", hostname)
+
+	assert.Equal(t, `warning: the config key "git_protocol" is deprecated; use "protocol" instead
+warning: the config key "hostname" is deprecated; use "default_hostname" instead
+`, warnOut.String())
+}
+
+func Test_parseConfig_deprecatedKeyIgnoredWhenCanonicalSet(t *testing.T) {
+	defer stubConfig(`---
+protocol: https
+git_protocol: ssh
+`, "")()
+	warnOut := &bytes.Buffer{}
+	oldWarnOut := deprecationWarnOut
+	deprecationWarnOut = warnOut
+	defer func() { deprecationWarnOut = oldWarnOut }()
+
+	config, err := parseConfig("config.yml")
+	require.NoError(t, err)
+
+	protocol, err := config.Get("", "protocol")
+	assert.NoError(t, err)
+	assert.Equal(t, "https", protocol)
+
+	assert.Equal(t, `warning: the deprecated config key "git_protocol" is ignored in favor of "protocol"
+`, warnOut.String())
+}
+
+func Test_parseConfig_deprecatedKeysPersistCanonicalForm(t *testing.T) {
+	defer stubConfig(`---
+git_protocol: ssh
+`, "")()
+	oldWarnOut := deprecationWarnOut
+	deprecationWarnOut = &bytes.Buffer{}
+	defer func() { deprecationWarnOut = oldWarnOut }()
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	config, err := parseConfig("config.yml")
+	require.NoError(t, err)
+	require.NoError(t, config.Write())
+
+	assert.Contains(t, mainBuf.String(), "protocol: ssh")
+	assert.NotContains(t, mainBuf.String(), "git_protocol")
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// exportHeader marks an exported config as credential-free, so a reader (or a
+// reviewer of a shared file) can tell at a glance that tokens were stripped.
+const exportHeader = "exported with secret values (tokens) removed"
+
+// Export serializes the configuration as YAML with every secret value removed
+// from all hosts, keeping the remaining structure and settings intact so the
+// result is safe to share and can be loaded back as a regular config file.
+// The stripped keys are the same per-host keys the keyring credential store
+// treats as secrets.
+func Export(cfg Config) ([]byte, error) {
+	// unwrap decorating layers that hide the underlying tree
+	for {
+		switch c := cfg.(type) {
+		case *readOnlyConfig:
+			cfg = c.Config
+		case *overridesConfig:
+			cfg = c.Config
+		default:
+			root, ok := cfg.(interface{ Root() *yaml.Node })
+			if !ok {
+				return nil, errors.New("this configuration cannot be exported")
+			}
+			return exportNode(root.Root())
+		}
+	}
+}
+
+func exportNode(root *yaml.Node) ([]byte, error) {
+	stripped := copyNode(root)
+	stripHostSecrets(stripped)
+	stripped.HeadComment = exportHeader
+
+	data, err := yaml.Marshal(stripped)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// copyNode deep-copies a yaml tree so stripping secrets cannot disturb the
+// live, in-memory configuration.
+func copyNode(node *yaml.Node) *yaml.Node {
+	dup := *node
+	dup.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		dup.Content[i] = copyNode(child)
+	}
+	return &dup
+}
+
+// stripHostSecrets removes every secret key/value pair from each entry of the
+// top-level "hosts" mapping.
+func stripHostSecrets(root *yaml.Node) {
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value != "hosts" {
+			continue
+		}
+		hosts := root.Content[i+1]
+		for j := 0; j < len(hosts.Content)-1; j += 2 {
+			hosts.Content[j+1] = withoutSecretKeys(hosts.Content[j+1])
+		}
+	}
+}
+
+func withoutSecretKeys(host *yaml.Node) *yaml.Node {
+	kept := make([]*yaml.Node, 0, len(host.Content))
+	for i := 0; i < len(host.Content)-1; i += 2 {
+		if isSecretKey(host.Content[i].Value) {
+			continue
+		}
+		kept = append(kept, host.Content[i], host.Content[i+1])
+	}
+	host.Content = kept
+	return host
+}
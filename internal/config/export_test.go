@@ -0,0 +1,83 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Export(t *testing.T) {
+	cfg := MustNewFromString(heredoc.Doc(`
+		editor: vim
+		default_hostname: api.instill.tech
+		hosts:
+		  api.instill.tech:
+		    access_token: SECRET1
+		    refresh_token: SECRET2
+		    id_token: SECRET3
+		    oauth2_hostname: auth.instill.tech
+		    expiry: Mon, 02 Jan 2034 15:04:05 UTC
+		  other.example.com:
+		    access_token: SECRET4
+		    api_version: v1alpha
+	`))
+
+	data, err := Export(cfg)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.Contains(t, out, "# "+exportHeader)
+	assert.NotContains(t, out, "SECRET1")
+	assert.NotContains(t, out, "SECRET2")
+	assert.NotContains(t, out, "SECRET3")
+	assert.NotContains(t, out, "SECRET4")
+	assert.Contains(t, out, "editor: vim")
+	assert.Contains(t, out, "oauth2_hostname: auth.instill.tech")
+
+	// the export loads back as a regular config, minus the tokens
+	reloaded, err := NewFromString(out)
+	require.NoError(t, err)
+
+	value, err := reloaded.Get("", "editor")
+	require.NoError(t, err)
+	assert.Equal(t, "vim", value)
+
+	value, err = reloaded.Get("api.instill.tech", "oauth2_hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "auth.instill.tech", value)
+
+	value, err = reloaded.Get("api.instill.tech", "access_token")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	hosts, err := reloaded.Hosts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.instill.tech", "other.example.com"}, hosts)
+
+	// the live config keeps its secrets
+	value, err = cfg.Get("api.instill.tech", "access_token")
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET1", value)
+}
+
+func Test_Export_unwrapsLayers(t *testing.T) {
+	base := MustNewFromString(heredoc.Doc(`
+		hosts:
+		  api.instill.tech:
+		    access_token: SECRET1
+	`))
+
+	layered, err := NewWithOverrides(NewReadOnly(base), map[string]string{"editor": "ed"})
+	require.NoError(t, err)
+
+	data, err := Export(layered)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "SECRET1")
+}
+
+func Test_Export_unsupported(t *testing.T) {
+	_, err := Export(ConfigStub{})
+	assert.EqualError(t, err, "this configuration cannot be exported")
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 
@@ -16,6 +18,12 @@ import (
 type fileConfig struct {
 	ConfigMap
 	documentRoot *yaml.Node
+
+	// mu guards the yaml node tree: concurrent Get calls are safe, while
+	// Set and Write are serialized with readers and with each other. The
+	// exported methods take the lock and delegate to unexported, unlocked
+	// implementations so they can call into each other freely.
+	mu sync.RWMutex
 }
 
 type HostConfig struct {
@@ -34,10 +42,29 @@ func (c *fileConfig) Get(hostname, key string) (string, error) {
 }
 
 func (c *fileConfig) GetWithSource(hostname, key string) (string, string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.getWithSource(hostname, key)
+}
+
+func (c *fileConfig) get(hostname, key string) (string, error) {
+	val, _, err := c.getWithSource(hostname, key)
+	return val, err
+}
+
+func (c *fileConfig) getWithSource(hostname, key string) (string, string, error) {
+	// a token supplied via the environment wins over the stored one, so CI
+	// can authenticate without writing config files
+	if key == "access_token" {
+		if token := c.tokenFromEnv(hostname); token != "" {
+			return token, "environment", nil
+		}
+	}
+
 	if hostname != "" {
 		var notFound *NotFoundError
 
-		hostCfg, err := c.ConfigForHost(hostname)
+		hostCfg, err := c.configForHost(hostname)
 		if err != nil && !errors.As(err, &notFound) {
 			return "", "", err
 		}
@@ -51,7 +78,14 @@ func (c *fileConfig) GetWithSource(hostname, key string) (string, string, error)
 		}
 
 		if hostValue != "" {
-			return hostValue, HostsConfigFile(), nil
+			return resolveSecret(hostValue), HostsConfigFile(), nil
+		}
+	}
+
+	// a selected profile overrides global keys, but not per-host settings
+	if profile := CurrentProfile(); profile != "" {
+		if entry, err := c.FindEntry("profiles", profile, key); err == nil && entry.ValueNode != nil && entry.ValueNode.Value != "" {
+			return entry.ValueNode.Value, fmt.Sprintf("%s (profile %s)", ConfigFile(), profile), nil
 		}
 	}
 
@@ -75,13 +109,27 @@ func (c *fileConfig) GetWithSource(hostname, key string) (string, string, error)
 }
 
 func (c *fileConfig) Set(hostname, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, _ := c.get(hostname, key)
+	if err := c.set(hostname, key, value); err != nil {
+		return err
+	}
+	c.audit(auditEntry{Action: "set", Hostname: hostname, Key: key, Old: old, New: value})
+	return nil
+}
+
+func (c *fileConfig) set(hostname, key, value string) error {
+	if err := validateDefaultHeaderKey(key); err != nil {
+		return err
+	}
 	if hostname == "" {
 		return c.SetStringValue(key, value)
 	} else {
-		hostCfg, err := c.ConfigForHost(hostname)
+		hostCfg, err := c.configForHost(hostname)
 		var notFound *NotFoundError
 		if errors.As(err, &notFound) {
-			hostCfg = c.MakeConfigForHost(hostname)
+			hostCfg = c.makeConfigForHost(hostname)
 		} else if err != nil {
 			return err
 		}
@@ -94,6 +142,9 @@ func (c *fileConfig) UnsetHost(hostname string) {
 		return
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	hostsEntry, err := c.FindEntry("hosts")
 	if err != nil {
 		return
@@ -101,9 +152,52 @@ func (c *fileConfig) UnsetHost(hostname string) {
 
 	cm := ConfigMap{hostsEntry.ValueNode}
 	cm.RemoveEntry(hostname)
+	c.audit(auditEntry{Action: "unset_host", Hostname: hostname})
+}
+
+// RenameHost moves the entire config block for `old` to `new`, keeping every
+// per-host setting intact. If `old` was the default hostname, the default is
+// updated to point at the new name. The change is persisted immediately.
+func (c *fileConfig) RenameHost(old, new string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hostsEntry, err := c.FindEntry("hosts")
+	if err != nil {
+		return fmt.Errorf("could not find config entry for %q", old)
+	}
+
+	var keyNode *yaml.Node
+	content := hostsEntry.ValueNode.Content
+	for i := 0; i < len(content)-1; i += 2 {
+		if strings.EqualFold(content[i].Value, old) {
+			keyNode = content[i]
+		} else if strings.EqualFold(content[i].Value, new) {
+			return fmt.Errorf("host %q already exists", new)
+		}
+	}
+	if keyNode == nil {
+		return fmt.Errorf("could not find config entry for %q", old)
+	}
+
+	keyNode.Value = new
+
+	if defaultHost, err := c.get("", "default_hostname"); err == nil && strings.EqualFold(defaultHost, old) {
+		if err := c.set("", "default_hostname", new); err != nil {
+			return err
+		}
+	}
+
+	return c.write()
 }
 
 func (c *fileConfig) ConfigForHost(hostname string) (*HostConfig, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.configForHost(hostname)
+}
+
+func (c *fileConfig) configForHost(hostname string) (*HostConfig, error) {
 	hosts, err := c.hostEntries()
 	if err != nil {
 		return nil, err
@@ -118,11 +212,33 @@ func (c *fileConfig) ConfigForHost(hostname string) (*HostConfig, error) {
 }
 
 func (c *fileConfig) CheckWriteable(hostname, key string) error {
-	// TODO: check filesystem permissions
-	return nil
+	fn := ConfigFile()
+	if hostname != "" {
+		fn = HostsConfigFile()
+	}
+	f, err := os.OpenFile(fn, os.O_WRONLY|os.O_APPEND, 0600)
+	if err == nil {
+		f.Close()
+		return nil
+	}
+	if os.IsNotExist(err) {
+		// the file will be created on the first write
+		return nil
+	}
+	return fmt.Errorf("%s is not writeable: %w", fn, err)
 }
 
 func (c *fileConfig) Write() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.write()
+}
+
+// write persists the in-memory tree across the two config files: host entries
+// go to the hosts file, everything else to the main config file. Each file is
+// only rewritten when its content actually changed, so e.g. setting a non-host
+// key leaves the credentials file (and its permissions and mtime) untouched.
+func (c *fileConfig) write() error {
 	mainData := yaml.Node{Kind: yaml.MappingNode}
 	hostsData := yaml.Node{Kind: yaml.MappingNode}
 
@@ -135,23 +251,23 @@ func (c *fileConfig) Write() error {
 		}
 	}
 
-	mainBytes, err := yaml.Marshal(&mainData)
-	if err != nil {
+	if err := writeConfigData(ConfigFile(), &mainData); err != nil {
 		return err
 	}
+	return writeConfigData(HostsConfigFile(), &hostsData)
+}
 
-	filename := ConfigFile()
-	err = WriteConfigFile(filename, yamlNormalize(mainBytes))
+// writeConfigData marshals one file's portion of the config and writes it
+// back, unless the file already holds exactly that content.
+func writeConfigData(filename string, data *yaml.Node) error {
+	b, err := marshalConfigData(filename, data)
 	if err != nil {
 		return err
 	}
-
-	hostsBytes, err := yaml.Marshal(&hostsData)
-	if err != nil {
-		return err
+	if existing, err := ReadConfigFile(filename); err == nil && bytes.Equal(existing, b) {
+		return nil
 	}
-
-	return WriteConfigFile(HostsConfigFile(), yamlNormalize(hostsBytes))
+	return WriteConfigFile(filename, b)
 }
 
 func (c *fileConfig) hostEntries() ([]*HostConfig, error) {
@@ -171,6 +287,36 @@ func (c *fileConfig) hostEntries() ([]*HostConfig, error) {
 // Hosts returns a list of all known hostnames configured in hosts.yml
 // TODO replace with HostsTyped
 func (c *fileConfig) Hosts() ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hosts()
+}
+
+// Profiles returns the names of the profiles defined under the top-level
+// profiles mapping, in file order.
+func (c *fileConfig) Profiles() ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, err := c.FindEntry("profiles")
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if entry.ValueNode != nil {
+		content := entry.ValueNode.Content
+		for i := 0; i < len(content)-1; i += 2 {
+			names = append(names, content[i].Value)
+		}
+	}
+	return names, nil
+}
+
+func (c *fileConfig) hosts() ([]string, error) {
 	entries, err := c.hostEntries()
 	if err != nil {
 		return nil, err
@@ -189,12 +335,19 @@ func (c *fileConfig) Hosts() ([]string, error) {
 // HostsTyped returns an array of typesafe host configs.
 // Every call re-reads the config file.
 func (c *fileConfig) HostsTyped() ([]HostConfigTyped, error) {
+	// a write lock, not a read lock: a missing default host is persisted below
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hostsTyped()
+}
+
+func (c *fileConfig) hostsTyped() ([]HostConfigTyped, error) {
 	var ret []HostConfigTyped
 	hosts, err := c.hostEntries()
 	if err != nil {
 		return nil, err
 	}
-	defaultHost, err := c.Get("", "default_hostname")
+	defaultHost, err := c.get("", "default_hostname")
 	if err != nil {
 		return nil, err
 	}
@@ -217,11 +370,11 @@ func (c *fileConfig) HostsTyped() ([]HostConfigTyped, error) {
 		ret[0].IsDefault = true
 		defaultHost = ret[0].APIHostname
 		// update the default hostname
-		err = c.Set("", "default_hostname", defaultHost)
+		err = c.set("", "default_hostname", defaultHost)
 		if err != nil {
 			return nil, err
 		}
-		err = c.Write()
+		err = c.write()
 		if err != nil {
 			return nil, err
 		}
@@ -235,14 +388,67 @@ func (c *fileConfig) HostsTyped() ([]HostConfigTyped, error) {
 
 // DefaultHostname returns the default API hostname, or a fallback in case of none or an error.
 func (c *fileConfig) DefaultHostname() string {
-	hostname, err := c.Get("", "default_hostname")
+	hostname, err := c.ResolveDefaultHostname()
 	if err != nil {
 		return instance.FallbackHostname()
 	}
 	return hostname
 }
 
+// ResolveDefaultHostname resolves the default_hostname key. The special value
+// "auto", as well as an unset key, picks the sole configured host when exactly
+// one exists; with several hosts configured the user has to choose explicitly.
+func (c *fileConfig) ResolveDefaultHostname() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resolveDefaultHostname()
+}
+
+func (c *fileConfig) resolveDefaultHostname() (string, error) {
+	hostname, err := c.get("", "default_hostname")
+	if err != nil {
+		return "", err
+	}
+	if hostname != "" && !strings.EqualFold(hostname, "auto") {
+		return hostname, nil
+	}
+
+	hosts, _ := c.hosts()
+	switch len(hosts) {
+	case 0:
+		return "", errors.New("no hosts are configured; run `inst auth login` to add one")
+	case 1:
+		return hosts[0], nil
+	default:
+		return "", fmt.Errorf("cannot choose a default among %d configured hosts; set default_hostname explicitly", len(hosts))
+	}
+}
+
+// tokenFromEnv returns an access token supplied via the environment for the
+// given host. The per-host form INSTILL_<HOST>_TOKEN (host uppercased, dots
+// turned into underscores) wins; the plain INSTILL_TOKEN applies to the
+// default host only.
+func (c *fileConfig) tokenFromEnv(hostname string) string {
+	if hostname == "" {
+		return ""
+	}
+	hostVar := "INSTILL_" + strings.ToUpper(strings.ReplaceAll(hostname, ".", "_")) + "_TOKEN"
+	if token := os.Getenv(hostVar); token != "" {
+		return token
+	}
+	if defaultHost, err := c.resolveDefaultHostname(); err == nil && defaultHost == hostname {
+		return os.Getenv("INSTILL_TOKEN")
+	}
+	return ""
+}
+
 func (c *fileConfig) MakeConfigForHost(hostname string) *HostConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.makeConfigForHost(hostname)
+}
+
+func (c *fileConfig) makeConfigForHost(hostname string) *HostConfig {
 	hostRoot := &yaml.Node{Kind: yaml.MappingNode}
 	hostCfg := &HostConfig{
 		Host:      hostname,
@@ -292,27 +498,72 @@ func (c *fileConfig) parseHosts(hostsEntry *yaml.Node) ([]*HostConfig, error) {
 	return hostConfigs, nil
 }
 
-// Save persists the host config into the passed `fileConfig`.
+// validateHostTyped rejects host configs that would corrupt the hosts
+// section, normalizing the API hostname in place when it is acceptable.
+func validateHostTyped(host *HostConfigTyped) error {
+	if strings.TrimSpace(host.APIHostname) == "" {
+		return errors.New("invalid host config: the API hostname is empty")
+	}
+	hostname, err := instance.NormalizeHostname(host.APIHostname)
+	if err != nil {
+		return fmt.Errorf("invalid API hostname %q: %w", host.APIHostname, err)
+	}
+	host.APIHostname = hostname
+	return nil
+}
+
 func (c *fileConfig) SaveTyped(host *HostConfigTyped) error {
-	conf, err := c.ConfigForHost(host.APIHostname)
+	if err := validateHostTyped(host); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conf, err := c.configForHost(host.APIHostname)
 	var notFound *NotFoundError
 	if errors.As(err, &notFound) {
-		conf = c.MakeConfigForHost(host.APIHostname)
+		conf = c.makeConfigForHost(host.APIHostname)
 	} else if err != nil {
 		return err
 	}
+	// optionally delegate token storage to the OS keyring, keeping only
+	// references in the config file
+	if store, _ := c.get("", "credential_store"); store == "keyring" {
+		redacted := *host
+		if redacted.AccessToken != "" {
+			if redacted.AccessToken, err = storeSecret(host.APIHostname, "access_token", host.AccessToken); err != nil {
+				return err
+			}
+		}
+		if redacted.RefreshToken != "" {
+			if redacted.RefreshToken, err = storeSecret(host.APIHostname, "refresh_token", host.RefreshToken); err != nil {
+				return err
+			}
+		}
+		if redacted.IDToken != "" {
+			if redacted.IDToken, err = storeSecret(host.APIHostname, "id_token", host.IDToken); err != nil {
+				return err
+			}
+		}
+		host = &redacted
+	}
 	err = hostTypedToConfig(host, conf)
 	if err != nil {
 		return err
 	}
 	// update the default instance in the main config
 	if host.IsDefault {
-		err = c.Set("", "default_hostname", host.APIHostname)
+		err = c.set("", "default_hostname", host.APIHostname)
 		if err != nil {
 			return err
 		}
 	}
-	return c.Write()
+	if err := c.write(); err != nil {
+		return err
+	}
+	c.audit(auditEntry{Action: "save_host", Hostname: host.APIHostname})
+	return nil
 }
 
 // HostConfigTyped is a type safe representation of an instance config.
@@ -338,6 +589,8 @@ type HostConfigTyped struct {
 	Expiry       string
 	RefreshToken string
 	IDToken      string
+	// the scopes granted with the token
+	Scopes []string `example:"openid email"`
 }
 
 // DefaultHostConfig returns default values for an instance config, as a single "source of truth" for other packages.
@@ -348,22 +601,46 @@ func DefaultHostConfig() HostConfigTyped {
 	}
 }
 
+// hostConfigKeys are the keys stored under each entry of the hosts section,
+// in the order `hostTypedToConfig` writes them.
+var hostConfigKeys = []string{
+	"token_type",
+	"access_token",
+	"expiry",
+	"refresh_token",
+	"id_token",
+	"oauth2_audience",
+	"oauth2_issuer",
+	"oauth2_hostname",
+	"oauth2_client_id",
+	"oauth2_client_secret",
+	"api_version",
+	"oauth_scopes",
+}
+
+// HostConfigKeys returns the keys recognized under each entry of the hosts
+// section, as a single source of truth for other packages.
+func HostConfigKeys() []string {
+	return hostConfigKeys
+}
+
 // hostConfigToTyped reads an untyped config into a `HostConfigTyped` struct.
 func hostConfigToTyped(conf *HostConfig) (*HostConfigTyped, error) {
 	ht := &HostConfigTyped{
 		APIHostname: conf.Host,
 	}
 	ht.TokenType = conf.GetOptionalStringValue("token_type")
-	ht.AccessToken = conf.GetOptionalStringValue("access_token")
+	ht.AccessToken = resolveSecret(conf.GetOptionalStringValue("access_token"))
 	ht.Expiry = conf.GetOptionalStringValue("expiry")
-	ht.RefreshToken = conf.GetOptionalStringValue("refresh_token")
-	ht.IDToken = conf.GetOptionalStringValue("id_token")
+	ht.RefreshToken = resolveSecret(conf.GetOptionalStringValue("refresh_token"))
+	ht.IDToken = resolveSecret(conf.GetOptionalStringValue("id_token"))
 	ht.Oauth2Audience = conf.GetOptionalStringValue("oauth2_audience")
 	ht.Oauth2Issuer = conf.GetOptionalStringValue("oauth2_issuer")
 	ht.Oauth2Hostname = conf.GetOptionalStringValue("oauth2_hostname")
 	ht.Oauth2ClientSecret = conf.GetOptionalStringValue("oauth2_client_secret")
 	ht.Oauth2ClientID = conf.GetOptionalStringValue("oauth2_client_id")
 	ht.APIVersion = conf.GetOptionalStringValue("api_version")
+	ht.Scopes = strings.Fields(conf.GetOptionalStringValue("oauth_scopes"))
 	return ht, nil
 }
 
@@ -413,6 +690,10 @@ func hostTypedToConfig(host *HostConfigTyped, conf *HostConfig) error {
 	if err != nil {
 		return err
 	}
+	err = conf.SetStringValue("oauth_scopes", strings.Join(host.Scopes, " "))
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,3 +49,403 @@ func Test_fileConfig_Typed(t *testing.T) {
 	err = fc.SaveTyped(hct)
 	require.NoError(t, err)
 }
+
+func Test_GetWithSource_precedence(t *testing.T) {
+	yml := heredoc.Doc(`
+		pager: less
+		hosts:
+		  api.instill.tech:
+		    pager: more
+		  instill.localhost:
+		    api_version: v1alpha
+	`)
+
+	tests := []struct {
+		name     string
+		hostname string
+		key      string
+		want     string
+	}{
+		{
+			name:     "host value wins over global",
+			hostname: "api.instill.tech",
+			key:      "pager",
+			want:     "more",
+		},
+		{
+			name:     "global value when host has none",
+			hostname: "instill.localhost",
+			key:      "pager",
+			want:     "less",
+		},
+		{
+			name:     "global value without hostname",
+			hostname: "",
+			key:      "pager",
+			want:     "less",
+		},
+		{
+			name:     "default when neither host nor global set",
+			hostname: "instill.localhost",
+			key:      "prompt",
+			want:     "enabled",
+		},
+		{
+			name:     "default for unknown hostname",
+			hostname: "unknown.host",
+			key:      "color",
+			want:     "auto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := MustNewFromString(yml)
+			val, err := cfg.Get(tt.hostname, tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
+
+func Test_GetWithSource_tokenFromEnv(t *testing.T) {
+	yml := heredoc.Doc(`
+		default_hostname: api.instill.tech
+		hosts:
+		  api.instill.tech:
+		    access_token: stored-token
+		  instill.localhost:
+		    access_token: other-stored-token
+	`)
+
+	t.Run("per-host variable", func(t *testing.T) {
+		t.Setenv("INSTILL_API_INSTILL_TECH_TOKEN", "host-env-token")
+
+		cfg := MustNewFromString(yml)
+		val, source, err := cfg.GetWithSource("api.instill.tech", "access_token")
+		require.NoError(t, err)
+		assert.Equal(t, "host-env-token", val)
+		assert.Equal(t, "environment", source)
+	})
+
+	t.Run("INSTILL_TOKEN applies to the default host only", func(t *testing.T) {
+		t.Setenv("INSTILL_TOKEN", "env-token")
+
+		cfg := MustNewFromString(yml)
+		val, source, err := cfg.GetWithSource("api.instill.tech", "access_token")
+		require.NoError(t, err)
+		assert.Equal(t, "env-token", val)
+		assert.Equal(t, "environment", source)
+
+		val, _, err = cfg.GetWithSource("instill.localhost", "access_token")
+		require.NoError(t, err)
+		assert.Equal(t, "other-stored-token", val)
+	})
+
+	t.Run("per-host variable wins over INSTILL_TOKEN", func(t *testing.T) {
+		t.Setenv("INSTILL_TOKEN", "env-token")
+		t.Setenv("INSTILL_API_INSTILL_TECH_TOKEN", "host-env-token")
+
+		cfg := MustNewFromString(yml)
+		val, _, err := cfg.GetWithSource("api.instill.tech", "access_token")
+		require.NoError(t, err)
+		assert.Equal(t, "host-env-token", val)
+	})
+
+	t.Run("stored token without environment", func(t *testing.T) {
+		cfg := MustNewFromString(yml)
+		val, source, err := cfg.GetWithSource("api.instill.tech", "access_token")
+		require.NoError(t, err)
+		assert.Equal(t, "stored-token", val)
+		assert.Equal(t, HostsConfigFile(), source)
+	})
+}
+
+func Test_write_skipsUnchangedFiles(t *testing.T) {
+	main := heredoc.Doc(`
+		editor: ed
+	`)
+	hosts := heredoc.Doc(`
+		api.instill.tech:
+		    access_token: TOKEN
+	`)
+	defer stubConfig(main, hosts)()
+
+	cfg, err := ParseDefaultConfig()
+	require.NoError(t, err)
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	// with nothing modified, neither file is rewritten
+	require.NoError(t, cfg.Write())
+	assert.Equal(t, "", mainBuf.String())
+	assert.Equal(t, "", hostsBuf.String())
+
+	// changing a global key must not rewrite the credentials file
+	require.NoError(t, cfg.Set("", "editor", "vim"))
+	require.NoError(t, cfg.Write())
+	assert.Contains(t, mainBuf.String(), "editor: vim")
+	assert.Equal(t, "", hostsBuf.String())
+}
+
+func Test_Profiles(t *testing.T) {
+	yml := heredoc.Doc(`
+		editor: ed
+		pager: less
+		profiles:
+		  staging:
+		    editor: vim
+		  prod:
+		    pager: more
+		hosts:
+		  api.instill.tech:
+		    editor: nano
+	`)
+
+	t.Run("lists profile names", func(t *testing.T) {
+		cfg := MustNewFromString(yml)
+		profiles, err := cfg.Profiles()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"staging", "prod"}, profiles)
+	})
+
+	t.Run("no profiles section", func(t *testing.T) {
+		cfg := MustNewFromString("editor: ed\n")
+		profiles, err := cfg.Profiles()
+		require.NoError(t, err)
+		assert.Nil(t, profiles)
+	})
+}
+
+func Test_GetWithSource_profile(t *testing.T) {
+	yml := heredoc.Doc(`
+		editor: ed
+		profiles:
+		  staging:
+		    editor: vim
+		hosts:
+		  api.instill.tech:
+		    editor: nano
+	`)
+
+	t.Run("profile override wins over global", func(t *testing.T) {
+		SetProfile("staging")
+		defer SetProfile("")
+
+		cfg := MustNewFromString(yml)
+		val, src, err := cfg.GetWithSource("", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "vim", val)
+		assert.Equal(t, ConfigFile()+" (profile staging)", src)
+	})
+
+	t.Run("host value wins over the profile", func(t *testing.T) {
+		SetProfile("staging")
+		defer SetProfile("")
+
+		cfg := MustNewFromString(yml)
+		val, err := cfg.Get("api.instill.tech", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "nano", val)
+	})
+
+	t.Run("unset key falls back through global to default", func(t *testing.T) {
+		SetProfile("staging")
+		defer SetProfile("")
+
+		cfg := MustNewFromString(yml)
+		val, err := cfg.Get("", "pager")
+		require.NoError(t, err)
+		assert.Equal(t, "", val)
+
+		val, err = cfg.Get("", "prompt")
+		require.NoError(t, err)
+		assert.Equal(t, "enabled", val)
+	})
+
+	t.Run("INSTILL_PROFILE selects a profile", func(t *testing.T) {
+		t.Setenv("INSTILL_PROFILE", "staging")
+
+		cfg := MustNewFromString(yml)
+		val, err := cfg.Get("", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "vim", val)
+	})
+
+	t.Run("unknown profile leaves global values untouched", func(t *testing.T) {
+		SetProfile("missing")
+		defer SetProfile("")
+
+		cfg := MustNewFromString(yml)
+		val, err := cfg.Get("", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "ed", val)
+	})
+}
+
+func Test_fileConfig_ResolveDefaultHostname(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no hosts",
+			yaml:    "default_hostname: auto\n",
+			wantErr: "no hosts are configured; run `inst auth login` to add one",
+		},
+		{
+			name: "auto with a single host",
+			yaml: heredoc.Doc(`
+				default_hostname: auto
+				hosts:
+				  my.instance:
+				    access_token: TOKEN
+			`),
+			want: "my.instance",
+		},
+		{
+			name: "unset is treated as auto",
+			yaml: heredoc.Doc(`
+				hosts:
+				  my.instance:
+				    access_token: TOKEN
+			`),
+			want: "my.instance",
+		},
+		{
+			name: "auto with several hosts",
+			yaml: heredoc.Doc(`
+				default_hostname: auto
+				hosts:
+				  my.instance:
+				    access_token: TOKEN1
+				  other.instance:
+				    access_token: TOKEN2
+			`),
+			wantErr: "cannot choose a default among 2 configured hosts; set default_hostname explicitly",
+		},
+		{
+			name: "explicit default wins",
+			yaml: heredoc.Doc(`
+				default_hostname: other.instance
+				hosts:
+				  my.instance:
+				    access_token: TOKEN1
+				  other.instance:
+				    access_token: TOKEN2
+			`),
+			want: "other.instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := MustNewFromString(tt.yaml)
+			hostname, err := c.ResolveDefaultHostname()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				// DefaultHostname falls back instead of surfacing the error
+				assert.Equal(t, "api.instill.tech", c.DefaultHostname())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hostname)
+			assert.Equal(t, tt.want, c.DefaultHostname())
+		})
+	}
+}
+
+// run with -race: readers hammer Get while a writer loops over Set
+func Test_fileConfig_concurrentAccess(t *testing.T) {
+	cfg := MustNewFromString("editor: vim\n")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = cfg.Get("", "editor")
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 100; j++ {
+			_ = cfg.Set("", "editor", "nano")
+		}
+	}()
+	wg.Wait()
+
+	value, err := cfg.Get("", "editor")
+	require.NoError(t, err)
+	assert.Equal(t, "nano", value)
+}
+
+func Test_fileConfig_SaveTyped_validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostname     string
+		wantHostname string
+		wantErr      string
+	}{
+		{
+			name:     "empty hostname",
+			hostname: "",
+			wantErr:  "invalid host config: the API hostname is empty",
+		},
+		{
+			name:         "URL is stripped to its hostname",
+			hostname:     "HTTPS://My.Instance/",
+			wantHostname: "my.instance",
+		},
+		{
+			name:         "path is stripped",
+			hostname:     "my.instance/api",
+			wantHostname: "my.instance",
+		},
+		{
+			name:         "default port is dropped",
+			hostname:     "my.instance:443",
+			wantHostname: "my.instance",
+		},
+		{
+			name:     "not a hostname",
+			hostname: "not@valid",
+			wantErr:  `invalid API hostname "not@valid": hostname not valid`,
+		},
+		{
+			name:         "hostname is normalized",
+			hostname:     "API.Instill.Tech.",
+			wantHostname: "api.instill.tech",
+		},
+		{
+			name:         "hostname with a port",
+			hostname:     "my.instance:8080",
+			wantHostname: "my.instance:8080",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainBuf := bytes.Buffer{}
+			hostsBuf := bytes.Buffer{}
+			defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+			cfg := NewBlankConfig()
+			err := cfg.SaveTyped(&HostConfigTyped{APIHostname: tt.hostname, APIVersion: "v1alpha"})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, hostsBuf.String())
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, hostsBuf.String(), tt.wantHostname+":")
+		})
+	}
+}
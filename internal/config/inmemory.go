@@ -0,0 +1,294 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/instill-ai/cli/internal/instance"
+)
+
+// inMemoryConfig implements the full Config interface on plain maps, without
+// a yaml.Node tree behind it. It backs tests and embedded uses where nothing
+// should touch the filesystem: Write is a no-op that only records it was
+// called.
+type inMemoryConfig struct {
+	mu      sync.RWMutex
+	globals map[string]string
+	hosts   map[string]map[string]string
+	aliases map[string]string
+	written bool
+}
+
+// NewInMemoryConfig returns a Config backed by the given global and per-host
+// values. Both maps may be nil; they are copied, so later changes to the
+// arguments do not affect the config.
+func NewInMemoryConfig(globals map[string]string, hosts map[string]map[string]string) Config {
+	c := &inMemoryConfig{
+		globals: map[string]string{},
+		hosts:   map[string]map[string]string{},
+		aliases: map[string]string{},
+	}
+	for key, value := range globals {
+		c.globals[key] = value
+	}
+	for hostname, values := range hosts {
+		c.hosts[hostname] = map[string]string{}
+		for key, value := range values {
+			c.hosts[hostname][key] = value
+		}
+	}
+	return c
+}
+
+func (c *inMemoryConfig) Get(hostname, key string) (string, error) {
+	value, _, err := c.GetWithSource(hostname, key)
+	return value, err
+}
+
+func (c *inMemoryConfig) GetWithSource(hostname, key string) (string, string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if hostname != "" {
+		if value, found := c.hosts[hostname][key]; found && value != "" {
+			return value, "(in-memory)", nil
+		}
+	}
+	if value, found := c.globals[key]; found && value != "" {
+		return value, "(in-memory)", nil
+	}
+	return defaultFor(key), "(in-memory)", nil
+}
+
+func (c *inMemoryConfig) Set(hostname, key, value string) error {
+	if err := validateDefaultHeaderKey(key); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hostname == "" {
+		c.globals[key] = value
+		return nil
+	}
+	if c.hosts[hostname] == nil {
+		c.hosts[hostname] = map[string]string{}
+	}
+	c.hosts[hostname][key] = value
+	return nil
+}
+
+func (c *inMemoryConfig) UnsetHost(hostname string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.hosts, hostname)
+}
+
+func (c *inMemoryConfig) RenameHost(old, new string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values, found := c.hosts[old]
+	if !found {
+		return fmt.Errorf("could not find config entry for %q", old)
+	}
+	if _, exists := c.hosts[new]; exists {
+		return fmt.Errorf("host %q already exists", new)
+	}
+	c.hosts[new] = values
+	delete(c.hosts, old)
+	if strings.EqualFold(c.globals["default_hostname"], old) {
+		c.globals["default_hostname"] = new
+	}
+	return nil
+}
+
+func (c *inMemoryConfig) Hosts() ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	hostnames := []string{}
+	for hostname := range c.hosts {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	return hostnames, nil
+}
+
+func (c *inMemoryConfig) Profiles() ([]string, error) {
+	return nil, nil
+}
+
+func (c *inMemoryConfig) HostsTyped() ([]HostConfigTyped, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	hostnames := []string{}
+	for hostname := range c.hosts {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+
+	var ret []HostConfigTyped
+	defaultMatched := false
+	for _, hostname := range hostnames {
+		values := c.hosts[hostname]
+		ht := HostConfigTyped{
+			APIHostname:        hostname,
+			TokenType:          values["token_type"],
+			AccessToken:        values["access_token"],
+			Expiry:             values["expiry"],
+			RefreshToken:       values["refresh_token"],
+			IDToken:            values["id_token"],
+			Oauth2Audience:     values["oauth2_audience"],
+			Oauth2Issuer:       values["oauth2_issuer"],
+			Oauth2Hostname:     values["oauth2_hostname"],
+			Oauth2ClientID:     values["oauth2_client_id"],
+			Oauth2ClientSecret: values["oauth2_client_secret"],
+			APIVersion:         values["api_version"],
+		}
+		if scopes := values["oauth_scopes"]; scopes != "" {
+			ht.Scopes = strings.Fields(scopes)
+		}
+		if c.globals["default_hostname"] == hostname {
+			ht.IsDefault = true
+			defaultMatched = true
+		}
+		ret = append(ret, ht)
+	}
+	if len(ret) > 0 && !defaultMatched {
+		ret[0].IsDefault = true
+	}
+	return ret, nil
+}
+
+func (c *inMemoryConfig) DefaultHostname() string {
+	hostname, err := c.ResolveDefaultHostname()
+	if err != nil {
+		return instance.FallbackHostname()
+	}
+	return hostname
+}
+
+func (c *inMemoryConfig) ResolveDefaultHostname() (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	hostname := c.globals["default_hostname"]
+	if hostname != "" && !strings.EqualFold(hostname, "auto") {
+		return hostname, nil
+	}
+
+	switch len(c.hosts) {
+	case 0:
+		return "", errors.New("no hosts are configured; run `inst auth login` to add one")
+	case 1:
+		for hostname := range c.hosts {
+			return hostname, nil
+		}
+		panic("unreachable")
+	default:
+		return "", fmt.Errorf("cannot choose a default among %d configured hosts; set default_hostname explicitly", len(c.hosts))
+	}
+}
+
+func (c *inMemoryConfig) CheckWriteable(hostname, key string) error {
+	return nil
+}
+
+func (c *inMemoryConfig) Write() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = true
+	return nil
+}
+
+func (c *inMemoryConfig) SaveTyped(host *HostConfigTyped) error {
+	if err := validateHostTyped(host); err != nil {
+		return err
+	}
+
+	hostname := host.APIHostname
+	values := map[string]string{
+		"token_type":           host.TokenType,
+		"access_token":         host.AccessToken,
+		"expiry":               host.Expiry,
+		"refresh_token":        host.RefreshToken,
+		"id_token":             host.IDToken,
+		"oauth2_audience":      host.Oauth2Audience,
+		"oauth2_issuer":        host.Oauth2Issuer,
+		"oauth2_hostname":      host.Oauth2Hostname,
+		"oauth2_client_id":     host.Oauth2ClientID,
+		"oauth2_client_secret": host.Oauth2ClientSecret,
+		"api_version":          host.APIVersion,
+		"oauth_scopes":         strings.Join(host.Scopes, " "),
+	}
+
+	c.mu.Lock()
+	c.hosts[hostname] = values
+	if host.IsDefault {
+		c.globals["default_hostname"] = hostname
+	}
+	c.mu.Unlock()
+
+	return c.Write()
+}
+
+func (c *inMemoryConfig) MakeConfigForHost(hostname string) *HostConfig {
+	return nil
+}
+
+func (c *inMemoryConfig) Aliases() (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	aliases := map[string]string{}
+	for alias, expansion := range c.aliases {
+		aliases[alias] = expansion
+	}
+	return aliases, nil
+}
+
+func (c *inMemoryConfig) SetAlias(alias, expansion string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aliases[alias] = expansion
+	return nil
+}
+
+func (c *inMemoryConfig) DeleteAlias(alias string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, found := c.aliases[alias]; !found {
+		return fmt.Errorf("no such alias %q", alias)
+	}
+	delete(c.aliases, alias)
+	return nil
+}
+
+func (c *inMemoryConfig) UnknownKeys() []string {
+	return nil
+}
+
+// DefaultHeaders collects "default_headers.<name>" keys from the globals,
+// mirroring the nested mapping a file-backed config stores.
+func (c *inMemoryConfig) DefaultHeaders() (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var headers map[string]string
+	for key, value := range c.globals {
+		if name, ok := defaultHeaderName(key); ok && !IsSensitiveHeader(name) && value != "" {
+			if headers == nil {
+				headers = map[string]string{}
+			}
+			headers[name] = value
+		}
+	}
+	return headers, nil
+}
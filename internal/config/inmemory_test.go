@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewInMemoryConfig_get(t *testing.T) {
+	cfg := NewInMemoryConfig(
+		map[string]string{"editor": "ed"},
+		map[string]map[string]string{
+			"api.instill.tech": {"editor": "vim"},
+		},
+	)
+
+	t.Run("host value wins over global", func(t *testing.T) {
+		val, err := cfg.Get("api.instill.tech", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "vim", val)
+	})
+
+	t.Run("global value when host has none", func(t *testing.T) {
+		val, err := cfg.Get("other.host", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "ed", val)
+	})
+
+	t.Run("default when neither is set", func(t *testing.T) {
+		val, err := cfg.Get("", "prompt")
+		require.NoError(t, err)
+		assert.Equal(t, "enabled", val)
+	})
+}
+
+func Test_NewInMemoryConfig_copiesInput(t *testing.T) {
+	globals := map[string]string{"editor": "ed"}
+	cfg := NewInMemoryConfig(globals, nil)
+
+	globals["editor"] = "vim"
+	val, err := cfg.Get("", "editor")
+	require.NoError(t, err)
+	assert.Equal(t, "ed", val)
+}
+
+func Test_inMemoryConfig_set(t *testing.T) {
+	cfg := NewInMemoryConfig(nil, nil)
+
+	require.NoError(t, cfg.Set("", "editor", "ed"))
+	require.NoError(t, cfg.Set("api.instill.tech", "editor", "vim"))
+	require.NoError(t, cfg.Write())
+
+	val, err := cfg.Get("api.instill.tech", "editor")
+	require.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	hosts, err := cfg.Hosts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.instill.tech"}, hosts)
+}
+
+func Test_inMemoryConfig_renameHost(t *testing.T) {
+	cfg := NewInMemoryConfig(
+		map[string]string{"default_hostname": "old.host"},
+		map[string]map[string]string{
+			"old.host": {"access_token": "TOKEN"},
+		},
+	)
+
+	require.NoError(t, cfg.RenameHost("old.host", "new.host"))
+
+	val, err := cfg.Get("new.host", "access_token")
+	require.NoError(t, err)
+	assert.Equal(t, "TOKEN", val)
+
+	hostname, err := cfg.ResolveDefaultHostname()
+	require.NoError(t, err)
+	assert.Equal(t, "new.host", hostname)
+
+	assert.EqualError(t, cfg.RenameHost("missing.host", "other.host"), `could not find config entry for "missing.host"`)
+}
+
+func Test_inMemoryConfig_saveTyped(t *testing.T) {
+	cfg := NewInMemoryConfig(nil, nil)
+
+	err := cfg.SaveTyped(&HostConfigTyped{
+		APIHostname: "api.instill.tech",
+		APIVersion:  "v1alpha",
+		AccessToken: "TOKEN",
+		IsDefault:   true,
+	})
+	require.NoError(t, err)
+
+	hosts, err := cfg.HostsTyped()
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.Equal(t, "api.instill.tech", hosts[0].APIHostname)
+	assert.Equal(t, "TOKEN", hosts[0].AccessToken)
+	assert.True(t, hosts[0].IsDefault)
+
+	// validation matches the file-backed implementation
+	err = cfg.SaveTyped(&HostConfigTyped{APIHostname: "", APIVersion: "v1alpha"})
+	assert.EqualError(t, err, "invalid host config: the API hostname is empty")
+}
+
+func Test_inMemoryConfig_aliases(t *testing.T) {
+	cfg := NewInMemoryConfig(nil, nil)
+
+	require.NoError(t, cfg.SetAlias("pl", "api vdp/v1alpha/pipelines"))
+	aliases, err := cfg.Aliases()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pl": "api vdp/v1alpha/pipelines"}, aliases)
+
+	require.NoError(t, cfg.DeleteAlias("pl"))
+	assert.EqualError(t, cfg.DeleteAlias("pl"), `no such alias "pl"`)
+}
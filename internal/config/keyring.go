@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// keyringService is the service name under which secrets are stored in the OS keyring.
+	keyringService = "instill-cli"
+	// keyringRefPrefix marks a config value that has been moved into the OS keyring.
+	keyringRefPrefix = "keyring:"
+)
+
+// secretKeys are the per-host config keys whose values are moved into the OS
+// keyring when `credential_store` is set to "keyring".
+var secretKeys = []string{"access_token", "refresh_token", "id_token"}
+
+func isSecretKey(key string) bool {
+	for _, k := range secretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func keyringRef(hostname, key string) string {
+	return keyringRefPrefix + hostname + ":" + key
+}
+
+// storeSecret persists a secret in the OS keyring and returns the reference
+// to store in the config file in its place.
+func storeSecret(hostname, key, value string) (string, error) {
+	err := keyring.Set(keyringService, hostname+":"+key, value)
+	if err != nil {
+		return "", fmt.Errorf("could not store %q in the OS keyring: %w", key, err)
+	}
+	return keyringRef(hostname, key), nil
+}
+
+// resolveSecret transparently replaces a keyring reference with the secret it
+// points to. Values that are not references are returned unchanged.
+func resolveSecret(value string) string {
+	if !strings.HasPrefix(value, keyringRefPrefix) {
+		return value
+	}
+	id := strings.TrimPrefix(value, keyringRefPrefix)
+	secret, err := keyring.Get(keyringService, id)
+	if err != nil {
+		return ""
+	}
+	return secret
+}
+
+// deleteSecret removes a secret from the OS keyring, ignoring missing entries.
+func deleteSecret(hostname, key string) error {
+	err := keyring.Delete(keyringService, hostname+":"+key)
+	if err != nil && err != keyring.ErrNotFound {
+		return err
+	}
+	return nil
+}
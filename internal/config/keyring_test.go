@@ -0,0 +1,50 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+)
+
+func TestSaveTypedKeyring(t *testing.T) {
+	keyring.MockInit()
+
+	cfg := MustNewFromString(`---
+credential_store: keyring
+hosts:
+  api.instill.tech:
+    access_token: plaintext
+`)
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	host := &HostConfigTyped{
+		APIHostname: "api.instill.tech",
+		AccessToken: "s3cret",
+	}
+	err := cfg.SaveTyped(host)
+	assert.NoError(t, err)
+
+	// the file keeps only a reference
+	raw, err := cfg.(*fileConfig).ConfigForHost("api.instill.tech")
+	assert.NoError(t, err)
+	assert.Equal(t, "keyring:api.instill.tech:access_token", raw.GetOptionalStringValue("access_token"))
+
+	// reads resolve the secret transparently
+	val, err := cfg.Get("api.instill.tech", "access_token")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", val)
+
+	hosts, err := cfg.HostsTyped()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", hosts[0].AccessToken)
+}
+
+func TestResolveSecretPassthrough(t *testing.T) {
+	keyring.MockInit()
+	assert.Equal(t, "plain", resolveSecret("plain"))
+	assert.Equal(t, "", resolveSecret("keyring:missing:access_token"))
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewWithOverrides layers one-off key overrides on top of a Config. The
+// overridden values win over anything stored in the config file for the
+// lifetime of the process and are never written to disk. Every key and value
+// is validated up front so a typo fails the invocation instead of being
+// silently ignored.
+func NewWithOverrides(base Config, overrides map[string]string) (Config, error) {
+	for key, value := range overrides {
+		if err := ValidateKey(key); err != nil {
+			return nil, fmt.Errorf("invalid config key %q", key)
+		}
+		if err := ValidateValue(key, value); err != nil {
+			var invalidValue *InvalidValueError
+			if errors.As(err, &invalidValue) {
+				if invalidValue.ExpectedType != "" {
+					return nil, fmt.Errorf("invalid value %q for config key %q: expected %s", value, key, invalidValue.ExpectedType.Description())
+				}
+				var values []string
+				for _, v := range invalidValue.ValidValues {
+					values = append(values, fmt.Sprintf("'%s'", v))
+				}
+				return nil, fmt.Errorf("invalid value %q for config key %q: valid values are %v", value, key, strings.Join(values, ", "))
+			}
+			return nil, err
+		}
+	}
+	return &overridesConfig{Config: base, overrides: overrides}, nil
+}
+
+type overridesConfig struct {
+	Config
+	overrides map[string]string
+}
+
+func (c *overridesConfig) Get(hostname, key string) (string, error) {
+	value, _, err := c.GetWithSource(hostname, key)
+	return value, err
+}
+
+func (c *overridesConfig) GetWithSource(hostname, key string) (string, string, error) {
+	if value, found := c.overrides[key]; found {
+		return value, "`--config-key` flag", nil
+	}
+	return c.Config.GetWithSource(hostname, key)
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewWithOverrides(t *testing.T) {
+	base := MustNewFromString("editor: ed\nprotocol: https\n")
+
+	t.Run("override wins over the stored value", func(t *testing.T) {
+		cfg, err := NewWithOverrides(base, map[string]string{"protocol": "ssh"})
+		require.NoError(t, err)
+
+		value, source, err := cfg.GetWithSource("", "protocol")
+		require.NoError(t, err)
+		assert.Equal(t, "ssh", value)
+		assert.Equal(t, "`--config-key` flag", source)
+	})
+
+	t.Run("other keys fall through to the base config", func(t *testing.T) {
+		cfg, err := NewWithOverrides(base, map[string]string{"protocol": "ssh"})
+		require.NoError(t, err)
+
+		value, err := cfg.Get("", "editor")
+		require.NoError(t, err)
+		assert.Equal(t, "ed", value)
+	})
+
+	t.Run("unknown key is rejected", func(t *testing.T) {
+		_, err := NewWithOverrides(base, map[string]string{"nope": "x"})
+		assert.EqualError(t, err, `invalid config key "nope"`)
+	})
+
+	t.Run("invalid value is rejected with the valid choices", func(t *testing.T) {
+		_, err := NewWithOverrides(base, map[string]string{"protocol": "carrier-pigeon"})
+		assert.EqualError(t, err, `invalid value "carrier-pigeon" for config key "protocol": valid values are 'https', 'ssh'`)
+	})
+}
@@ -0,0 +1,22 @@
+package config
+
+import "os"
+
+// profileOverride selects the active config profile; it is set from the
+// --profile flag.
+var profileOverride string
+
+// SetProfile selects the active config profile for the rest of the process.
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// CurrentProfile returns the name of the active config profile: the --profile
+// flag wins over the INSTILL_PROFILE environment variable, and an empty name
+// means no profile is selected.
+func CurrentProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	return os.Getenv("INSTILL_PROFILE")
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+)
+
+// ErrReadOnly is returned on any attempt to persist a read-only configuration.
+var ErrReadOnly = errors.New("configuration is read-only")
+
+// NewFromBytes initializes a Config from raw yaml data.
+func NewFromBytes(data []byte) (Config, error) {
+	root, err := parseConfigData(data)
+	if err != nil {
+		return nil, &InvalidConfigError{Err: err}
+	}
+	return NewConfig(root), nil
+}
+
+// NewReadOnly wraps a Config and rejects writes, for one-shot configurations
+// (e.g. piped in via stdin) that must not touch the filesystem.
+func NewReadOnly(c Config) Config {
+	return &readOnlyConfig{Config: c}
+}
+
+type readOnlyConfig struct {
+	Config
+}
+
+func (c *readOnlyConfig) Set(hostname, key, value string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) UnsetHost(hostname string) {
+}
+
+func (c *readOnlyConfig) RenameHost(old, new string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) Write() error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) SaveTyped(host *HostConfigTyped) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) SetAlias(alias, expansion string) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyConfig) DeleteAlias(alias string) error {
+	return ErrReadOnly
+}
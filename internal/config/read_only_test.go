@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromBytes(t *testing.T) {
+	cfg, err := NewFromBytes([]byte("editor: vim\n"))
+	assert.NoError(t, err)
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	_, err = NewFromBytes([]byte("- not\n- a\n- map\n"))
+	assert.Error(t, err)
+}
+
+func TestNewReadOnly(t *testing.T) {
+	inner, err := NewFromBytes([]byte("editor: vim\n"))
+	assert.NoError(t, err)
+	cfg := NewReadOnly(inner)
+
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	assert.ErrorIs(t, cfg.Set("", "editor", "ed"), ErrReadOnly)
+	assert.ErrorIs(t, cfg.Write(), ErrReadOnly)
+	assert.ErrorIs(t, cfg.SaveTyped(&HostConfigTyped{APIHostname: "example.com"}), ErrReadOnly)
+}
@@ -0,0 +1,32 @@
+package config
+
+import "strings"
+
+// sensitiveKeys are config keys whose values are secrets and must never be
+// printed in full.
+var sensitiveKeys = map[string]bool{
+	"access_token":         true,
+	"refresh_token":        true,
+	"id_token":             true,
+	"oauth_token":          true,
+	"oauth2_client_secret": true,
+}
+
+// IsSensitiveKey reports whether a config key holds a secret that should be
+// redacted before printing.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// RedactSecret masks a secret for display, keeping only the last four
+// characters so a user can still tell tokens apart. Values too short to
+// safely reveal a suffix are masked entirely.
+func RedactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) <= 8 {
+		return "********"
+	}
+	return "********" + value[len(value)-4:]
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsSensitiveKey(t *testing.T) {
+	for _, key := range []string{"access_token", "refresh_token", "id_token", "oauth_token", "oauth2_client_secret", "ACCESS_TOKEN"} {
+		assert.True(t, IsSensitiveKey(key), key)
+	}
+	for _, key := range []string{"editor", "pager", "protocol", ""} {
+		assert.False(t, IsSensitiveKey(key), key)
+	}
+}
+
+func Test_RedactSecret(t *testing.T) {
+	assert.Equal(t, "", RedactSecret(""))
+	assert.Equal(t, "********", RedactSecret("short"))
+	assert.Equal(t, "********", RedactSecret("12345678"))
+
+	token := "very-secret-token-abcd"
+	redacted := RedactSecret(token)
+	assert.Equal(t, "********abcd", redacted)
+	assert.False(t, strings.Contains(redacted, token))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/instill-ai/cli/internal/instance"
 )
@@ -40,6 +41,19 @@ func (c ConfigStub) UnsetHost(hostname string) {
 	// TODO
 }
 
+func (c ConfigStub) RenameHost(old, new string) error {
+	for k, v := range c {
+		if strings.HasPrefix(k, old+":") {
+			c[genKey(new, strings.TrimPrefix(k, old+":"))] = v
+			delete(c, k)
+		}
+	}
+	if c["default_hostname"] == old {
+		c["default_hostname"] = new
+	}
+	return c.Write()
+}
+
 func (c ConfigStub) CheckWriteable(host, key string) error {
 	return nil
 }
@@ -61,6 +75,13 @@ func (c ConfigStub) DefaultHostname() string {
 	return instance.FallbackHostname()
 }
 
+func (c ConfigStub) ResolveDefaultHostname() (string, error) {
+	if v := c["default_hostname"]; v != "" && !strings.EqualFold(v, "auto") {
+		return v, nil
+	}
+	return instance.FallbackHostname(), nil
+}
+
 func (c ConfigStub) MakeConfigForHost(hostname string) *HostConfig {
 	return nil
 }
@@ -95,10 +116,46 @@ func (c ConfigStub) SaveTyped(host *HostConfigTyped) error {
 	_ = c.Set(h, "oauth2_client_id", host.Oauth2ClientID)
 	_ = c.Set(h, "oauth2_client_secret", host.Oauth2ClientSecret)
 	_ = c.Set(h, "api_version", host.APIVersion)
+	_ = c.Set(h, "oauth_scopes", strings.Join(host.Scopes, " "))
 	// TODO default instance
 	return c.Write()
 }
 
+func (c ConfigStub) Aliases() (map[string]string, error) {
+	aliases := map[string]string{}
+	for k, v := range c {
+		if len(k) > 6 && k[:6] == "alias:" {
+			aliases[k[6:]] = v
+		}
+	}
+	return aliases, nil
+}
+
+func (c ConfigStub) SetAlias(alias, expansion string) error {
+	c["alias:"+alias] = expansion
+	return nil
+}
+
+func (c ConfigStub) DeleteAlias(alias string) error {
+	if _, found := c["alias:"+alias]; !found {
+		return errors.New("not found")
+	}
+	delete(c, "alias:"+alias)
+	return nil
+}
+
+func (c ConfigStub) UnknownKeys() []string {
+	return nil
+}
+
+func (c ConfigStub) DefaultHeaders() (map[string]string, error) {
+	return nil, nil
+}
+
+func (c ConfigStub) Profiles() ([]string, error) {
+	return nil, nil
+}
+
 func ConfigStubFactory() (Config, error) {
 	return ConfigStub{}, nil
 }
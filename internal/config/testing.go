@@ -22,10 +22,10 @@ func StubWriteConfig(wc io.Writer, wh io.Writer) func() {
 	orig := WriteConfigFile
 	WriteConfigFile = func(fn string, data []byte) error {
 		switch filepath.Base(fn) {
-		case "config.yml":
+		case "config.yml", "config.toml":
 			_, err := wc.Write(data)
 			return err
-		case "hosts.yml":
+		case "hosts.yml", "hosts.toml":
 			_, err := wh.Write(data)
 			return err
 		default:
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// isTOMLFile reports whether a config filename selects the TOML format. The
+// format is chosen purely by file extension; everything else in the config
+// layer keeps operating on the yaml.Node tree.
+func isTOMLFile(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".toml")
+}
+
+// parseConfigDataForFile picks a decoder based on the file extension, so a
+// config.toml is read into the same yaml.Node tree that the rest of the
+// config layer operates on.
+func parseConfigDataForFile(filename string, data []byte) (*yaml.Node, error) {
+	if isTOMLFile(filename) {
+		return parseTOMLData(data)
+	}
+	return parseConfigData(data)
+}
+
+// parseTOMLData decodes TOML into the internal yaml.Node representation by
+// unmarshaling into a generic map and re-encoding that map as YAML.
+func parseTOMLData(data []byte) (*yaml.Node, error) {
+	var m map[string]interface{}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	yamlBytes, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseConfigData(yamlBytes)
+}
+
+// marshalTOML is the inverse of parseTOMLData: it flattens the yaml.Node tree
+// into a generic map and encodes that as TOML.
+func marshalTOML(node *yaml.Node) ([]byte, error) {
+	if len(node.Content) == 0 {
+		return []byte{}, nil
+	}
+
+	var m map[string]interface{}
+	if err := node.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return toml.Marshal(m)
+}
+
+// marshalConfigData serializes a config subtree in the format implied by the
+// file it is destined for, so a config loaded from TOML is written back out as
+// TOML.
+func marshalConfigData(filename string, node *yaml.Node) ([]byte, error) {
+	if isTOMLFile(filename) {
+		return marshalTOML(node)
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	return yamlNormalize(data), nil
+}
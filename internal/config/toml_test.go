@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_config_roundTrip_yaml(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), ".config", "instill")
+	_ = os.MkdirAll(configDir, 0755)
+	os.Setenv(INSTILL_CONFIG_DIR, configDir)
+	defer os.Unsetenv(INSTILL_CONFIG_DIR)
+
+	err := os.WriteFile(filepath.Join(configDir, "config.yml"), []byte("editor: vim\n"), 0600)
+	assert.NoError(t, err)
+
+	cfg, err := ParseDefaultConfig()
+	assert.NoError(t, err)
+
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	assert.NoError(t, cfg.Set("", "pager", "less"))
+	assert.NoError(t, cfg.Write())
+
+	configBytes, err := os.ReadFile(filepath.Join(configDir, "config.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "editor: vim\npager: less\n", string(configBytes))
+
+	cfg, err = ParseDefaultConfig()
+	assert.NoError(t, err)
+	val, err = cfg.Get("", "pager")
+	assert.NoError(t, err)
+	assert.Equal(t, "less", val)
+}
+
+func Test_config_roundTrip_toml(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), ".config", "instill")
+	_ = os.MkdirAll(configDir, 0755)
+	os.Setenv(INSTILL_CONFIG_DIR, configDir)
+	defer os.Unsetenv(INSTILL_CONFIG_DIR)
+
+	err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("editor = \"vim\"\n"), 0600)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(configDir, "hosts.toml"), []byte("[\"api.instill.tech\"]\noauth_token = \"TOKEN\"\n"), 0600)
+	assert.NoError(t, err)
+
+	cfg, err := ParseDefaultConfig()
+	assert.NoError(t, err)
+
+	val, err := cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", val)
+
+	val, err = cfg.Get("api.instill.tech", "oauth_token")
+	assert.NoError(t, err)
+	assert.Equal(t, "TOKEN", val)
+
+	assert.NoError(t, cfg.Set("", "pager", "less"))
+	assert.NoError(t, cfg.Write())
+
+	// Write must keep the on-disk format: the TOML files are rewritten in
+	// place and no YAML siblings appear.
+	assert.False(t, fileExists(filepath.Join(configDir, "config.yml")))
+	assert.False(t, fileExists(filepath.Join(configDir, "hosts.yml")))
+
+	configBytes, err := os.ReadFile(filepath.Join(configDir, "config.toml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(configBytes), "pager = ")
+	assert.Contains(t, string(configBytes), "editor = ")
+
+	cfg, err = ParseDefaultConfig()
+	assert.NoError(t, err)
+	val, err = cfg.Get("", "pager")
+	assert.NoError(t, err)
+	assert.Equal(t, "less", val)
+	val, err = cfg.Get("api.instill.tech", "oauth_token")
+	assert.NoError(t, err)
+	assert.Equal(t, "TOKEN", val)
+}
+
+func Test_parseConfigDataForFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		wantErr  string
+	}{
+		{
+			name:     "yaml",
+			filename: "config.yml",
+			data:     "editor: vim\n",
+		},
+		{
+			name:     "toml",
+			filename: "config.toml",
+			data:     "editor = \"vim\"\n",
+		},
+		{
+			name:     "empty toml",
+			filename: "config.toml",
+			data:     "",
+		},
+		{
+			name:     "invalid toml",
+			filename: "config.toml",
+			data:     "editor: vim\n",
+			wantErr:  "toml:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := parseConfigDataForFile(tt.filename, []byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, root)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// getIntValue backs every implementation's GetInt. The stored value is parsed
+// as a decimal integer, with the option's declared default filling in when the
+// key is unset.
+func getIntValue(c Config, hostname, key string) (int, error) {
+	value := typedRawValue(c, hostname, key)
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for %s", value, key)
+	}
+	return n, nil
+}
+
+// getBoolValue backs every implementation's GetBool, accepting the forms
+// strconv.ParseBool does ("true", "1", "T", ...) in any case.
+func getBoolValue(c Config, hostname, key string) (bool, error) {
+	value := typedRawValue(c, hostname, key)
+	b, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(value)))
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s", value, key)
+	}
+	return b, nil
+}
+
+// typedRawValue reads a key's stored value, substituting the option's default
+// when the key is unset or empty.
+func typedRawValue(c Config, hostname, key string) string {
+	value, err := c.Get(hostname, key)
+	if err != nil || value == "" {
+		if opt := findOption(key); opt != nil {
+			value = opt.DefaultValue
+		}
+	}
+	return value
+}
+
+func (c *fileConfig) GetInt(hostname, key string) (int, error) {
+	return getIntValue(c, hostname, key)
+}
+
+func (c *fileConfig) GetBool(hostname, key string) (bool, error) {
+	return getBoolValue(c, hostname, key)
+}
+
+func (c *inMemoryConfig) GetInt(hostname, key string) (int, error) {
+	return getIntValue(c, hostname, key)
+}
+
+func (c *inMemoryConfig) GetBool(hostname, key string) (bool, error) {
+	return getBoolValue(c, hostname, key)
+}
+
+// the overrides layer resolves through its own Get so `--config-key` values
+// take effect for typed reads too
+func (c *overridesConfig) GetInt(hostname, key string) (int, error) {
+	return getIntValue(c, hostname, key)
+}
+
+func (c *overridesConfig) GetBool(hostname, key string) (bool, error) {
+	return getBoolValue(c, hostname, key)
+}
+
+func (c ConfigStub) GetInt(hostname, key string) (int, error) {
+	return getIntValue(c, hostname, key)
+}
+
+func (c ConfigStub) GetBool(hostname, key string) (bool, error) {
+	return getBoolValue(c, hostname, key)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetInt(t *testing.T) {
+	cfg := MustNewFromString("http_retry_base_ms: 500\nhttp_max_idle_conns: lots\n")
+
+	n, err := cfg.GetInt("", "http_retry_base_ms")
+	assert.NoError(t, err)
+	assert.Equal(t, 500, n)
+
+	// unset keys fall back to the option's declared default
+	n, err = cfg.GetInt("", "http_retry_max_ms")
+	assert.NoError(t, err)
+	assert.Equal(t, 4000, n)
+
+	_, err = cfg.GetInt("", "http_max_idle_conns")
+	assert.EqualError(t, err, `invalid value "lots" for http_max_idle_conns`)
+}
+
+func Test_GetBool(t *testing.T) {
+	cfg := MustNewFromString("http_force_http1: TRUE\nhttp_retry_jitter: maybe\n")
+
+	on, err := cfg.GetBool("", "http_force_http1")
+	assert.NoError(t, err)
+	assert.True(t, on)
+
+	_, err = cfg.GetBool("", "http_retry_jitter")
+	assert.EqualError(t, err, `invalid value "maybe" for http_retry_jitter`)
+
+	// an unset key yields the option's default
+	on, err = MustNewFromString("").GetBool("", "http_retry_jitter")
+	assert.NoError(t, err)
+	assert.True(t, on)
+}
+
+func Test_GetInt_overrides(t *testing.T) {
+	base := MustNewFromString("http_retry_base_ms: 500\n")
+	cfg, err := NewWithOverrides(base, map[string]string{"http_retry_base_ms": "900"})
+	assert.NoError(t, err)
+
+	n, err := cfg.GetInt("", "http_retry_base_ms")
+	assert.NoError(t, err)
+	assert.Equal(t, 900, n)
+}
+
+func Test_NewWithOverrides_typedValue(t *testing.T) {
+	base := MustNewFromString("")
+	_, err := NewWithOverrides(base, map[string]string{"http_retry_base_ms": "soon"})
+	assert.EqualError(t, err, `invalid value "soon" for config key "http_retry_base_ms": expected an integer value`)
+}
+
+func Test_ConfigStub_typedValues(t *testing.T) {
+	stub := ConfigStub{"http_max_idle_conns": "10", "http_force_http1": "1"}
+
+	n, err := stub.GetInt("", "http_max_idle_conns")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	on, err := stub.GetBool("", "http_force_http1")
+	assert.NoError(t, err)
+	assert.True(t, on)
+}
@@ -0,0 +1,32 @@
+package config
+
+// structuralKeys are top-level keys that are part of the file layout rather
+// than configuration options.
+var structuralKeys = []string{"hosts", "aliases", "profiles", "default_headers"}
+
+// UnknownKeys returns top-level config keys that are neither known options
+// nor structural keys, e.g. typos like "protcol".
+func (c *fileConfig) UnknownKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var unknown []string
+	content := c.Root().Content
+	for i := 0; i < len(content)-1; i += 2 {
+		key := content[i].Value
+		if isStructuralKey(key) || ValidateKey(key) == nil {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	return unknown
+}
+
+func isStructuralKey(key string) bool {
+	for _, k := range structuralKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ package httpunix
 import (
 	"net"
 	"net/http"
+	"sync"
 )
 
 // NewRoundTripper returns an http.RoundTripper which sends requests via a unix
@@ -19,3 +20,44 @@ func NewRoundTripper(socketPath string) http.RoundTripper {
 		DisableKeepAlives: true,
 	}
 }
+
+// NewHostRoundTripper returns an http.RoundTripper that resolves a unix socket
+// path per request host via lookup. Requests to hosts with a non-empty socket
+// path are dialed through that socket; all other requests use fallback.
+func NewHostRoundTripper(lookup func(host string) string, fallback http.RoundTripper) http.RoundTripper {
+	return &hostRoundTripper{
+		lookup:     lookup,
+		fallback:   fallback,
+		transports: map[string]http.RoundTripper{},
+	}
+}
+
+type hostRoundTripper struct {
+	lookup   func(host string) string
+	fallback http.RoundTripper
+
+	mu         sync.Mutex
+	transports map[string]http.RoundTripper
+}
+
+func (t *hostRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Hostname()
+	}
+
+	socketPath := t.lookup(host)
+	if socketPath == "" {
+		return t.fallback.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	tr, ok := t.transports[socketPath]
+	if !ok {
+		tr = NewRoundTripper(socketPath)
+		t.transports[socketPath] = tr
+	}
+	t.mu.Unlock()
+
+	return tr.RoundTrip(req)
+}
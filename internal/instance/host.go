@@ -36,6 +36,35 @@ func HostnameValidator(v string) error {
 }
 
 // GetProtocol returns the correct protocol based on a hostname
+// NormalizeHostname canonicalizes a user-supplied hostname so the same
+// instance always resolves to one name: any scheme and path are stripped, the
+// name is lowercased without a trailing dot, and a port equal to the scheme
+// default (80 for local instances, 443 otherwise) is dropped. Inputs that do
+// not contain a valid hostname are rejected.
+func NormalizeHostname(v string) (string, error) {
+	hostname := strings.TrimSpace(v)
+	if idx := strings.Index(hostname, "://"); idx > -1 {
+		hostname = hostname[idx+3:]
+	}
+	if idx := strings.IndexRune(hostname, '/'); idx > -1 {
+		hostname = hostname[:idx]
+	}
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+	if host, port, found := strings.Cut(hostname, ":"); found {
+		defaultPort := "443"
+		if IsLocal(host) {
+			defaultPort = "80"
+		}
+		if port == defaultPort {
+			hostname = host
+		}
+	}
+	if err := HostnameValidator(hostname); err != nil {
+		return "", err
+	}
+	return hostname, nil
+}
+
 func GetProtocol(hostname string) string {
 	if IsLocal(hostname) {
 		return fmt.Sprintf("http://%s/", hostname)
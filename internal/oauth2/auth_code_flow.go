@@ -71,7 +71,7 @@ func NewAuthenticator(issuer, clientID, clientSecret, callbackHost string, callb
 		ClientSecret: clientSecret,
 		Endpoint:     provider.Endpoint(),
 		RedirectURL:  fmt.Sprintf("http://%s:%d/%s", callbackHost, callbackPort, "callback"),
-		Scopes:       []string{"offline_access", "openid", "email", "profile"},
+		Scopes:       defaultScopes,
 	}
 
 	return &Authenticator{
@@ -96,6 +96,12 @@ func (a *Authenticator) VerifyIDToken(ctx context.Context, token *oauth2.Token)
 
 // AuthCodeFlowWithConfig authorizes a user via Authorization Code Flow
 func AuthCodeFlowWithConfig(f *cmdutil.Factory, host *config.HostConfigTyped, cfg iconfig, IO *iostreams.IOStreams) error {
+	return AuthCodeFlowWithScopes(f, host, cfg, IO, nil)
+}
+
+// AuthCodeFlowWithScopes is AuthCodeFlowWithConfig with additional OAuth
+// scopes requested on top of the defaults.
+func AuthCodeFlowWithScopes(f *cmdutil.Factory, host *config.HostConfigTyped, cfg iconfig, IO *iostreams.IOStreams, extraScopes []string) error {
 
 	serverHost := "localhost"
 	serverPort := 8085
@@ -112,6 +118,7 @@ func AuthCodeFlowWithConfig(f *cmdutil.Factory, host *config.HostConfigTyped, cf
 	if err != nil {
 		return err
 	}
+	auth.Scopes = append(auth.Scopes, extraScopes...)
 
 	prompt := []string{""}
 	maxAge := 0
@@ -120,7 +127,11 @@ func AuthCodeFlowWithConfig(f *cmdutil.Factory, host *config.HostConfigTyped, cf
 	fmt.Fprintf(IO.Out, "Log into %s. Press ctrl + c to end the process.\n\n", host.Oauth2Hostname)
 	fmt.Fprintf(IO.Out, "Complete the login via your OIDC provider. Launching a browser to:\n\n\t%s\n\n", loginURL)
 
-	if err := f.Browser.Browse(loginURL); err != nil {
+	browserCfg, err := f.Config()
+	if err != nil {
+		return err
+	}
+	if err := cmdutil.OpenInBrowser(browserCfg, loginURL); err != nil {
 		return err
 	}
 
@@ -145,6 +156,8 @@ func AuthCodeFlowWithConfig(f *cmdutil.Factory, host *config.HostConfigTyped, cf
 	host.RefreshToken = token.RefreshToken
 	host.Expiry = token.Expiry.Format(time.RFC1123)
 	host.IDToken = token.Extra("id_token").(string)
+	grantedRaw, _ := token.Extra("scope").(string)
+	host.Scopes = resolveGrantedScopes(IO, grantedRaw, auth.Scopes, extraScopes)
 	if err := cfg.SaveTyped(host); err != nil {
 		return err
 	}
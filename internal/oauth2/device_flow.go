@@ -0,0 +1,200 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+// swappable for tests
+var deviceFlowSleep = time.Sleep
+var deviceFlowNow = time.Now
+
+// deviceCodeResponse is the device authorization response per RFC 8628.
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceTokenResponse is the token endpoint response while polling.
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	IDToken          string `json:"id_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	Scope            string `json:"scope"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceCodeFlowWithConfig authorizes a user via the OAuth device-code grant
+// (RFC 8628), for environments without a browser. The verification URL and
+// user code are printed to ErrOut, and the token endpoint is polled honoring
+// the server-provided interval until the code is approved or expires.
+func DeviceCodeFlowWithConfig(host *config.HostConfigTyped, cfg iconfig, IO *iostreams.IOStreams) error {
+	return DeviceCodeFlowWithScopes(host, cfg, IO, nil)
+}
+
+// DeviceCodeFlowWithScopes is DeviceCodeFlowWithConfig with additional OAuth
+// scopes requested on top of the defaults.
+func DeviceCodeFlowWithScopes(host *config.HostConfigTyped, cfg iconfig, IO *iostreams.IOStreams, extraScopes []string) error {
+	issuer := host.APIHostname
+	if host.Oauth2Issuer != "" {
+		issuer = host.Oauth2Issuer
+	}
+	audience := host.APIHostname
+	if host.Oauth2Audience != "" {
+		audience = host.Oauth2Audience
+	}
+
+	provider, err := oidc.NewProvider(context.Background(), issuer)
+	if err != nil {
+		return err
+	}
+	deviceEndpoint := deviceAuthorizationEndpoint(provider, issuer)
+
+	scopes := append(append([]string{}, defaultScopes...), extraScopes...)
+	code, err := requestDeviceCode(http.DefaultClient, deviceEndpoint, host.Oauth2ClientID, audience, scopes)
+	if err != nil {
+		return err
+	}
+
+	verificationURL := code.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = code.VerificationURI
+	}
+	fmt.Fprintf(IO.ErrOut, "First copy your one-time code: %s\n", IO.ColorScheme().Bold(code.UserCode))
+	fmt.Fprintf(IO.ErrOut, "Then visit this URL to authorize the device:\n\n\t%s\n\n", verificationURL)
+
+	IO.StartProgressIndicatorWithLabel("Waiting for authorization")
+	token, err := pollDeviceToken(http.DefaultClient, provider.Endpoint().TokenURL, host, code)
+	IO.StopProgressIndicator()
+	if err != nil {
+		return err
+	}
+
+	host.TokenType = token.TokenType
+	host.AccessToken = token.AccessToken
+	host.RefreshToken = token.RefreshToken
+	host.Expiry = deviceFlowNow().Add(time.Duration(token.ExpiresIn) * time.Second).Format(time.RFC1123)
+	host.IDToken = token.IDToken
+	host.Scopes = resolveGrantedScopes(IO, token.Scope, scopes, extraScopes)
+	if err := cfg.SaveTyped(host); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(IO.ErrOut, "%s Authentication complete.\n", IO.ColorScheme().SuccessIcon())
+	return nil
+}
+
+// deviceAuthorizationEndpoint reads the device authorization endpoint from
+// OIDC discovery, falling back to the de-facto default path on the issuer.
+func deviceAuthorizationEndpoint(provider *oidc.Provider, issuer string) string {
+	var claims struct {
+		DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	}
+	if err := provider.Claims(&claims); err == nil && claims.DeviceAuthorizationEndpoint != "" {
+		return claims.DeviceAuthorizationEndpoint
+	}
+	return strings.TrimSuffix(issuer, "/") + "/oauth/device/code"
+}
+
+func requestDeviceCode(client *http.Client, endpoint, clientID, audience string, scopes []string) (*deviceCodeResponse, error) {
+	form := url.Values{
+		"client_id": {clientID},
+		"scope":     {strings.Join(scopes, " ")},
+		"audience":  {audience},
+	}
+	resp, err := client.PostForm(endpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device code request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var code deviceCodeResponse
+	if err := json.Unmarshal(body, &code); err != nil {
+		return nil, err
+	}
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return nil, errors.New("device code response is missing required fields")
+	}
+	return &code, nil
+}
+
+func pollDeviceToken(client *http.Client, tokenURL string, host *config.HostConfigTyped, code *deviceCodeResponse) (*deviceTokenResponse, error) {
+	interval := code.Interval
+	if interval <= 0 {
+		interval = 5
+	}
+	deadline := deviceFlowNow().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		if code.ExpiresIn > 0 && deviceFlowNow().After(deadline) {
+			return nil, errors.New("the device code has expired; run `inst auth login` to try again")
+		}
+		deviceFlowSleep(time.Duration(interval) * time.Second)
+
+		form := url.Values{
+			"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code":   {code.DeviceCode},
+			"client_id":     {host.Oauth2ClientID},
+			"client_secret": {host.Oauth2ClientSecret},
+		}
+		resp, err := client.PostForm(tokenURL, form)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var token deviceTokenResponse
+		if err := json.Unmarshal(body, &token); err != nil {
+			return nil, fmt.Errorf("could not parse token response: %w", err)
+		}
+
+		switch token.Error {
+		case "":
+			if token.AccessToken == "" {
+				return nil, errors.New("token response is missing an access token")
+			}
+			return &token, nil
+		case "authorization_pending":
+			// keep polling at the current interval
+		case "slow_down":
+			interval += 5
+		case "expired_token":
+			return nil, errors.New("the device code has expired; run `inst auth login` to try again")
+		default:
+			if token.ErrorDescription != "" {
+				return nil, fmt.Errorf("%s: %s", token.Error, token.ErrorDescription)
+			}
+			return nil, fmt.Errorf("device authorization failed: %s", token.Error)
+		}
+	}
+}
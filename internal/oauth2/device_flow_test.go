@@ -0,0 +1,103 @@
+package oauth2
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func stubDeviceFlowClock(t *testing.T) *time.Duration {
+	t.Helper()
+	origSleep, origNow := deviceFlowSleep, deviceFlowNow
+	t.Cleanup(func() {
+		deviceFlowSleep, deviceFlowNow = origSleep, origNow
+	})
+
+	var elapsed time.Duration
+	base := time.Now()
+	deviceFlowSleep = func(d time.Duration) { elapsed += d }
+	deviceFlowNow = func() time.Time { return base.Add(elapsed) }
+	return &elapsed
+}
+
+func Test_requestDeviceCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client123", r.Form.Get("client_id"))
+		assert.Equal(t, "openid custom:scope", r.Form.Get("scope"))
+		fmt.Fprint(w, `{"device_code":"dev123","user_code":"ABCD-1234","verification_uri":"https://example.com/activate","expires_in":900,"interval":5}`)
+	}))
+	defer server.Close()
+
+	code, err := requestDeviceCode(server.Client(), server.URL, "client123", "aud", []string{"openid", "custom:scope"})
+	require.NoError(t, err)
+	assert.Equal(t, "dev123", code.DeviceCode)
+	assert.Equal(t, "ABCD-1234", code.UserCode)
+	assert.Equal(t, 5, code.Interval)
+}
+
+func Test_pollDeviceToken(t *testing.T) {
+	elapsed := stubDeviceFlowClock(t)
+
+	responses := []string{
+		`{"error":"authorization_pending"}`,
+		`{"error":"slow_down"}`,
+		`{"access_token":"at123","refresh_token":"rt123","id_token":"id123","token_type":"Bearer","expires_in":3600}`,
+	}
+	call := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.Form.Get("grant_type"))
+		assert.Equal(t, "dev123", r.Form.Get("device_code"))
+		fmt.Fprint(w, responses[call])
+		call++
+	}))
+	defer server.Close()
+
+	host := &config.HostConfigTyped{Oauth2ClientID: "client123"}
+	code := &deviceCodeResponse{DeviceCode: "dev123", ExpiresIn: 900, Interval: 5}
+
+	token, err := pollDeviceToken(server.Client(), server.URL, host, code)
+	require.NoError(t, err)
+	assert.Equal(t, "at123", token.AccessToken)
+	assert.Equal(t, 3, call)
+	// 5s + 5s + 10s after slow_down
+	assert.Equal(t, 20*time.Second, *elapsed)
+}
+
+func Test_pollDeviceToken_expires(t *testing.T) {
+	stubDeviceFlowClock(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error":"authorization_pending"}`)
+	}))
+	defer server.Close()
+
+	host := &config.HostConfigTyped{Oauth2ClientID: "client123"}
+	code := &deviceCodeResponse{DeviceCode: "dev123", ExpiresIn: 12, Interval: 5}
+
+	_, err := pollDeviceToken(server.Client(), server.URL, host, code)
+	assert.ErrorContains(t, err, "device code has expired")
+}
+
+func Test_pollDeviceToken_denied(t *testing.T) {
+	stubDeviceFlowClock(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error":"access_denied","error_description":"The user rejected the request"}`)
+	}))
+	defer server.Close()
+
+	host := &config.HostConfigTyped{Oauth2ClientID: "client123"}
+	code := &deviceCodeResponse{DeviceCode: "dev123", ExpiresIn: 900, Interval: 5}
+
+	_, err := pollDeviceToken(server.Client(), server.URL, host, code)
+	assert.EqualError(t, err, "access_denied: The user rejected the request")
+}
@@ -0,0 +1,36 @@
+package oauth2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+// defaultScopes are requested with every OAuth flow.
+var defaultScopes = []string{"offline_access", "openid", "email", "profile"}
+
+// resolveGrantedScopes interprets the scope field of a token response and
+// warns about any explicitly requested scopes the server declined. Servers
+// that omit the field are assumed to have granted everything requested.
+func resolveGrantedScopes(IO *iostreams.IOStreams, grantedRaw string, requested, extraScopes []string) []string {
+	if grantedRaw == "" {
+		return requested
+	}
+	granted := strings.Fields(grantedRaw)
+	for _, scope := range extraScopes {
+		if !containsScope(granted, scope) {
+			fmt.Fprintf(IO.ErrOut, "%s scope %q was requested but not granted\n", IO.ColorScheme().WarningIcon(), scope)
+		}
+	}
+	return granted
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
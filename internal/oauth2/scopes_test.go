@@ -0,0 +1,34 @@
+package oauth2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_resolveGrantedScopes(t *testing.T) {
+	requested := []string{"openid", "email", "read:pipelines"}
+
+	t.Run("server omits the scope field", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		granted := resolveGrantedScopes(io, "", requested, []string{"read:pipelines"})
+		assert.Equal(t, requested, granted)
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("all requested scopes granted", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		granted := resolveGrantedScopes(io, "openid email read:pipelines", requested, []string{"read:pipelines"})
+		assert.Equal(t, []string{"openid", "email", "read:pipelines"}, granted)
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("denied scopes produce a warning", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		granted := resolveGrantedScopes(io, "openid email", requested, []string{"read:pipelines"})
+		assert.Equal(t, []string{"openid", "email"}, granted)
+		assert.Contains(t, stderr.String(), `scope "read:pipelines" was requested but not granted`)
+	})
+}
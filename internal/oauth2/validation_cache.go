@@ -0,0 +1,68 @@
+package oauth2
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// ValidationCache records when a host's access token was last validated, so
+// repeated commands in a script do not each pay for a validation round trip.
+// Entries live in the state directory, keyed by a hash of host and token, and
+// expire after the TTL. The entry's modification time carries the timestamp.
+type ValidationCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewValidationCache returns a cache rooted in the state directory. A zero or
+// negative TTL disables the cache: IsValid always reports false and
+// MarkValidated records nothing.
+func NewValidationCache(ttl time.Duration) *ValidationCache {
+	return &ValidationCache{
+		dir: filepath.Join(config.StateDir(), "token-validations"),
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// IsValid reports whether the token was validated against the host within the
+// TTL.
+func (c *ValidationCache) IsValid(hostname, token string) bool {
+	if c.ttl <= 0 || token == "" {
+		return false
+	}
+	info, err := os.Stat(c.entryPath(hostname, token))
+	return err == nil && c.now().Sub(info.ModTime()) <= c.ttl
+}
+
+// MarkValidated records a successful validation of the token against the host.
+func (c *ValidationCache) MarkValidated(hostname, token string) error {
+	if c.ttl <= 0 || token == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	path := c.entryPath(hostname, token)
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		return err
+	}
+	now := c.now()
+	return os.Chtimes(path, now, now)
+}
+
+// Invalidate forgets a token's validation, e.g. after the server answered 401.
+func (c *ValidationCache) Invalidate(hostname, token string) {
+	_ = os.Remove(c.entryPath(hostname, token))
+}
+
+func (c *ValidationCache) entryPath(hostname, token string) string {
+	digest := sha256.Sum256([]byte(hostname + ":" + token))
+	return filepath.Join(c.dir, fmt.Sprintf("%x", digest))
+}
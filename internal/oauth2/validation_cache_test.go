@@ -0,0 +1,42 @@
+package oauth2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidationCache(t *testing.T) {
+	now := time.Now()
+	cache := &ValidationCache{
+		dir: t.TempDir(),
+		ttl: 5 * time.Minute,
+		now: func() time.Time { return now },
+	}
+
+	assert.False(t, cache.IsValid("my.instance", "TOKEN"))
+
+	require.NoError(t, cache.MarkValidated("my.instance", "TOKEN"))
+	assert.True(t, cache.IsValid("my.instance", "TOKEN"))
+
+	// a different token or host misses the cache
+	assert.False(t, cache.IsValid("my.instance", "OTHER"))
+	assert.False(t, cache.IsValid("other.instance", "TOKEN"))
+
+	// entries expire after the TTL
+	now = now.Add(6 * time.Minute)
+	assert.False(t, cache.IsValid("my.instance", "TOKEN"))
+
+	now = now.Add(-6 * time.Minute)
+	cache.Invalidate("my.instance", "TOKEN")
+	assert.False(t, cache.IsValid("my.instance", "TOKEN"))
+}
+
+func Test_ValidationCache_disabled(t *testing.T) {
+	cache := &ValidationCache{dir: t.TempDir(), ttl: 0, now: time.Now}
+
+	require.NoError(t, cache.MarkValidated("my.instance", "TOKEN"))
+	assert.False(t, cache.IsValid("my.instance", "TOKEN"))
+}
@@ -53,6 +53,34 @@ func CheckForUpdate(client *api.Client, stateFilePath, repo, currentVersion stri
 	return nil, nil
 }
 
+// LatestRelease returns the most recent release of repo, reusing a cached
+// result when it was fetched within the last 24 hours.
+func LatestRelease(client *api.Client, stateFilePath, repo string) (*ReleaseInfo, error) {
+	stateEntry, _ := getStateEntry(stateFilePath)
+	if stateEntry != nil && time.Since(stateEntry.CheckedForUpdateAt).Hours() < 24 {
+		latestRelease := stateEntry.LatestRelease
+		return &latestRelease, nil
+	}
+
+	releaseInfo, err := getLatestReleaseInfo(client, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setStateEntry(stateFilePath, time.Now(), *releaseInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return releaseInfo, nil
+}
+
+// IsUpdateAvailable reports whether latestVersion is a newer semantic version
+// than currentVersion.
+func IsUpdateAvailable(latestVersion, currentVersion string) bool {
+	return versionGreaterThan(latestVersion, currentVersion)
+}
+
 func getLatestReleaseInfo(client *api.Client, repo string) (*ReleaseInfo, error) {
 	var latestRelease ReleaseInfo
 	err := client.REST("github.com", "GET", fmt.Sprintf("repos/%s/releases/latest", repo), nil, &latestRelease)
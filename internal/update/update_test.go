@@ -123,3 +123,47 @@ func tempFilePath() string {
 	os.Remove(file.Name())
 	return file.Name()
 }
+
+func TestLatestRelease_cache(t *testing.T) {
+	http := &httpmock.Registry{}
+	client := api.NewClient(api.ReplaceTripper(http))
+
+	http.Register(
+		httpmock.REST("GET", "repos/OWNER/REPO/releases/latest"),
+		httpmock.StringResponse(`{
+			"tag_name": "v1.1.0",
+			"html_url": "https://example.com/v1.1.0"
+		}`),
+	)
+
+	stateFilePath := tempFilePath()
+
+	rel, err := LatestRelease(client, stateFilePath, "OWNER/REPO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Version != "v1.1.0" {
+		t.Errorf("Version: %q", rel.Version)
+	}
+
+	// a second lookup within 24h is served from the cached state file
+	rel, err = LatestRelease(client, stateFilePath, "OWNER/REPO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Version != "v1.1.0" {
+		t.Errorf("Version: %q", rel.Version)
+	}
+	if len(http.Requests) != 1 {
+		t.Fatalf("expected 1 HTTP request, got %d", len(http.Requests))
+	}
+}
+
+func TestIsUpdateAvailable(t *testing.T) {
+	if !IsUpdateAvailable("v1.1.0", "v1.0.0") {
+		t.Error("expected v1.1.0 to be newer than v1.0.0")
+	}
+	if IsUpdateAvailable("v1.0.0", "v1.0.0") {
+		t.Error("expected v1.0.0 not to be newer than itself")
+	}
+}
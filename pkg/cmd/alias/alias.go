@@ -0,0 +1,28 @@
+package alias
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/pkg/cmdutil"
+
+	deleteCmd "github.com/instill-ai/cli/pkg/cmd/alias/delete"
+	listCmd "github.com/instill-ai/cli/pkg/cmd/alias/list"
+	setCmd "github.com/instill-ai/cli/pkg/cmd/alias/set"
+)
+
+// NewCmdAlias creates the `alias` command group
+func NewCmdAlias(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias <command>",
+		Short: "Create command shortcuts",
+		Long:  `Aliases can be used to make shortcuts for inst commands or to compose multiple commands.`,
+	}
+
+	cmdutil.DisableAuthCheck(cmd)
+
+	cmd.AddCommand(setCmd.NewCmdSet(f, nil))
+	cmd.AddCommand(listCmd.NewCmdList(f, nil))
+	cmd.AddCommand(deleteCmd.NewCmdDelete(f, nil))
+
+	return cmd
+}
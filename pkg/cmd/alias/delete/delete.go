@@ -0,0 +1,73 @@
+package delete
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type DeleteOptions struct {
+	IO     *iostreams.IOStreams
+	Config func() (config.Config, error)
+
+	Name string
+}
+
+func NewCmdDelete(f *cmdutil.Factory, runF func(*DeleteOptions) error) *cobra.Command {
+	opts := &DeleteOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete <alias>",
+		Short: "Delete an alias",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return deleteRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func deleteRun(opts *DeleteOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	aliases, err := cfg.Aliases()
+	if err != nil {
+		return err
+	}
+	expansion, found := aliases[opts.Name]
+	if !found {
+		return fmt.Errorf("no such alias %q", opts.Name)
+	}
+
+	err = cfg.DeleteAlias(opts.Name)
+	if err != nil {
+		return fmt.Errorf("could not delete alias: %w", err)
+	}
+
+	err = cfg.Write()
+	if err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.ErrOut, "%s Deleted alias %s; was %s\n", cs.SuccessIcon(), opts.Name, expansion)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+type SetOptions struct {
+	IO     *iostreams.IOStreams
+	Config func() (config.Config, error)
+
+	Name      string
+	Expansion string
+
+	validCommand func(string) bool
+}
+
+func NewCmdSet(f *cmdutil.Factory, runF func(*SetOptions) error) *cobra.Command {
+	opts := &SetOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set <alias> <expansion>",
+		Short: "Create a shortcut for an inst command",
+		Long: heredoc.Doc(`
+			Declare a word as a command alias that will expand to the specified command(s).
+
+			The expansion may specify additional arguments and flags. If the expansion
+			includes positional placeholders such as "$1", extra arguments that follow the
+			alias will be inserted appropriately. Otherwise, extra arguments will be
+			appended to the expanded command.
+		`),
+		Example: heredoc.Doc(`
+			$ inst alias set pl 'api vdp/v1alpha/pipelines'
+			$ inst pl
+			# => inst api vdp/v1alpha/pipelines
+
+			$ inst alias set trigger 'api vdp/v1alpha/pipelines/$1/trigger'
+			$ inst trigger hello
+			# => inst api vdp/v1alpha/pipelines/hello/trigger
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			opts.Expansion = args[1]
+
+			opts.validCommand = func(args string) bool {
+				split, err := shlex.Split(args)
+				if err != nil {
+					return false
+				}
+				rootCmd := cmd.Root()
+				cmd, _, err := rootCmd.Traverse(split)
+				return err == nil && cmd != rootCmd
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return setRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func setRun(opts *SetOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	if opts.validCommand != nil && opts.validCommand(opts.Name) {
+		if !opts.IO.CanPrompt() {
+			return fmt.Errorf("could not create alias: %q is already an inst command", opts.Name)
+		}
+		var keepGoing bool
+		err = prompt.SurveyAskOne(&survey.Confirm{
+			Message: fmt.Sprintf("%q is already an inst command. Shadow it with the alias anyway?", opts.Name),
+			Default: false,
+		}, &keepGoing)
+		if err != nil {
+			return fmt.Errorf("could not prompt: %w", err)
+		}
+		if !keepGoing {
+			return cmdutil.CancelError
+		}
+	}
+
+	if opts.validCommand != nil && !opts.validCommand(opts.Expansion) {
+		return fmt.Errorf("could not create alias: %s does not correspond to an inst command", opts.Expansion)
+	}
+
+	err = cfg.SetAlias(opts.Name, opts.Expansion)
+	if err != nil {
+		return fmt.Errorf("could not create alias: %w", err)
+	}
+
+	err = cfg.Write()
+	if err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.ErrOut, "%s Added alias %s\n", cs.SuccessIcon(), opts.Name)
+	}
+	return nil
+}
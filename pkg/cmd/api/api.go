@@ -2,12 +2,15 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"regexp"
 	"sort"
 	"strconv"
@@ -16,33 +19,87 @@ import (
 	"time"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/spf13/cobra"
 
 	"github.com/instill-ai/cli/api"
 	"github.com/instill-ai/cli/internal/config"
-	"github.com/instill-ai/cli/internal/instance"
+	"github.com/instill-ai/cli/pkg/cmd/factory"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/export"
 	"github.com/instill-ai/cli/pkg/iostreams"
 	"github.com/instill-ai/cli/pkg/jsoncolor"
+	"github.com/instill-ai/cli/utils"
 )
 
 type ApiOptions struct {
 	IO *iostreams.IOStreams
 
+	// Context is cancelled on SIGINT/SIGTERM so in-flight requests abort
+	// cleanly; a nil Context behaves like context.Background()
+	Context context.Context
+
 	Hostname            string
+	BaseURL             string
+	NoAuth              bool
 	RequestMethod       string
 	RequestMethodPassed bool
 	RequestPath         string
 	RequestInputFile    string
+	RawBody             string
+	Form                bool
+	Wait                bool
+	WaitField           string
+	WaitUntil           string
+	WaitInterval        time.Duration
+	WaitTimeout         time.Duration
 	MagicFields         []string
 	RawFields           []string
+	PathArgs            []string
+	Resolve             []string
 	RequestHeaders      []string
 	ShowResponseHeaders bool
+	HeadRequest         bool
 	Silent              bool
+	Pretty              bool
+	Compact             bool
+	ExplainError        bool
+	ExpectStatus        []int
+	DryRun              bool
+	RespectRateLimit    bool
+	MaxTime             time.Duration
+	PartialOK           bool
+	Verbose             bool
+	ShowRetries         bool
+	Trace               bool
+	BatchInput          bool
+	Concurrency         int
+	FailFast            bool
+	Paginate            bool
+	Stream              bool
+	Slurp               bool
+	GraphQLPaginate     bool
+	GraphQLPaginatePath string
+	PerPage             int
+	Limit               int
+	Since               string
+	Until               string
+	SinceParam          string
+	UntilParam          string
+	QueryParams         []string
+	QueryReplace        bool
+	OutputFile          string
+	MaxResponseSize     string
+	ValidateSchema      string
+	APIVersion          string
 	Template            string
+	TemplateFile        string
 	CacheTTL            time.Duration
+	CacheDir            string
+	ClearCache          bool
 	FilterOutput        string
+	Fields              []string
+	OutputFormat        string
 
 	Config     func() (config.Config, error)
 	HTTPClient func() (*http.Client, error)
@@ -55,6 +112,7 @@ func NewCmdAPI(f *cmdutil.Factory, runF func(*ApiOptions) error) *cobra.Command
 		Config:     f.Config,
 		HTTPClient: f.HTTPClient,
 	}
+	var remoteName bool
 	// TODO handle error
 	cfg, _ := opts.Config()
 
@@ -64,13 +122,20 @@ func NewCmdAPI(f *cmdutil.Factory, runF func(*ApiOptions) error) *cobra.Command
 		Long: heredoc.Docf(`
 			Makes an authenticated HTTP request to the Instill API and prints the response.
 
-			The endpoint argument should be a path of a Instill API endpoint.
+			The endpoint argument should be a path of a Instill API endpoint, or one of
+			the following shorthands for common resources:
+
+			%[2]s
+
+			Anything else, including paths starting with "/", is used as a literal path.
 
 			Note that in some shells, for example PowerShell, you may need to enclose any value that
 			contains "{...}" in quotes to prevent the shell from applying special meaning to curly braces.
 
-			The default HTTP request method is "GET" normally and "POST" if any parameters
-			were added. Override the method with %[1]s--method%[1]s.
+			The default HTTP request method is "GET" if no request body is involved, and
+			"POST" when parameters were added with %[1]s--field%[1]s/%[1]s--raw-field%[1]s or a body was
+			passed via %[1]s--input%[1]s. Override the method with %[1]s--method%[1]s; note that the server
+			may ignore a request body sent with an explicit "GET".
 
 			Pass one or more %[1]s--raw-field%[1]s values in "key=value" format to add string
 			parameters to the request payload. To add non-string parameters, see %[1]s--field%[1]s below.
@@ -83,14 +148,136 @@ func NewCmdAPI(f *cmdutil.Factory, runF func(*ApiOptions) error) *cobra.Command
 			- literal values "true", "false", "null", and integer numbers get converted to
 			  appropriate JSON types;
 			- if the value starts with "@", the rest of the value is interpreted as a
-			  filename to read the value from. Pass "-" to read from standard input.
+			  filename to read the value from. Pass "-" to read from standard input;
+			- if the value starts with a backslash, the remainder is always sent as a
+			  string, e.g. %[1]s-F version=\1%[1]s sends "1" instead of the number 1.
+
+			Values that do not match any of the above, such as "1.2", are sent as strings.
+
+			With %[1]s--query%[1]s, parameters are URL-encoded and appended to the endpoint's
+			query string, so special characters never need escaping by hand. The flag
+			may be repeated, and repeating a key sends it as an array. A key that the
+			endpoint already carries keeps its value and gains the new one as a
+			repeated key; pass %[1]s--query-replace%[1]s to drop the endpoint's value instead.
+			A value of "@file" is read from a file, and "@-" from standard input.
 
 			Raw request body may be passed from the outside via a file specified by %[1]s--input%[1]s.
 			Pass "-" to read from standard input. In this mode, parameters specified via
 			%[1]s--field%[1]s flags are serialized into URL query parameters.
-		`, "`"),
+
+			With %[1]s--raw-body%[1]s, the given string is sent as the request body exactly as
+			typed, with no JSON wrapping; %[1]s--raw-body @file%[1]s reads the bytes from a file
+			instead. The Content-Type defaults to "text/plain" unless an explicit
+			%[1]s--header%[1]s overrides it. This suits endpoints expecting NDJSON, plain text,
+			or other custom payloads, and cannot be combined with %[1]s--field%[1]s,
+			%[1]s--raw-field%[1]s, or %[1]s--input%[1]s.
+
+			With %[1]s--paginate%[1]s, the command keeps requesting additional pages using the
+			"next_page_token" field of list responses until all results have been fetched.
+			Use %[1]s--per-page%[1]s to control the page size, and %[1]s--limit%[1]s to cap the total number
+			of items: the last page is still requested whole, but its output is truncated
+			so that no more than the limit is printed. Add %[1]s--slurp%[1]s to print one JSON
+			object per line per item (NDJSON, also selectable as %[1]s--output ndjson%[1]s) as
+			each page arrives, instead of every page's whole response object; memory
+			stays flat no matter how many pages there are.
+
+			With %[1]s--graphql-paginate%[1]s, a GraphQL query is re-issued with the connection's
+			"endCursor" as the "after" variable until "hasNextPage" is false. The connection
+			is located automatically by its "pageInfo" object, or explicitly via
+			%[1]s--graphql-paginate-path%[1]s; %[1]s--limit%[1]s caps the total nodes or edges printed.
+
+			With %[1]s--output-file%[1]s, the response body is streamed into a file instead of
+			printed; %[1]s-O%[1]s derives the filename from the last segment of the endpoint path.
+			The file is only created when the request succeeds, and combining with
+			%[1]s--include%[1]s prints the response headers to standard error instead.
+
+			With %[1]s--concurrency%[1]s, the %[1]s--input%[1]s file is treated as newline-delimited
+			bodies and one request is sent per line, with up to that many requests in
+			flight at a time. Responses are printed in input order. Failed lines are
+			reported in a summary at the end and make the command exit non-zero; pass
+			%[1]s--fail-fast%[1]s to abort the batch on the first failure instead.
+
+			With %[1]s--validate%[1]s, the JSON response is checked against a JSON Schema read
+			from the given file and the command exits non-zero, listing every violation,
+			when the response does not conform. The body is still printed unless
+			%[1]s--silent%[1]s is passed, which makes it handy for contract testing in CI.
+
+			With %[1]s--fields%[1]s, a list response is projected down to just the named fields,
+			a friendlier alternative to %[1]s--jq%[1]s for common cases. Nested fields are
+			addressed with dot notation, e.g. "owner.name", and missing fields render as
+			empty. The projection prints as JSON by default; pass %[1]s--output table%[1]s for a
+			table with one column per field.
+
+			Responses cached with %[1]s--cache%[1]s are stored under a dedicated directory in the
+			system temp location; %[1]s--cache-dir%[1]s overrides where. %[1]s--clear-cache%[1]s purges the
+			cached responses, reports how much space was freed, and only ever touches
+			this CLI's own cache entries.
+
+			Fields are serialized as a JSON request body by default. With %[1]s--form%[1]s, they
+			are URL-encoded into an %[1]sapplication/x-www-form-urlencoded%[1]s body instead, for
+			endpoints that expect form submissions. An explicit %[1]s--header Content-Type:...%[1]s
+			still takes precedence.
+
+			With %[1]s--wait%[1]s, the command polls a long-running operation until the field
+			named by %[1]s--wait-field%[1]s (default "status", dot notation for nested fields)
+			reaches the value given with %[1]s--wait-until%[1]s, following the response's
+			%[1]sstatus_url%[1]s field when present. %[1]s--wait-interval%[1]s and %[1]s--wait-timeout%[1]s bound
+			the polling; on timeout the command exits non-zero with the last observed
+			state.
+
+			With %[1]s--stream%[1]s, a "text/event-stream" (server-sent events) response is read
+			as it arrives and each event's data is printed on its own line, flushed
+			immediately, until the server closes the stream or the command is
+			interrupted. The request advertises the stream with an
+			"Accept: text/event-stream" header unless an explicit %[1]s--header Accept:...%[1]s
+			overrides it, and the response body is never buffered whole, so live
+			monitoring works no matter how long the stream runs.
+
+			With %[1]s--max-time%[1]s, the whole operation — every pagination page, retry, and
+			wait included — is bounded by a single wall-clock limit, unlike the
+			%[1]shttp_timeout%[1]s config value which applies to each request separately. On
+			hitting the limit the command exits non-zero; add %[1]s--partial-ok%[1]s to keep
+			the output collected before the cutoff instead of failing outright.
+
+			With %[1]s--base-url%[1]s, the request goes to the given URL instead of the host's
+			resolved base URL, which is handy for testing against a local dev server
+			without reconfiguring the host. The override applies to this request only and
+			is never persisted. The host's stored token still authenticates the request;
+			pass %[1]s--no-auth%[1]s to omit it. %[1]s--no-auth%[1]s also works on its own, for public
+			endpoints that reject or do not need a token.
+
+			With %[1]s--resolve host:port:addr%[1]s, connections to the given host and port are
+			dialed at the fixed address instead of resolving DNS, like curl's flag of the
+			same name. The URL, Host header, and TLS server name all keep the original
+			host, so certificates still verify. The flag may be repeated and the override
+			lasts for this invocation only; nothing is persisted.
+
+			With %[1]s--api-version%[1]s, an "Accept: application/json; version=X" header is added
+			to pin the request to a specific API version. Set the %[1]sapi_version%[1]s config
+			value to pin every call; the flag overrides the config value, and an explicit
+			%[1]s--header Accept:...%[1]s overrides both.
+
+			A JSON response body is pretty-printed with two-space indentation when
+			standard output is a terminal, and compacted onto a single line when it is
+			piped. %[1]s--pretty%[1]s and %[1]s--compact%[1]s force one or the other regardless of the
+			destination; bodies that are not valid JSON print unchanged either way.
+			Set the %[1]sapi_default_output%[1]s config value to "pretty", "compact", or
+			"ndjson" to change the default without repeating a flag on every call.
+
+			The exit code reflects the class of the HTTP response status: 4 for 4xx
+			responses, 5 for 5xx responses, and 0 on success. Combine with %[1]s--silent%[1]s to
+			suppress the response body when only the outcome matters.
+
+			With %[1]s--expect-status%[1]s, the listed status codes — and only those — count as
+			success, so e.g. a 404 can be a valid "not found" outcome for a script to
+			branch on. The response body still prints either way; statuses outside the
+			list make the command exit non-zero even when they are 2xx.
+		`, "`", shorthandList()),
 		Example: heredoc.Doc(`
-			# list pipelines
+			# list pipelines, using a resource shorthand
+			$ inst api pipelines
+
+			# list pipelines, spelling out the endpoint path
 			$ inst api vdp/v1alpha/pipelines
 
 			# list models
@@ -102,33 +289,358 @@ func NewCmdAPI(f *cmdutil.Factory, runF func(*ApiOptions) error) *cobra.Command
 			# add parameters to a GET request
 			$ inst api model/v1alpha/models?visibility=public
 
+			# the same, with the parameter URL-encoded for you
+			$ inst api model/v1alpha/models --query visibility=public
+
 			# add nested JSON body to a POST request
 			$ jq -n '{"inputs":[{"image": <your image base64 encoded string>}]}' | inst api vdp/v1alpha/pipelines/trigger --input -
 
+			# fill endpoint placeholders from flags
+			$ inst api 'vdp/v1alpha/{owner}/pipelines' --arg owner=alice
+
 			# set a custom HTTP header
 			$ inst api -H 'Authorization: Basic ...'
+
+			# send a plain-text payload verbatim
+			$ inst api vdp/v1alpha/pipelines/trigger --raw-body 'hello, pipeline'
+
+			# preview the request without sending it
+			$ inst api vdp/v1alpha/pipelines --dry-run
+
+			# reach the host at a specific IP without DNS changes
+			$ inst api pipelines --resolve api.instill.tech:443:127.0.0.1
+
+			# keep the response indented when piping it into a file
+			$ inst api pipelines --pretty > pipelines.json
+
+			# download a pipeline definition to disk
+			$ inst api vdp/v1alpha/pipelines/my-pipeline --output-file my-pipeline.json
+
+			# check the response against a JSON Schema
+			$ inst api vdp/v1alpha/pipelines --validate pipelines.schema.json --silent
+
+			# print only selected fields of each pipeline, as a table
+			$ inst api pipelines --fields id,mode,owner.name --output table
+
+			# fetch at most 50 pipelines, 20 per page
+			$ inst api vdp/v1alpha/pipelines --paginate --per-page 20 --limit 50
+
+			# stream all pipelines as one JSON object per line
+			$ inst api vdp/v1alpha/pipelines --paginate --slurp
+
+			# fetch all pages of a GraphQL connection
+			$ inst api /graphql -f query=@query.graphql --graphql-paginate --graphql-paginate-path data.things
+
+			# follow live pipeline events as they happen
+			$ inst api vdp/v1alpha/pipelines/my-pipeline/events --stream
+
+			# trigger a pipeline once per line of an ndjson file, 4 requests at a time
+			$ inst api vdp/v1alpha/pipelines/trigger --input bodies.ndjson --concurrency 4
+
+			# treat "not found" as a valid outcome for scripting
+			$ inst api vdp/v1alpha/pipelines/maybe-missing --expect-status 200,404
 		`),
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			opts.RequestPath = args[0]
+			opts.Context = c.Context()
+			if len(args) == 0 {
+				if !opts.ClearCache {
+					return cmdutil.FlagErrorf("an endpoint argument is required unless `--clear-cache` is used")
+				}
+			} else {
+				opts.RequestPath = expandShorthand(args[0])
+			}
 			opts.RequestMethodPassed = c.Flags().Changed("method")
 
-			if c.Flags().Changed("instance") {
-				// TODO look for the instance in the config
-				if err := instance.HostnameValidator(opts.Hostname); err != nil {
-					return cmdutil.FlagErrorf("error parsing `--instance`: %w", err)
+			for _, arg := range opts.PathArgs {
+				if !strings.ContainsRune(arg, '=') {
+					return cmdutil.FlagErrorf("expected the \"name=value\" format for `--arg`, got %q", arg)
+				}
+			}
+
+			for _, param := range opts.QueryParams {
+				if !strings.ContainsRune(param, '=') {
+					return cmdutil.FlagErrorf("expected the \"key=value\" format for `--query`, got %q", param)
+				}
+			}
+			if opts.QueryReplace && len(opts.QueryParams) == 0 {
+				return cmdutil.FlagErrorf("`--query-replace` requires `--query`")
+			}
+
+			if _, err := parseResolveOverrides(opts.Resolve); err != nil {
+				return cmdutil.FlagErrorf("invalid value for `--resolve`: %v", err)
+			}
+
+			if opts.RawBody != "" {
+				if len(opts.MagicFields) > 0 || len(opts.RawFields) > 0 || opts.RequestInputFile != "" {
+					return cmdutil.FlagErrorf("`--raw-body` cannot be combined with `--field`, `--raw-field`, or `--input`")
+				}
+				if opts.Form {
+					return cmdutil.FlagErrorf("`--raw-body` cannot be combined with `--form`")
+				}
+			}
+
+			// `--config-stdin` and `--input -` cannot both consume stdin
+			if configStdin, _ := c.Flags().GetBool("config-stdin"); configStdin && opts.RequestInputFile == "-" {
+				return cmdutil.FlagErrorf("`--input -` cannot be combined with `--config-stdin`")
+			}
+
+			if c.Flags().Changed("hostname") {
+				hostname, err := cmdutil.NormalizeHostname(opts.Hostname)
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--hostname`: %v", opts.Hostname, err)
 				}
+				opts.Hostname = hostname
+			}
+
+			if opts.Template != "" && opts.TemplateFile != "" {
+				return cmdutil.FlagErrorf("only one of `--template` or `--template-file` may be used")
 			}
 
 			if err := cmdutil.MutuallyExclusive(
-				"only one of `--template`, `--jq`, or `--silent` may be used",
+				"only one of `--template`, `--jq`, `--fields`, or `--silent` may be used",
 				opts.Silent,
 				opts.FilterOutput != "",
-				opts.Template != "",
+				opts.Template != "" || opts.TemplateFile != "",
+				len(opts.Fields) > 0,
+			); err != nil {
+				return err
+			}
+
+			// `--template-file -` and `--input -` cannot both consume stdin
+			if opts.TemplateFile == "-" && opts.RequestInputFile == "-" {
+				return cmdutil.FlagErrorf("`--template-file -` cannot be combined with `--input -`")
+			}
+
+			if err := cmdutil.MutuallyExclusive(
+				"only one of `--pretty` or `--compact` may be used",
+				opts.Pretty,
+				opts.Compact,
 			); err != nil {
 				return err
 			}
 
+			// a configured api_default_output fills in for the formatting
+			// flags when none are passed; explicit flags always win, and the
+			// TTY-aware auto default applies when the key is unset or "json"
+			if !c.Flags().Changed("pretty") && !c.Flags().Changed("compact") && !opts.Slurp {
+				switch defaultOutput, _ := cfg.Get("", "api_default_output"); defaultOutput {
+				case "pretty":
+					opts.Pretty = true
+				case "compact":
+					opts.Compact = true
+				case "ndjson":
+					if opts.Paginate && !opts.Silent && opts.FilterOutput == "" &&
+						opts.Template == "" && opts.TemplateFile == "" && len(opts.Fields) == 0 && opts.OutputFile == "" {
+						opts.Slurp = true
+					}
+				}
+			}
+
+			if c.Flags().Changed("output") {
+				if opts.OutputFormat == "ndjson" {
+					if !opts.Slurp {
+						return cmdutil.FlagErrorf("`--output ndjson` requires `--slurp`")
+					}
+				} else {
+					if opts.Slurp {
+						return cmdutil.FlagErrorf("`--slurp` only supports `--output ndjson`")
+					}
+					if len(opts.Fields) == 0 {
+						return cmdutil.FlagErrorf("`--output` requires `--fields`")
+					}
+					if err := cmdutil.ValidateFormat(opts.OutputFormat); err != nil {
+						return err
+					}
+				}
+			}
+
+			opts.BatchInput = c.Flags().Changed("concurrency")
+			if opts.BatchInput && opts.RequestInputFile == "" {
+				return cmdutil.FlagErrorf("`--concurrency` requires `--input`")
+			}
+			if opts.BatchInput && opts.DryRun {
+				return cmdutil.FlagErrorf("`--concurrency` cannot be combined with `--dry-run`")
+			}
+			if opts.FailFast && !opts.BatchInput {
+				return cmdutil.FlagErrorf("`--fail-fast` requires `--concurrency`")
+			}
+			if opts.Trace && opts.BatchInput {
+				return cmdutil.FlagErrorf("`--trace` cannot be combined with `--concurrency`")
+			}
+
+			if opts.Paginate && opts.RequestInputFile != "" {
+				return cmdutil.FlagErrorf("`--paginate` cannot be combined with `--input`")
+			}
+			if opts.Slurp {
+				if !opts.Paginate {
+					return cmdutil.FlagErrorf("`--slurp` requires `--paginate`")
+				}
+				if opts.Silent || opts.FilterOutput != "" || opts.Template != "" || opts.TemplateFile != "" || len(opts.Fields) > 0 {
+					return cmdutil.FlagErrorf("`--slurp` cannot be combined with `--template`, `--jq`, `--fields`, or `--silent`")
+				}
+			}
+			if opts.Form && opts.RequestInputFile != "" {
+				return cmdutil.FlagErrorf("`--form` cannot be combined with `--input`")
+			}
+
+			if opts.GraphQLPaginate {
+				if opts.Paginate {
+					return cmdutil.FlagErrorf("only one of `--paginate` or `--graphql-paginate` may be used")
+				}
+				if opts.RequestInputFile != "" {
+					return cmdutil.FlagErrorf("`--graphql-paginate` cannot be combined with `--input`")
+				}
+				if opts.Form {
+					return cmdutil.FlagErrorf("`--graphql-paginate` cannot be combined with `--form`")
+				}
+			} else if c.Flags().Changed("graphql-paginate-path") {
+				return cmdutil.FlagErrorf("`--graphql-paginate-path` requires `--graphql-paginate`")
+			}
+
+			if opts.Wait {
+				if opts.WaitUntil == "" {
+					return cmdutil.FlagErrorf("`--wait` requires `--wait-until`")
+				}
+				if opts.Paginate || opts.GraphQLPaginate {
+					return cmdutil.FlagErrorf("`--wait` cannot be combined with `--paginate`")
+				}
+				if opts.BatchInput {
+					return cmdutil.FlagErrorf("`--wait` cannot be combined with `--concurrency`")
+				}
+				if opts.DryRun {
+					return cmdutil.FlagErrorf("`--wait` cannot be combined with `--dry-run`")
+				}
+			} else {
+				for _, flag := range []string{"wait-field", "wait-until", "wait-interval", "wait-timeout"} {
+					if c.Flags().Changed(flag) {
+						return cmdutil.FlagErrorf("`--%s` requires `--wait`", flag)
+					}
+				}
+			}
+			if opts.Stream {
+				if opts.Paginate || opts.GraphQLPaginate {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--paginate`")
+				}
+				if opts.BatchInput {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--concurrency`")
+				}
+				if opts.Wait {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--wait`")
+				}
+				if opts.OutputFile != "" || remoteName {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--output-file`")
+				}
+				if opts.CacheTTL > 0 {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--cache`")
+				}
+				if opts.ValidateSchema != "" {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--validate`")
+				}
+				if opts.Silent || opts.FilterOutput != "" || opts.Template != "" || opts.TemplateFile != "" || len(opts.Fields) > 0 {
+					return cmdutil.FlagErrorf("`--stream` cannot be combined with `--template`, `--jq`, `--fields`, or `--silent`")
+				}
+			}
+			if c.Flags().Changed("max-time") && opts.MaxTime <= 0 {
+				return cmdutil.FlagErrorf("`--max-time` must be a positive duration")
+			}
+			if opts.PartialOK && opts.MaxTime == 0 {
+				return cmdutil.FlagErrorf("`--partial-ok` requires `--max-time`")
+			}
+
+			if opts.Since != "" {
+				if _, err := parseTimeFlag(opts.Since, time.Now()); err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--since`: %v", opts.Since, err)
+				}
+			} else if c.Flags().Changed("since-param") {
+				return cmdutil.FlagErrorf("`--since-param` requires `--since`")
+			}
+			if opts.Until != "" {
+				if _, err := parseTimeFlag(opts.Until, time.Now()); err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--until`: %v", opts.Until, err)
+				}
+			} else if c.Flags().Changed("until-param") {
+				return cmdutil.FlagErrorf("`--until-param` requires `--until`")
+			}
+
+			if opts.MaxResponseSize != "" {
+				if _, err := parseByteSize(opts.MaxResponseSize); err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--max-response-size`: %v", opts.MaxResponseSize, err)
+				}
+			}
+
+			if c.Flags().Changed("per-page") && opts.PerPage < 1 {
+				return cmdutil.FlagErrorf("`--per-page` must be a positive integer")
+			}
+			if c.Flags().Changed("limit") && opts.Limit < 1 {
+				return cmdutil.FlagErrorf("`--limit` must be a positive integer")
+			}
+			if opts.Limit > 0 && !opts.Paginate && !opts.GraphQLPaginate {
+				return cmdutil.FlagErrorf("`--limit` requires `--paginate` or `--graphql-paginate`")
+			}
+
+			if remoteName {
+				if opts.OutputFile != "" {
+					return cmdutil.FlagErrorf("`-O` cannot be combined with `--output-file`")
+				}
+				name := opts.RequestPath
+				if idx := strings.IndexRune(name, '?'); idx > -1 {
+					name = name[:idx]
+				}
+				name = path.Base(strings.TrimSuffix(name, "/"))
+				if name == "" || name == "." || name == "/" {
+					return cmdutil.FlagErrorf("could not derive a filename from %q; use `--output-file` instead", opts.RequestPath)
+				}
+				opts.OutputFile = name
+			}
+			if opts.OutputFile != "" {
+				if opts.Silent || opts.FilterOutput != "" || opts.Template != "" || opts.TemplateFile != "" || len(opts.Fields) > 0 {
+					return cmdutil.FlagErrorf("`--output-file` cannot be combined with `--silent`, `--jq`, `--fields`, or `--template`")
+				}
+				if opts.Paginate || opts.GraphQLPaginate {
+					return cmdutil.FlagErrorf("`--output-file` cannot be combined with `--paginate`")
+				}
+				if opts.BatchInput {
+					return cmdutil.FlagErrorf("`--output-file` cannot be combined with `--concurrency`")
+				}
+			}
+
+			if opts.ValidateSchema != "" {
+				if opts.BatchInput {
+					return cmdutil.FlagErrorf("`--validate` cannot be combined with `--concurrency`")
+				}
+				if opts.OutputFile != "" {
+					return cmdutil.FlagErrorf("`--validate` cannot be combined with `--output-file`")
+				}
+			}
+
+			for _, code := range opts.ExpectStatus {
+				if code < 100 || code > 599 {
+					return cmdutil.FlagErrorf("invalid status code %d for `--expect-status`", code)
+				}
+			}
+
+			if opts.BaseURL != "" {
+				if u, err := url.Parse(opts.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return cmdutil.FlagErrorf("invalid value %q for `--base-url`: expected an absolute http(s) URL", opts.BaseURL)
+				}
+			}
+
+			// an unauthenticated request still resolves its base URL from the
+			// host; only the Authorization middleware is dropped
+			if opts.NoAuth && f.UnauthenticatedHTTPClient != nil {
+				opts.HTTPClient = f.UnauthenticatedHTTPClient
+			}
+
+			if opts.HeadRequest {
+				if opts.RequestMethodPassed && !strings.EqualFold(opts.RequestMethod, "HEAD") {
+					return cmdutil.FlagErrorf("the `--head` option is not supported with `--method %s`", opts.RequestMethod)
+				}
+				opts.RequestMethod = "HEAD"
+				opts.RequestMethodPassed = true
+				opts.ShowResponseHeaders = true
+			}
+
 			if runF != nil {
 				return runF(&opts)
 			}
@@ -137,30 +649,137 @@ func NewCmdAPI(f *cmdutil.Factory, runF func(*ApiOptions) error) *cobra.Command
 	}
 
 	cmd.Flags().StringVar(&opts.Hostname, "hostname", cfg.DefaultHostname(), "Target instance")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "Override the host's base `URL` for this request only, e.g. for a local dev server")
+	cmd.Flags().BoolVar(&opts.NoAuth, "no-auth", false, "Do not send an Authorization header with the request")
 	cmd.Flags().StringVarP(&opts.RequestMethod, "method", "X", "GET", "The HTTP method for the request")
 	cmd.Flags().StringArrayVarP(&opts.MagicFields, "field", "F", nil, "Add a typed parameter in `key=value` format")
 	cmd.Flags().StringArrayVarP(&opts.RawFields, "raw-field", "f", nil, "Add a string parameter in `key=value` format")
-	cmd.Flags().StringArrayVarP(&opts.RequestHeaders, "header", "H", nil, "Add a HTTP request header in `key:value` format")
+	cmd.Flags().StringArrayVarP(&opts.RequestHeaders, "header", "H", nil, "Add a HTTP request header in `key:value` format; \"@file\" reads one header per line, and ${VAR} references in values are expanded")
+	cmd.Flags().StringArrayVar(&opts.PathArgs, "arg", nil, "Fill an endpoint `{placeholder}` with a value, in \"name=value\" format")
+	cmd.Flags().StringArrayVar(&opts.QueryParams, "query", nil, "Append a URL query parameter in `key=value` format (may be repeated; \"@file\" reads the value from a file)")
+	cmd.Flags().BoolVar(&opts.QueryReplace, "query-replace", false, "With `--query`, replace a parameter already present in the endpoint instead of appending another value")
+	cmd.Flags().StringArrayVar(&opts.Resolve, "resolve", nil, "Dial the given `host:port:addr` at a fixed address instead of resolving DNS (may be repeated)")
 	cmd.Flags().BoolVarP(&opts.ShowResponseHeaders, "include", "i", false, "Include HTTP response headers in the output")
+	cmd.Flags().BoolVar(&opts.HeadRequest, "head", false, "Issue a HEAD request and print only the response headers")
 	cmd.Flags().StringVar(&opts.RequestInputFile, "input", "", "The `file` to use as body for the HTTP request (use \"-\" to read from standard input)")
+	cmd.Flags().StringVar(&opts.RawBody, "raw-body", "", "The raw `string` to send verbatim as the request body (\"@file\" reads it from a file)")
+	cmd.Flags().BoolVar(&opts.Form, "form", false, "Serialize fields as an application/x-www-form-urlencoded body instead of JSON")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", false, "Poll the operation until a response field reaches a target value")
+	cmd.Flags().StringVar(&opts.WaitField, "wait-field", "status", "The response `field` to watch with `--wait`; nested fields use dot notation")
+	cmd.Flags().StringVar(&opts.WaitUntil, "wait-until", "", "The target `value` the watched field must reach")
+	cmd.Flags().DurationVar(&opts.WaitInterval, "wait-interval", 2*time.Second, "How often to poll with `--wait`")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", 5*time.Minute, "Give up polling after this `duration`")
 	cmd.Flags().BoolVar(&opts.Silent, "silent", false, "Do not print the response body")
+	cmd.Flags().BoolVar(&opts.Pretty, "pretty", false, "Indent a JSON response body even when the output is not a terminal")
+	cmd.Flags().BoolVar(&opts.Compact, "compact", false, "Print a JSON response body on a single line even on a terminal")
+	cmd.Flags().BoolVar(&opts.ExplainError, "explain-error", false, "Expand known error codes in failed responses into explanations")
+	cmd.Flags().IntSliceVar(&opts.ExpectStatus, "expect-status", nil, "Treat exactly these HTTP status `codes` as success, comma separated, e.g. \"200,404\"")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the request that would be sent without making it")
+	cmd.Flags().BoolVar(&opts.RespectRateLimit, "respect-rate-limit", false, "On HTTP 429, wait until the rate limit resets and retry the request")
+	cmd.Flags().DurationVar(&opts.MaxTime, "max-time", 0, "Bound the total wall-clock time for the operation, pagination and retries included, e.g. \"60s\"")
+	cmd.Flags().BoolVar(&opts.PartialOK, "partial-ok", false, "With `--max-time`, keep the output collected before the limit instead of failing outright")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 1, "With `--input`, send one request per line with this many requests in flight")
+	cmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "Abort a batch of requests on the first failure")
+	cmd.Flags().BoolVar(&opts.Paginate, "paginate", false, "Make additional HTTP requests to fetch all pages of results")
+	cmd.Flags().BoolVar(&opts.Stream, "stream", false, "Read a text/event-stream response and print each event's data as it arrives")
+	cmd.Flags().BoolVar(&opts.Slurp, "slurp", false, "With `--paginate`, print one JSON object per line per item (NDJSON) as pages arrive")
+	cmd.Flags().IntVar(&opts.PerPage, "per-page", 0, "The number of items to request per page, sent as the page_size query parameter")
+	cmd.Flags().BoolVar(&opts.GraphQLPaginate, "graphql-paginate", false, "Follow a GraphQL connection's pageInfo cursor to fetch all pages of results")
+	cmd.Flags().StringVar(&opts.GraphQLPaginatePath, "graphql-paginate-path", "", "With `--graphql-paginate`, the dot-separated `path` to the connection in the response (auto-detected when omitted)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "With `--paginate` or `--graphql-paginate`, the maximum number of items to output across all pages")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only items newer than this `time`: an RFC3339 timestamp, or a relative duration like \"24h\" or \"7d\"")
+	cmd.Flags().StringVar(&opts.Until, "until", "", "Only items older than this `time`: an RFC3339 timestamp, or a relative duration like \"24h\" or \"7d\"")
+	cmd.Flags().StringVar(&opts.SinceParam, "since-param", "since", "The query parameter `name` used for `--since`")
+	cmd.Flags().StringVar(&opts.UntilParam, "until-param", "until", "The query parameter `name` used for `--until`")
+	cmd.Flags().StringVar(&opts.OutputFile, "output-file", "", "Write the response body to `file` instead of printing it")
+	cmd.Flags().StringVar(&opts.MaxResponseSize, "max-response-size", "", "Fail when the response body exceeds this `size`, e.g. \"10MB\"; overrides the http_max_response_bytes config value")
+	cmd.Flags().StringVar(&opts.ValidateSchema, "validate", "", "Validate the JSON response against the JSON Schema in `file`")
+	cmd.Flags().StringVar(&opts.APIVersion, "api-version", "", "Request a specific API `version` via the Accept header, overriding the api_version config value")
+	cmd.Flags().BoolVarP(&remoteName, "remote-name", "O", false, "Write the response body to a file named after the last segment of the endpoint path")
+	cmd.Flags().BoolVar(&opts.Trace, "trace", false, "Print DNS, connect, TLS handshake, and first-byte timings to standard error")
+	cmd.Flags().BoolVar(&opts.Verbose, "verbose", false, "Log request and response headers to standard error (Authorization redacted)")
+	cmd.Flags().BoolVar(&opts.ShowRetries, "show-retries", false, "Report retried attempts and their reasons on standard error after the request completes")
 	cmd.Flags().StringVarP(&opts.Template, "template", "t", "", "Format the response using a Go template")
+	cmd.Flags().StringVar(&opts.TemplateFile, "template-file", "", "Format the response using the Go template in `file` (use \"-\" to read from standard input)")
 	cmd.Flags().StringVarP(&opts.FilterOutput, "jq", "q", "", "Query to select values from the response using jq syntax")
+	cmd.Flags().StringSliceVar(&opts.Fields, "fields", nil, "Project only these `fields` of a list response, comma separated; nested fields use dot notation")
+	cmd.Flags().StringVar(&opts.OutputFormat, "output", "json", "The output format: one of {json|table|yaml} with `--fields`, or \"ndjson\" with `--slurp`")
 	cmd.Flags().DurationVar(&opts.CacheTTL, "cache", 0, "Cache the response, e.g. \"3600s\", \"60m\", \"1h\"")
+	cmd.Flags().StringVar(&opts.CacheDir, "cache-dir", "", "The `directory` to store cached responses in, instead of a default under the system temp directory")
+	cmd.Flags().BoolVar(&opts.ClearCache, "clear-cache", false, "Purge cached responses and report how much space was freed")
+
+	_ = cmd.RegisterFlagCompletionFunc("method", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "table", "yaml", "ndjson"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return shorthandCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+
 	return cmd
 }
 
 func apiRun(opts *ApiOptions) error {
+	if opts.ClearCache {
+		entries, freed, err := api.ClearCache(opts.CacheDir)
+		if err != nil {
+			return fmt.Errorf("failed to clear the response cache: %w", err)
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "Cleared %d cached responses (%d bytes)\n", entries, freed)
+		if opts.RequestPath == "" {
+			return nil
+		}
+	}
+
 	params, err := parseFields(opts)
 	if err != nil {
 		return err
 	}
 
+	if opts.RequestHeaders, err = expandRequestHeaders(opts.RequestHeaders); err != nil {
+		return err
+	}
+
+	// a template file feeds the same parse and render path as `--template`
+	if opts.TemplateFile != "" {
+		file, _, err := openUserFile(opts.TemplateFile, opts.IO.In)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		opts.Template = string(data)
+	}
+
 	// get the host config
 	cfg, err := opts.Config()
 	if err != nil {
 		return err
 	}
+
+	// a live event stream must be negotiated up front; an explicit Accept
+	// header wins, including over the api_version handling below
+	if opts.Stream && !hasRequestHeader(opts.RequestHeaders, "Accept") {
+		opts.RequestHeaders = append(opts.RequestHeaders, "Accept: text/event-stream")
+	}
+
+	// pin the API version via an Accept header; the flag wins over the
+	// api_version config value, and an explicit Accept header wins over both
+	apiVersion := opts.APIVersion
+	if apiVersion == "" {
+		apiVersion, _ = cfg.Get("", "api_version")
+	}
+	if apiVersion != "" && !hasRequestHeader(opts.RequestHeaders, "Accept") {
+		opts.RequestHeaders = append(opts.RequestHeaders, fmt.Sprintf("Accept: application/json; version=%s", apiVersion))
+	}
 	var host *config.HostConfigTyped
 	if err != nil {
 		return err
@@ -188,16 +807,96 @@ func apiRun(opts *ApiOptions) error {
 			hostname, hostname))
 	}
 
+	// placeholders resolve after the host is known so per-host config values
+	// can fill them
+	if opts.RequestPath, err = fillPathPlaceholders(opts.RequestPath, opts.PathArgs, cfg, host.APIHostname); err != nil {
+		return err
+	}
+
+	// --query parameters merge into the endpoint's query string before the
+	// base-url override or any batch processing sees the path
+	if len(opts.QueryParams) > 0 {
+		if opts.RequestPath, err = applyQueryParams(opts.RequestPath, opts.QueryParams, opts.QueryReplace, opts.IO.In); err != nil {
+			return err
+		}
+	}
+
+	// a --base-url override redirects the request without touching the host
+	// config; the stored token still authenticates it unless --no-auth is given
+	if opts.BaseURL != "" {
+		opts.RequestPath = strings.TrimSuffix(opts.BaseURL, "/") + "/" + strings.TrimPrefix(opts.RequestPath, "/")
+		if !opts.NoAuth && host.AccessToken != "" && !hasRequestHeader(opts.RequestHeaders, "Authorization") {
+			opts.RequestHeaders = append(opts.RequestHeaders, fmt.Sprintf("Authorization: bearer %s", host.AccessToken))
+		}
+	}
+
+	if opts.BatchInput {
+		return batchRun(opts, host, params)
+	}
+
 	// set up the http client
 	method := opts.RequestMethod
 	requestPath := opts.RequestPath
 	requestHeaders := opts.RequestHeaders
 	var requestBody interface{} = params
 
-	if !opts.RequestMethodPassed && (len(params) > 0 || opts.RequestInputFile != "") {
+	if !opts.RequestMethodPassed && (len(params) > 0 || opts.RequestInputFile != "" || opts.RawBody != "") {
 		method = "POST"
 	}
 
+	if opts.Paginate && !strings.EqualFold(method, "GET") {
+		return fmt.Errorf("`--paginate` only supports GET requests")
+	}
+
+	if opts.GraphQLPaginate {
+		if _, ok := params["query"]; !ok {
+			return fmt.Errorf("`--graphql-paginate` requires a GraphQL query field, e.g. `-f query=@query.graphql`")
+		}
+		if strings.EqualFold(method, "GET") {
+			return fmt.Errorf("`--graphql-paginate` only supports POST requests")
+		}
+	}
+
+	// added after method inference so the page size does not flip the method
+	// to POST like user-supplied fields do
+	if opts.PerPage > 0 {
+		params["page_size"] = opts.PerPage
+	}
+
+	// --since/--until resolve to absolute timestamps at request time and ride
+	// along as parameters, like the page size above
+	if opts.Since != "" {
+		t, err := parseTimeFlag(opts.Since, timeFlagNow())
+		if err != nil {
+			return err
+		}
+		params[opts.SinceParam] = t.UTC().Format(time.RFC3339)
+	}
+	if opts.Until != "" {
+		t, err := parseTimeFlag(opts.Until, timeFlagNow())
+		if err != nil {
+			return err
+		}
+		params[opts.UntilParam] = t.UTC().Format(time.RFC3339)
+	}
+
+	// --form serializes the fields as a URL-encoded body instead of JSON; GET
+	// requests keep fields in the query string either way
+	if opts.Form && !strings.EqualFold(method, "GET") {
+		encoded, err := encodeForm(params)
+		if err != nil {
+			return err
+		}
+		requestBody = strings.NewReader(encoded)
+		if !hasRequestHeader(requestHeaders, "Content-Type") {
+			requestHeaders = append(requestHeaders, "Content-Type: application/x-www-form-urlencoded")
+		}
+	}
+
+	if opts.RequestMethodPassed && strings.EqualFold(method, "GET") && (opts.RequestInputFile != "" || opts.RawBody != "") {
+		fmt.Fprintln(opts.IO.ErrOut, "warning: request body may be ignored by the server with `--method GET`")
+	}
+
 	if opts.RequestInputFile != "" {
 		file, size, err := openUserFile(opts.RequestInputFile, opts.IO.In)
 		if err != nil {
@@ -211,18 +910,52 @@ func apiRun(opts *ApiOptions) error {
 		}
 	}
 
+	if opts.RawBody != "" {
+		body, size, err := rawBodyReader(opts.RawBody, opts.IO.In)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		requestPath = addQuery(requestPath, params)
+		requestBody = body
+		if size >= 0 {
+			requestHeaders = append([]string{fmt.Sprintf("Content-Length: %d", size)}, requestHeaders...)
+		}
+		if !hasRequestHeader(requestHeaders, "Content-Type") {
+			requestHeaders = append(requestHeaders, "Content-Type: text/plain")
+		}
+	}
+
+	if opts.DryRun {
+		return dryRun(opts, host, method, requestPath, requestBody, requestHeaders)
+	}
+
 	httpClient, err := opts.HTTPClient()
 	if err != nil {
 		return err
 	}
 	if opts.CacheTTL > 0 {
-		httpClient = api.NewCachedClient(httpClient, opts.CacheTTL)
+		if opts.CacheDir != "" {
+			httpClient = api.NewCachedClientInDir(httpClient, opts.CacheTTL, opts.CacheDir)
+		} else {
+			httpClient = api.NewCachedClient(httpClient, opts.CacheTTL)
+		}
+	}
+	// the factory client already logs when debugging is enabled via environment
+	if opts.Verbose && os.Getenv("INSTILL_DEBUG") == "" && os.Getenv("DEBUG") == "" {
+		if opt, ok := factory.VerboseLogOption(opts.IO, true); ok {
+			httpClient = &http.Client{Transport: opt(httpClient.Transport)}
+		}
 	}
 
 	headersOutputStream := opts.IO.Out
-	if opts.Silent {
+	if opts.OutputFile != "" {
+		// the body goes to the file, so `--include` headers belong on stderr
+		headersOutputStream = opts.IO.ErrOut
+	} else if opts.Silent {
 		opts.IO.Out = io.Discard
-	} else {
+	} else if !opts.Stream {
+		// a pager buffers its input, which would defeat streaming
 		err := opts.IO.StartPager()
 		if err != nil {
 			return err
@@ -230,16 +963,386 @@ func apiRun(opts *ApiOptions) error {
 		defer opts.IO.StopPager()
 	}
 
-	template := export.NewTemplate(opts.IO, opts.Template)
-	resp, err := httpRequest(httpClient, host.APIHostname, method, requestPath, requestBody, requestHeaders)
-	if err != nil {
-		return err
+	// cap how much of a response body gets read into memory; the flag wins
+	// over the http_max_response_bytes config value
+	var maxResponseBytes int64
+	if opts.MaxResponseSize != "" {
+		if maxResponseBytes, err = parseByteSize(opts.MaxResponseSize); err != nil {
+			return cmdutil.FlagErrorf("invalid value %q for `--max-response-size`: %v", opts.MaxResponseSize, err)
+		}
+	} else if raw, _ := cfg.Get("", "http_max_response_bytes"); raw != "" && raw != "0" {
+		if maxResponseBytes, err = parseByteSize(raw); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "warning: invalid value %q for http_max_response_bytes, ignoring it\n", raw)
+			maxResponseBytes = 0
+		}
 	}
-	err = processResponse(resp, opts, headersOutputStream, &template)
-	if err != nil {
+
+	// retrying after a rate limit reset needs a rewindable request body
+	var bufferedBody []byte
+	if opts.RespectRateLimit {
+		if reader, ok := requestBody.(io.Reader); ok {
+			if bufferedBody, err = io.ReadAll(reader); err != nil {
+				return err
+			}
+		}
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// --max-time bounds the whole run — every page, retry, and wait — unlike
+	// http_timeout, which limits each individual request
+	if opts.MaxTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.MaxTime)
+		defer cancel()
+	}
+	// --resolve overrides ride on the request context down to the transport's
+	// dialer; the URL and TLS server name keep the original host
+	if len(opts.Resolve) > 0 {
+		overrides, err := parseResolveOverrides(opts.Resolve)
+		if err != nil {
+			return cmdutil.FlagErrorf("invalid value for `--resolve`: %v", err)
+		}
+		ctx = api.WithResolveOverrides(ctx, overrides)
+	}
+	// the retry middleware records attempts on the request context; --verbose
+	// implies the report since it promises full request diagnostics
+	var retryAttempts *[]api.RetryAttempt
+	if opts.ShowRetries || opts.Verbose {
+		ctx, retryAttempts = api.WithRetryRecorder(ctx)
+	}
+	doRequest := func(reqCtx context.Context) (*http.Response, error) {
+		body := requestBody
+		if bufferedBody != nil {
+			body = bytes.NewReader(bufferedBody)
+		}
+		return httpRequest(reqCtx, httpClient, host.APIHostname, method, requestPath, body, requestHeaders)
+	}
+
+	var schema *jsonschema.Schema
+	if opts.ValidateSchema != "" {
+		if schema, err = compileSchema(opts.ValidateSchema); err != nil {
+			return err
+		}
+	}
+
+	template := newResponseTemplate(opts)
+	itemsLeft := opts.Limit
+	schemaValid := true
+	for {
+		// when tracing, the hooks live on the request context so they follow
+		// the request through any transport in the chain
+		reqCtx := ctx
+		var trace *requestTrace
+		if opts.Trace {
+			trace = startRequestTrace()
+			reqCtx = withClientTrace(reqCtx, trace)
+		}
+
+		if opts.Paginate || opts.GraphQLPaginate {
+			opts.IO.StartProgressIndicatorWithLabel("Fetching pages")
+		}
+		resp, err := doRequest(reqCtx)
+		if opts.Paginate || opts.GraphQLPaginate {
+			opts.IO.StopProgressIndicator()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelledExit(ctx, opts, &template)
+			}
+			return err
+		}
+
+		for resp.StatusCode == http.StatusTooManyRequests {
+			rateLimit := api.ParseRateLimit(resp)
+			if !rateLimit.HasReset() {
+				break
+			}
+			if !opts.RespectRateLimit {
+				fmt.Fprintf(opts.IO.ErrOut, "inst: rate limit exceeded; resets at %s\n", rateLimit.Reset.Format(time.RFC1123))
+				break
+			}
+			waitForRateLimitReset(reqCtx, opts.IO.ErrOut, rateLimit.Reset)
+			resp.Body.Close()
+			if resp, err = doRequest(reqCtx); err != nil {
+				if ctx.Err() != nil {
+					return cancelledExit(ctx, opts, &template)
+				}
+				return err
+			}
+		}
+
+		// polling replaces the initial response with the final one, so filters
+		// and templates below apply to the settled state of the operation
+		if opts.Wait && resp.StatusCode < 300 {
+			if resp, err = waitForCondition(reqCtx, opts, httpClient, host.APIHostname, resp); err != nil {
+				return err
+			}
+		}
+
+		// guard every body consumer below, including the pagination buffering;
+		// a body streaming to disk only warns instead of failing. An event
+		// stream is unbounded by design, so the cap does not apply to it.
+		if maxResponseBytes > 0 && resp.Body != nil && !opts.Stream {
+			resp.Body = newLimitedResponseBody(resp.Body, maxResponseBytes, opts.OutputFile != "", opts.IO.ErrOut)
+		}
+
+		var nextPageToken string
+		if opts.Paginate && resp.StatusCode < 300 {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			nextPageToken = findNextPageToken(body)
+			if opts.Limit > 0 {
+				var kept int
+				body, kept = truncateItems(body, itemsLeft)
+				itemsLeft -= kept
+				if itemsLeft <= 0 {
+					nextPageToken = ""
+				}
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		var nextCursor string
+		if opts.GraphQLPaginate && resp.StatusCode < 300 {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			hasNextPage, endCursor, err := findGraphQLCursor(body, opts.GraphQLPaginatePath)
+			if err != nil {
+				return err
+			}
+			if hasNextPage {
+				nextCursor = endCursor
+			}
+			if opts.Limit > 0 {
+				var kept int
+				body, kept = truncateGraphQLItems(body, opts.GraphQLPaginatePath, itemsLeft)
+				itemsLeft -= kept
+				if itemsLeft <= 0 {
+					nextCursor = ""
+				}
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		if schema != nil && resp.StatusCode < 300 {
+			violations, err := validateResponse(resp, schema)
+			if err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				schemaValid = false
+				fmt.Fprintf(opts.IO.ErrOut, "inst: response does not conform to the schema:\n")
+				for _, violation := range violations {
+					fmt.Fprintf(opts.IO.ErrOut, "- %s\n", violation)
+				}
+			}
+		}
+
+		if opts.Stream && resp.StatusCode < 300 {
+			err = streamEvents(ctx, resp, opts)
+		} else if opts.Slurp && resp.StatusCode < 300 {
+			err = writeNDJSONOutput(resp, opts)
+		} else if len(opts.Fields) > 0 && resp.StatusCode < 300 {
+			err = writeFieldsOutput(resp, opts)
+		} else if opts.OutputFile != "" {
+			err = writeResponseToFile(resp, opts, headersOutputStream)
+		} else {
+			err = processResponse(resp, opts, headersOutputStream, &template)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelledExit(ctx, opts, &template)
+			}
+			return err
+		}
+
+		if trace != nil {
+			trace.report(opts.IO.ErrOut)
+		}
+
+		if nextPageToken == "" && nextCursor == "" {
+			break
+		}
+		if nextCursor != "" {
+			if err := setGraphQLCursor(params, nextCursor); err != nil {
+				return err
+			}
+		} else {
+			params["page_token"] = nextPageToken
+		}
+	}
+	if err := template.End(); err != nil {
 		return err
 	}
-	return template.End()
+	if retryAttempts != nil && len(*retryAttempts) > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "inst: retried %s:\n", utils.Pluralize(len(*retryAttempts), "time"))
+		for _, attempt := range *retryAttempts {
+			fmt.Fprintf(opts.IO.ErrOut, "- %s, waited %s before retrying\n", attempt.Reason, attempt.Delay.Round(time.Millisecond))
+		}
+	}
+	if !schemaValid {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+// cancelledExit reports a run cut short by its context. Hitting the
+// `--max-time` deadline either keeps the partial output or fails outright,
+// per `--partial-ok`; any other cancellation (e.g. an interrupt) keeps the
+// generic message. Both paths exit non-zero.
+func cancelledExit(ctx context.Context, opts *ApiOptions, template *export.Template) error {
+	if opts.MaxTime > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if opts.PartialOK {
+			_ = template.End()
+			fmt.Fprintf(opts.IO.ErrOut, "inst: exceeded `--max-time` after %s; output is incomplete\n", opts.MaxTime)
+			return cmdutil.SilentError
+		}
+		return fmt.Errorf("exceeded the `--max-time` limit of %s", opts.MaxTime)
+	}
+	fmt.Fprintln(opts.IO.ErrOut, "inst: request cancelled; output may be incomplete")
+	return cmdutil.SilentError
+}
+
+var placeholderRE = regexp.MustCompile(`\{([a-zA-Z0-9_-]+)\}`)
+
+// fillPathPlaceholders replaces every {name} placeholder in the endpoint path
+// with the matching `--arg name=value` flag or, failing that, the value of the
+// config key of that name. Values are escaped for use inside a path segment.
+// Placeholders that resolve to nothing are an error listing what is missing.
+func fillPathPlaceholders(path string, args []string, cfg config.Config, hostname string) (string, error) {
+	if !strings.ContainsRune(path, '{') {
+		return path, nil
+	}
+
+	values := map[string]string{}
+	for _, arg := range args {
+		name, value, _ := strings.Cut(arg, "=")
+		values[name] = value
+	}
+
+	var missing []string
+	filled := placeholderRE.ReplaceAllStringFunc(path, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		value, found := values[name]
+		if !found {
+			value, _ = cfg.Get(hostname, name)
+		}
+		if value == "" {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return url.PathEscape(value)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no values for endpoint placeholders: %s; pass them with `--arg name=value`", strings.Join(missing, ", "))
+	}
+	return filled, nil
+}
+
+// swappable for tests
+var rateLimitNow = time.Now
+var rateLimitSleep = time.Sleep
+
+// waitForRateLimitReset sleeps until the rate limit replenishes, printing a
+// countdown so the user knows the command is stalled on purpose. A cancelled
+// context (e.g. the `--max-time` deadline) cuts the wait short; the caller's
+// next request surfaces the cancellation.
+func waitForRateLimitReset(ctx context.Context, w io.Writer, reset time.Time) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		remaining := reset.Sub(rateLimitNow())
+		if remaining <= 0 {
+			return
+		}
+		fmt.Fprintf(w, "inst: rate limit exceeded; retrying in %s\n", remaining.Round(time.Second))
+		step := remaining
+		if step > 10*time.Second {
+			step = 10 * time.Second
+		}
+		rateLimitSleep(step)
+	}
+}
+
+// redactedValue replaces sensitive header values in `--dry-run` output.
+const redactedValue = "********"
+
+// dryRun prints the request that apiRun would make, with sensitive header
+// values redacted, and validates any `--jq` or `--template` expression.
+func dryRun(opts *ApiOptions, host *config.HostConfigTyped, method, path string, params interface{}, headers []string) error {
+	if opts.FilterOutput != "" {
+		if err := export.ValidateFilter(opts.FilterOutput); err != nil {
+			return fmt.Errorf("invalid `--jq` query: %w", err)
+		}
+	}
+	if opts.Template != "" {
+		template := newResponseTemplate(opts)
+		if err := template.Validate(); err != nil {
+			return fmt.Errorf("invalid `--template` format: %w", err)
+		}
+	}
+
+	requestURL := resolveRequestURL(host.APIHostname, path)
+
+	var body []byte
+	bodyIsJSON := false
+	switch pp := params.(type) {
+	case map[string]interface{}:
+		if strings.EqualFold(method, "GET") {
+			requestURL = addQuery(requestURL, pp)
+		} else if len(pp) > 0 {
+			b, err := json.MarshalIndent(pp, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error serializing parameters: %w", err)
+			}
+			body = b
+			bodyIsJSON = true
+		}
+	case io.Reader:
+		b, err := io.ReadAll(pp)
+		if err != nil {
+			return err
+		}
+		body = b
+		bodyIsJSON = json.Valid(b)
+	}
+
+	out := opts.IO.Out
+	fmt.Fprintf(out, "> %s %s\n", strings.ToUpper(method), requestURL)
+	if host.AccessToken != "" && !opts.NoAuth && !hasRequestHeader(headers, "Authorization") {
+		fmt.Fprintf(out, "> Authorization: bearer %s\n", redactedValue)
+	}
+	hasContentType := false
+	for _, h := range headers {
+		idx := strings.IndexRune(h, ':')
+		if idx == -1 {
+			return fmt.Errorf("header %q requires a value separated by ':'", h)
+		}
+		name, value := h[0:idx], strings.TrimSpace(h[idx+1:])
+		if strings.EqualFold(name, "Authorization") {
+			value = redactedValue
+		}
+		if strings.EqualFold(name, "Content-Type") {
+			hasContentType = true
+		}
+		fmt.Fprintf(out, "> %s: %s\n", name, value)
+	}
+	if bodyIsJSON && !hasContentType {
+		fmt.Fprintln(out, "> Content-Type: application/json; charset=utf-8")
+	}
+	if len(body) > 0 {
+		fmt.Fprintf(out, "\n%s\n", body)
+	}
+	return nil
 }
 
 func processResponse(resp *http.Response, opts *ApiOptions, headersOutputStream io.Writer, template *export.Template) (err error) {
@@ -249,24 +1352,25 @@ func processResponse(resp *http.Response, opts *ApiOptions, headersOutputStream
 		fmt.Fprint(headersOutputStream, "\r\n")
 	}
 
-	if resp.StatusCode == 204 {
+	if (resp.StatusCode == 204 && statusAllowed(opts, resp.StatusCode)) || opts.HeadRequest {
 		return
 	}
 
 	var responseBody io.Reader = resp.Body
 	defer resp.Body.Close()
 
-	isJSON, _ := regexp.MatchString(`[/+]json(;|$)`, resp.Header.Get("Content-Type"))
+	isJSON, responseBody := isJSONResponse(resp, responseBody)
 
 	var serverError string
-	if isJSON && resp.StatusCode >= 400 {
-		responseBody, serverError, err = parseErrorResponse(responseBody, resp.StatusCode)
+	var errorCodes []string
+	if isJSON && resp.StatusCode >= 400 && !statusAllowed(opts, resp.StatusCode) {
+		responseBody, serverError, errorCodes, err = parseErrorResponse(responseBody, resp.StatusCode)
 		if err != nil {
 			return
 		}
 	}
 
-	if opts.FilterOutput != "" {
+	if opts.FilterOutput != "" && isJSON {
 		// TODO: reuse parsed query across pagination invocations
 		err = export.FilterJSON(opts.IO.Out, responseBody, opts.FilterOutput)
 		if err != nil {
@@ -278,8 +1382,19 @@ func processResponse(resp *http.Response, opts *ApiOptions, headersOutputStream
 		if err != nil {
 			return
 		}
-	} else if isJSON && opts.IO.ColorEnabled() {
-		err = jsoncolor.Write(opts.IO.Out, responseBody, "  ")
+	} else if isJSON {
+		// pretty on a terminal, compact when piped; the flags force either
+		pretty := opts.IO.IsStdoutTTY()
+		if opts.Pretty {
+			pretty = true
+		} else if opts.Compact {
+			pretty = false
+		}
+		if pretty && opts.IO.ColorEnabled() {
+			err = jsoncolor.Write(opts.IO.Out, responseBody, "  ")
+		} else {
+			err = formatJSONBody(opts.IO.Out, responseBody, pretty)
+		}
 	} else {
 		_, err = io.Copy(opts.IO.Out, responseBody)
 	}
@@ -291,19 +1406,93 @@ func processResponse(resp *http.Response, opts *ApiOptions, headersOutputStream
 		}
 	}
 
-	if serverError == "" && resp.StatusCode > 299 {
+	if serverError == "" && !statusAllowed(opts, resp.StatusCode) {
 		serverError = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if len(opts.ExpectStatus) > 0 {
+			serverError += ", which `--expect-status` does not allow"
+		}
 	}
 
 	if serverError != "" {
 		fmt.Fprintf(opts.IO.ErrOut, "inst: %s\n", serverError)
-		err = cmdutil.SilentError
+		if opts.ExplainError {
+			explainErrorCodes(opts.IO.ErrOut, errorCodes)
+		}
+		err = cmdutil.HTTPStatusError{StatusCode: resp.StatusCode}
 		return
 	}
 
 	return
 }
 
+// statusAllowed reports whether a response status counts as success. By
+// default anything below 300 passes; `--expect-status` replaces that range
+// with an explicit allow list, so e.g. a 404 can be a valid outcome for a
+// script while an unlisted 200 is not.
+func statusAllowed(opts *ApiOptions, code int) bool {
+	if len(opts.ExpectStatus) > 0 {
+		for _, allowed := range opts.ExpectStatus {
+			if code == allowed {
+				return true
+			}
+		}
+		return false
+	}
+	return code < 300
+}
+
+// newResponseTemplate builds the response template, named after the
+// `--template-file` when one was given so parse errors reference the file
+// and line.
+func newResponseTemplate(opts *ApiOptions) export.Template {
+	if opts.TemplateFile != "" {
+		return export.NewTemplateWithName(opts.IO, opts.Template, opts.TemplateFile)
+	}
+	return export.NewTemplate(opts.IO, opts.Template)
+}
+
+var jsonContentTypeRE = regexp.MustCompile(`[/+]json(;|$)`)
+
+// isJSONResponse reports whether the response should go through the JSON
+// formatting paths. The Content-Type header is authoritative, so binary or
+// plain-text bodies are never mangled by accident; only when the header is
+// absent is a small prefix of the body sniffed. The returned reader replays
+// any bytes the sniff consumed.
+func isJSONResponse(resp *http.Response, body io.Reader) (bool, io.Reader) {
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		return jsonContentTypeRE.MatchString(contentType), body
+	}
+	peek := make([]byte, 512)
+	n, _ := io.ReadFull(body, peek)
+	body = io.MultiReader(bytes.NewReader(peek[:n]), body)
+	trimmed := bytes.TrimLeft(peek[:n], " \t\r\n")
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '['), body
+}
+
+// formatJSONBody re-indents or compacts a JSON response body; bodies that
+// turn out not to be valid JSON are copied through unchanged.
+func formatJSONBody(w io.Writer, r io.Reader, pretty bool) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		_, err = w.Write(data)
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if pretty {
+		err = json.Indent(buf, data, "", "  ")
+	} else {
+		err = json.Compact(buf, data)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
 func printHeaders(w io.Writer, headers http.Header, colorize bool) {
 	var names []string
 	for name := range headers {
@@ -324,6 +1513,62 @@ func printHeaders(w io.Writer, headers http.Header, colorize bool) {
 	}
 }
 
+// hasRequestHeader reports whether a header with the given name was already
+// specified in "key:value" format, ignoring case.
+func hasRequestHeader(headers []string, name string) bool {
+	for _, h := range headers {
+		if idx := strings.IndexRune(h, ':'); idx > -1 && strings.EqualFold(strings.TrimSpace(h[:idx]), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandRequestHeaders resolves "@file" header specs into their lines, one
+// header per line, and expands environment variable references in header
+// values so secrets do not have to appear on the command line.
+func expandRequestHeaders(headers []string) ([]string, error) {
+	expanded := make([]string, 0, len(headers))
+	for _, h := range headers {
+		if strings.HasPrefix(h, "@") {
+			data, err := os.ReadFile(h[1:])
+			if err != nil {
+				return nil, fmt.Errorf("error reading headers from %q: %w", h[1:], err)
+			}
+			for _, line := range strings.Split(string(data), "\n") {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				header, err := expandRequestHeader(line)
+				if err != nil {
+					return nil, err
+				}
+				expanded = append(expanded, header)
+			}
+			continue
+		}
+		header, err := expandRequestHeader(h)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, header)
+	}
+	return expanded, nil
+}
+
+func expandRequestHeader(h string) (string, error) {
+	idx := strings.IndexRune(h, ':')
+	if idx == -1 {
+		return "", fmt.Errorf("invalid header %q: expected \"name: value\" format", h)
+	}
+	name := strings.TrimSpace(h[:idx])
+	if name == "" {
+		return "", fmt.Errorf("invalid header %q: empty header name", h)
+	}
+	value := os.ExpandEnv(strings.TrimSpace(h[idx+1:]))
+	return fmt.Sprintf("%s: %s", name, value), nil
+}
+
 func parseFields(opts *ApiOptions) (map[string]interface{}, error) {
 	params := make(map[string]interface{})
 	for _, f := range opts.RawFields {
@@ -355,9 +1600,22 @@ func parseField(f string) (string, string, error) {
 	return f[0:idx], f[idx+1:], nil
 }
 
+// magicFieldValue coerces a typed (-F) field value: "true", "false", "null",
+// and integer-looking values become the matching JSON types, "@file" is
+// replaced by the file contents as a string ("@-" reads standard input), and
+// a leading backslash escapes the coercion so the remainder is always sent
+// as a string.
 func magicFieldValue(v string, opts *ApiOptions) (interface{}, error) {
+	if strings.HasPrefix(v, `\`) {
+		return v[1:], nil
+	}
+
 	if strings.HasPrefix(v, "@") {
-		return opts.IO.ReadUserFile(v[1:])
+		fileContents, err := opts.IO.ReadUserFile(v[1:])
+		if err != nil {
+			return nil, err
+		}
+		return string(fileContents), nil
 	}
 
 	if n, err := strconv.Atoi(v); err == nil {
@@ -376,6 +1634,40 @@ func magicFieldValue(v string, opts *ApiOptions) (interface{}, error) {
 	}
 }
 
+// swappable for tests
+var timeFlagNow = time.Now
+
+// parseTimeFlag converts a --since/--until value into an absolute timestamp:
+// an RFC3339 timestamp is used as-is, while a relative duration like "24h" or
+// "7d" is subtracted from the current time.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	raw := value
+	// time.ParseDuration has no day unit, so translate one before parsing
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		if n, err := strconv.Atoi(days); err == nil {
+			raw = fmt.Sprintf("%dh", n*24)
+		}
+	}
+	dur, err := time.ParseDuration(raw)
+	if err != nil || dur < 0 {
+		return time.Time{}, fmt.Errorf("expected an RFC3339 timestamp or a relative duration like \"24h\" or \"7d\"")
+	}
+	return now.Add(-dur), nil
+}
+
+// rawBodyReader turns a `--raw-body` value into a request body: "@file" reads
+// the bytes from a file, anything else is sent exactly as given.
+func rawBodyReader(spec string, stdin io.ReadCloser) (io.ReadCloser, int64, error) {
+	if strings.HasPrefix(spec, "@") {
+		return openUserFile(spec[1:], stdin)
+	}
+	return io.NopCloser(strings.NewReader(spec)), int64(len(spec)), nil
+}
+
 func openUserFile(fn string, stdin io.ReadCloser) (io.ReadCloser, int64, error) {
 	if fn == "-" {
 		return stdin, -1, nil
@@ -394,27 +1686,35 @@ func openUserFile(fn string, stdin io.ReadCloser) (io.ReadCloser, int64, error)
 	return r, s.Size(), nil
 }
 
-func parseErrorResponse(r io.Reader, statusCode int) (io.Reader, string, error) {
+func parseErrorResponse(r io.Reader, statusCode int) (io.Reader, string, []string, error) {
 	bodyCopy := &bytes.Buffer{}
 	b, err := io.ReadAll(io.TeeReader(r, bodyCopy))
 	if err != nil {
-		return r, "", err
+		return r, "", nil, err
 	}
 
 	var parsedBody struct {
 		Message string
+		Code    json.RawMessage
 		Errors  []json.RawMessage
 	}
 	err = json.Unmarshal(b, &parsedBody)
 	if err != nil {
-		return r, "", err
+		return r, "", nil, err
+	}
+
+	var codes []string
+	if code := rawCodeToString(parsedBody.Code); code != "" {
+		codes = append(codes, code)
 	}
+
 	if parsedBody.Message != "" {
-		return bodyCopy, fmt.Sprintf("%s (HTTP %d)", parsedBody.Message, statusCode), nil
+		return bodyCopy, fmt.Sprintf("%s (HTTP %d)", parsedBody.Message, statusCode), codes, nil
 	}
 
 	type errorMessage struct {
 		Message string
+		Code    json.RawMessage
 	}
 
 	var errors []string
@@ -426,22 +1726,37 @@ func parseErrorResponse(r io.Reader, statusCode int) (io.Reader, string, error)
 			var objectError errorMessage
 			err := json.Unmarshal(rawErr, &objectError)
 			if err != nil {
-				return r, "", err
+				return r, "", nil, err
 			}
 			errors = append(errors, objectError.Message)
+			if code := rawCodeToString(objectError.Code); code != "" {
+				codes = append(codes, code)
+			}
 		} else if rawErr[0] == '"' {
 			var stringError string
 			err := json.Unmarshal(rawErr, &stringError)
 			if err != nil {
-				return r, "", err
+				return r, "", nil, err
 			}
 			errors = append(errors, stringError)
 		}
 	}
 
 	if len(errors) > 0 {
-		return bodyCopy, strings.Join(errors, "\n"), nil
+		return bodyCopy, strings.Join(errors, "\n"), codes, nil
 	}
 
-	return bodyCopy, "", nil
+	return bodyCopy, "", codes, nil
+}
+
+// rawCodeToString extracts a string error code, ignoring numeric gRPC codes.
+func rawCodeToString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return ""
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/instill-ai/cli/api"
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/export"
@@ -40,7 +42,7 @@ func Test_NewCmdApi(t *testing.T) {
 				Hostname:            "api.instill.tech",
 				RequestMethod:       "DELETE",
 				RequestMethodPassed: true,
-				RequestPath:         "pipelines",
+				RequestPath:         "vdp/v1alpha/pipelines",
 				RequestInputFile:    "",
 				RawFields:           []string(nil),
 				MagicFields:         []string(nil),
@@ -80,7 +82,7 @@ func Test_NewCmdApi(t *testing.T) {
 				Hostname:            "api.instill.tech",
 				RequestMethod:       "GET",
 				RequestMethodPassed: false,
-				RequestPath:         "models",
+				RequestPath:         "model/v1alpha/models",
 				RequestInputFile:    "",
 				RawFields:           []string(nil),
 				MagicFields:         []string(nil),
@@ -118,6 +120,77 @@ func Test_NewCmdApi(t *testing.T) {
 			cli:      "",
 			wantsErr: true,
 		},
+		{
+			name:     "malformed base-url",
+			cli:      "user --base-url not-a-url",
+			wantsErr: true,
+		},
+		{
+			name:     "--form conflicts with --input",
+			cli:      "user --form --input body.txt",
+			wantsErr: true,
+		},
+		{
+			name:     "--graphql-paginate conflicts with --paginate",
+			cli:      "graphql --paginate --graphql-paginate",
+			wantsErr: true,
+		},
+		{
+			name:     "--graphql-paginate-path without --graphql-paginate",
+			cli:      "graphql --graphql-paginate-path data.things",
+			wantsErr: true,
+		},
+		{
+			name:     "invalid --since",
+			cli:      "user --since tomorrow",
+			wantsErr: true,
+		},
+		{
+			name:     "--since-param without --since",
+			cli:      "user --since-param from",
+			wantsErr: true,
+		},
+		{
+			name: "endpoint placeholder args",
+			cli:  "'vdp/v1alpha/{owner}/pipelines' --arg owner=alice",
+			wants: ApiOptions{
+				Hostname:      "api.instill.tech",
+				RequestMethod: "GET",
+				RequestPath:   "vdp/v1alpha/{owner}/pipelines",
+				PathArgs:      []string{"owner=alice"},
+			},
+			wantsErr: false,
+		},
+		{
+			name:     "malformed --arg",
+			cli:      "'vdp/v1alpha/{owner}/pipelines' --arg owner",
+			wantsErr: true,
+		},
+		{
+			name:     "invalid --max-response-size",
+			cli:      "user --max-response-size ten",
+			wantsErr: true,
+		},
+		{
+			name:     "--wait without --wait-until",
+			cli:      "user --wait",
+			wantsErr: true,
+		},
+		{
+			name:     "--wait-field without --wait",
+			cli:      "user --wait-field status",
+			wantsErr: true,
+		},
+		{
+			name: "no-auth without base-url",
+			cli:  "user --no-auth",
+			wants: ApiOptions{
+				Hostname:      "api.instill.tech",
+				RequestMethod: "GET",
+				RequestPath:   "user",
+			},
+			wantsErr: false,
+		},
 		{
 			name: "with cache",
 			cli:  "user --cache 5m",
@@ -178,6 +251,31 @@ func Test_NewCmdApi(t *testing.T) {
 			},
 			wantsErr: false,
 		},
+		{
+			name: "head request",
+			cli:  "user --head",
+			wants: ApiOptions{
+				Hostname:            "api.instill.tech",
+				RequestMethod:       "HEAD",
+				RequestMethodPassed: true,
+				RequestPath:         "user",
+				RequestInputFile:    "",
+				RawFields:           []string(nil),
+				MagicFields:         []string(nil),
+				RequestHeaders:      []string(nil),
+				ShowResponseHeaders: true,
+				Silent:              false,
+				CacheTTL:            0,
+				Template:            "",
+				FilterOutput:        "",
+			},
+			wantsErr: false,
+		},
+		{
+			name:     "--head with --method POST",
+			cli:      "user --head -XPOST",
+			wantsErr: true,
+		},
 		{
 			name:     "--silent with --jq",
 			cli:      "user --silent -q .foo",
@@ -193,6 +291,96 @@ func Test_NewCmdApi(t *testing.T) {
 			cli:      "user --jq .foo -t '{{.foo}}'",
 			wantsErr: true,
 		},
+		{
+			name:     "malformed --resolve",
+			cli:      "user --resolve api.instill.tech:443",
+			wantsErr: true,
+		},
+		{
+			name:     "--slurp without --paginate",
+			cli:      "pipelines --slurp",
+			wantsErr: true,
+		},
+		{
+			name:     "--slurp with --jq",
+			cli:      "pipelines --paginate --slurp -q .foo",
+			wantsErr: true,
+		},
+		{
+			name:     "--output ndjson without --slurp",
+			cli:      "pipelines --paginate --output ndjson",
+			wantsErr: true,
+		},
+		{
+			name:     "--slurp with a non-ndjson output format",
+			cli:      "pipelines --paginate --slurp --output table",
+			wantsErr: true,
+		},
+		{
+			name:     "--pretty with --compact",
+			cli:      "pipelines --pretty --compact",
+			wantsErr: true,
+		},
+		{
+			name:     "--raw-body with --raw-field",
+			cli:      "pipelines --raw-body hello -f key=value",
+			wantsErr: true,
+		},
+		{
+			name:     "--partial-ok without --max-time",
+			cli:      "pipelines --partial-ok",
+			wantsErr: true,
+		},
+		{
+			name:     "--max-time must be positive",
+			cli:      "pipelines --max-time 0s",
+			wantsErr: true,
+		},
+		{
+			name:     "--query without an equals sign",
+			cli:      "pipelines --query oops",
+			wantsErr: true,
+		},
+		{
+			name:     "--query-replace without --query",
+			cli:      "pipelines --query-replace",
+			wantsErr: true,
+		},
+		{
+			name:     "--stream with --paginate",
+			cli:      "pipelines --stream --paginate",
+			wantsErr: true,
+		},
+		{
+			name:     "--stream with --jq",
+			cli:      "pipelines --stream --jq .id",
+			wantsErr: true,
+		},
+		{
+			name:     "--expect-status with an invalid code",
+			cli:      "pipelines --expect-status 200,9000",
+			wantsErr: true,
+		},
+		{
+			name:     "--expect-status with a non-numeric code",
+			cli:      "pipelines --expect-status 200,teapot",
+			wantsErr: true,
+		},
+		{
+			name:     "--raw-body with --input",
+			cli:      "pipelines --raw-body hello --input body.txt",
+			wantsErr: true,
+		},
+		{
+			name:     "--template with --template-file",
+			cli:      "pipelines -t '{{.}}' --template-file report.tmpl",
+			wantsErr: true,
+		},
+		{
+			name:     "--template-file - with --input -",
+			cli:      "pipelines --template-file - --input -",
+			wantsErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -221,6 +409,7 @@ func Test_NewCmdApi(t *testing.T) {
 			assert.Equal(t, tt.wants.RequestPath, opts.RequestPath)
 			assert.Equal(t, tt.wants.RequestInputFile, opts.RequestInputFile)
 			assert.Equal(t, tt.wants.RawFields, opts.RawFields)
+			assert.Equal(t, tt.wants.PathArgs, opts.PathArgs)
 			assert.Equal(t, tt.wants.MagicFields, opts.MagicFields)
 			assert.Equal(t, tt.wants.RequestHeaders, opts.RequestHeaders)
 			assert.Equal(t, tt.wants.ShowResponseHeaders, opts.ShowResponseHeaders)
@@ -232,6 +421,96 @@ func Test_NewCmdApi(t *testing.T) {
 	}
 }
 
+func Test_NewCmdApi_apiDefaultOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      config.ConfigStub
+		cli         string
+		wantPretty  bool
+		wantCompact bool
+		wantSlurp   bool
+	}{
+		{
+			name:       "pretty from config",
+			config:     config.ConfigStub{"api_default_output": "pretty"},
+			cli:        "pipelines",
+			wantPretty: true,
+		},
+		{
+			name:        "compact from config",
+			config:      config.ConfigStub{"api_default_output": "compact"},
+			cli:         "pipelines",
+			wantCompact: true,
+		},
+		{
+			name:        "explicit flag wins over the config value",
+			config:      config.ConfigStub{"api_default_output": "pretty"},
+			cli:         "pipelines --compact",
+			wantCompact: true,
+		},
+		{
+			name:      "ndjson applies with --paginate",
+			config:    config.ConfigStub{"api_default_output": "ndjson"},
+			cli:       "pipelines --paginate",
+			wantSlurp: true,
+		},
+		{
+			name:   "ndjson is ignored without --paginate",
+			config: config.ConfigStub{"api_default_output": "ndjson"},
+			cli:    "pipelines",
+		},
+		{
+			name:   "json keeps the TTY-aware default",
+			config: config.ConfigStub{"api_default_output": "json"},
+			cli:    "pipelines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				Config: func() (config.Config, error) { return tt.config, nil },
+			}
+
+			var opts *ApiOptions
+			cmd := NewCmdAPI(f, func(o *ApiOptions) error {
+				opts = o
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			_, err = cmd.ExecuteC()
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantPretty, opts.Pretty)
+			assert.Equal(t, tt.wantCompact, opts.Compact)
+			assert.Equal(t, tt.wantSlurp, opts.Slurp)
+		})
+	}
+}
+
+func Test_NewCmdApi_configStdinConflict(t *testing.T) {
+	f := &cmdutil.Factory{
+		Config: config.ConfigStubFactory,
+	}
+
+	cmd := NewCmdAPI(f, func(o *ApiOptions) error { return nil })
+	// simulate the persistent flag inherited from the root command
+	cmd.Flags().Bool("config-stdin", false, "")
+
+	cmd.SetArgs([]string{"user", "--input", "-", "--config-stdin"})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	_, err := cmd.ExecuteC()
+	assert.EqualError(t, err, "`--input -` cannot be combined with `--config-stdin`")
+}
+
 func Test_apiRun(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -284,8 +563,8 @@ func Test_apiRun(t *testing.T) {
 				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "THIS IS FINE"}`)),
 				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
 			},
-			err:    cmdutil.SilentError,
-			stdout: `{"message": "THIS IS FINE"}`,
+			err:    cmdutil.HTTPStatusError{StatusCode: 400},
+			stdout: `{"message":"THIS IS FINE"}`,
 			stderr: "inst: THIS IS FINE (HTTP 400)\n",
 		},
 		{
@@ -295,20 +574,97 @@ func Test_apiRun(t *testing.T) {
 				Body:       io.NopCloser(bytes.NewBufferString(`{"errors": ["ALSO", "FINE"]}`)),
 				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
 			},
-			err:    cmdutil.SilentError,
-			stdout: `{"errors": ["ALSO", "FINE"]}`,
+			err:    cmdutil.HTTPStatusError{StatusCode: 400},
+			stdout: `{"errors":["ALSO","FINE"]}`,
 			stderr: "inst: ALSO\nFINE\n",
 		},
+		{
+			name: "compacts JSON when piped",
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("{\n  \"message\": \"OK\"\n}")),
+				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			},
+			err:    nil,
+			stdout: `{"message":"OK"}`,
+			stderr: ``,
+		},
+		{
+			name: "pretty-prints JSON on request",
+			options: ApiOptions{
+				Pretty: true,
+			},
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message":"OK"}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			},
+			err:    nil,
+			stdout: "{\n  \"message\": \"OK\"\n}",
+			stderr: ``,
+		},
+		{
+			name: "invalid JSON body prints unchanged",
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": truncat`)),
+				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			},
+			err:    nil,
+			stdout: `{"message": truncat`,
+			stderr: ``,
+		},
 		{
 			name: "failure",
 			httpResponse: &http.Response{
 				StatusCode: 502,
 				Body:       io.NopCloser(bytes.NewBufferString(`gateway timeout`)),
 			},
-			err:    cmdutil.SilentError,
+			err:    cmdutil.HTTPStatusError{StatusCode: 502},
 			stdout: `gateway timeout`,
 			stderr: "inst: HTTP 502\n",
 		},
+		{
+			name: "allowed status via --expect-status",
+			options: ApiOptions{
+				ExpectStatus: []int{200, 404},
+			},
+			httpResponse: &http.Response{
+				StatusCode: 404,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "not found"}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			},
+			err:    nil,
+			stdout: `{"message":"not found"}`,
+			stderr: ``,
+		},
+		{
+			name: "status outside --expect-status",
+			options: ApiOptions{
+				ExpectStatus: []int{200, 404},
+			},
+			httpResponse: &http.Response{
+				StatusCode: 201,
+				Body:       io.NopCloser(bytes.NewBufferString(`created`)),
+			},
+			err:    cmdutil.HTTPStatusError{StatusCode: 201},
+			stdout: `created`,
+			stderr: "inst: HTTP 201, which `--expect-status` does not allow\n",
+		},
+		{
+			name: "server error outside --expect-status",
+			options: ApiOptions{
+				ExpectStatus: []int{200, 404},
+			},
+			httpResponse: &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "THIS IS FINE"}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			},
+			err:    cmdutil.HTTPStatusError{StatusCode: 500},
+			stdout: `{"message":"THIS IS FINE"}`,
+			stderr: "inst: THIS IS FINE (HTTP 500)\n",
+		},
 		{
 			name: "silent",
 			options: ApiOptions{
@@ -367,6 +723,40 @@ func Test_apiRun(t *testing.T) {
 			stdout: "Mona\nHubot\n",
 			stderr: ``,
 		},
+		{
+			name: "sniffs JSON when the content type is absent",
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("{\n  \"message\": \"OK\"\n}")),
+			},
+			err:    nil,
+			stdout: `{"message":"OK"}`,
+			stderr: ``,
+		},
+		{
+			name: "missing content type with a non-JSON body",
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("plain text\n")),
+			},
+			err:    nil,
+			stdout: "plain text\n",
+			stderr: ``,
+		},
+		{
+			name: "jq filter skipped for non-JSON content type",
+			options: ApiOptions{
+				FilterOutput: `.name`,
+			},
+			httpResponse: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString(`just text`)),
+				Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			},
+			err:    nil,
+			stdout: `just text`,
+			stderr: ``,
+		},
 	}
 
 	for _, tt := range tests {
@@ -477,19 +867,156 @@ func Test_apiRun_inputFile(t *testing.T) {
 	}
 }
 
-func Test_apiRun_cache(t *testing.T) {
-	stream, _, stdout, stderr := iostreams.Test()
+func Test_apiRun_rawBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawBody     string
+		headers     []string
+		bodyFile    string
+		wantBody    string
+		wantType    string
+		wantLength  int64
+		wantsMethod string
+	}{
+		{
+			name:        "inline string",
+			rawBody:     "line one\nline two",
+			wantBody:    "line one\nline two",
+			wantType:    "text/plain",
+			wantLength:  17,
+			wantsMethod: "POST",
+		},
+		{
+			name:        "from a file",
+			bodyFile:    `{"not":"wrapped"}`,
+			wantBody:    `{"not":"wrapped"}`,
+			wantType:    "text/plain",
+			wantLength:  17,
+			wantsMethod: "POST",
+		},
+		{
+			name:        "explicit content type wins",
+			rawBody:     `{"a":1}` + "\n" + `{"a":2}`,
+			headers:     []string{"Content-Type: application/x-ndjson"},
+			wantBody:    `{"a":1}` + "\n" + `{"a":2}`,
+			wantType:    "application/x-ndjson",
+			wantLength:  15,
+			wantsMethod: "POST",
+		},
+	}
 
-	requestCount := 0
-	options := ApiOptions{
-		IO: stream,
-		HTTPClient: func() (*http.Client, error) {
-			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
-				requestCount++
-				return &http.Response{
-					Request:    req,
-					StatusCode: 204,
-				}, nil
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawBody := tt.rawBody
+			if tt.bodyFile != "" {
+				fn := filepath.Join(t.TempDir(), "body.txt")
+				require.NoError(t, os.WriteFile(fn, []byte(tt.bodyFile), 0600))
+				rawBody = "@" + fn
+			}
+
+			stream, _, _, _ := iostreams.Test()
+			var gotReq *http.Request
+			var bodyBytes []byte
+			options := ApiOptions{
+				IO: stream,
+				HTTPClient: func() (*http.Client, error) {
+					var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+						gotReq = req
+						var err error
+						if bodyBytes, err = io.ReadAll(req.Body); err != nil {
+							return nil, err
+						}
+						return &http.Response{
+							Request:    req,
+							StatusCode: 204,
+						}, nil
+					}
+					return &http.Client{Transport: tr}, nil
+				},
+				Config:         config.ConfigStubFactory,
+				RequestPath:    "pipelines",
+				RawBody:        rawBody,
+				RequestHeaders: tt.headers,
+			}
+
+			require.NoError(t, apiRun(&options))
+			assert.Equal(t, tt.wantBody, string(bodyBytes))
+			assert.Equal(t, tt.wantsMethod, gotReq.Method)
+			assert.Equal(t, tt.wantType, gotReq.Header.Get("Content-Type"))
+			assert.Equal(t, tt.wantLength, gotReq.ContentLength)
+		})
+	}
+}
+
+func Test_apiRun_templateFile(t *testing.T) {
+	makeOptions := func(stream *iostreams.IOStreams) ApiOptions {
+		return ApiOptions{
+			IO: stream,
+			HTTPClient: func() (*http.Client, error) {
+				var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						Request:    req,
+						StatusCode: 200,
+						Body:       io.NopCloser(bytes.NewBufferString(`{"status":"not a cat"}`)),
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+					}, nil
+				}
+				return &http.Client{Transport: tr}, nil
+			},
+			Config:      config.ConfigStubFactory,
+			RequestPath: "pipelines",
+		}
+	}
+
+	t.Run("renders a template from a file", func(t *testing.T) {
+		templateFile := filepath.Join(t.TempDir(), "status.tmpl")
+		require.NoError(t, os.WriteFile(templateFile, []byte(`{{.status}}`), 0600))
+
+		stream, _, stdout, _ := iostreams.Test()
+		options := makeOptions(stream)
+		options.TemplateFile = templateFile
+
+		require.NoError(t, apiRun(&options))
+		assert.Equal(t, "not a cat", stdout.String())
+	})
+
+	t.Run("reads the template from stdin", func(t *testing.T) {
+		stream, stdin, stdout, _ := iostreams.Test()
+		stdin.WriteString(`{{.status}}`)
+		options := makeOptions(stream)
+		options.TemplateFile = "-"
+
+		require.NoError(t, apiRun(&options))
+		assert.Equal(t, "not a cat", stdout.String())
+	})
+
+	t.Run("parse errors reference the file and line", func(t *testing.T) {
+		templateFile := filepath.Join(t.TempDir(), "broken.tmpl")
+		require.NoError(t, os.WriteFile(templateFile, []byte(`{{.status`), 0600))
+
+		stream, _, _, _ := iostreams.Test()
+		options := makeOptions(stream)
+		options.TemplateFile = templateFile
+
+		err := apiRun(&options)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), templateFile+":1:")
+	})
+}
+
+func Test_apiRun_cache(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+
+	requestCount := 0
+	options := ApiOptions{
+		IO: stream,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				requestCount++
+				return &http.Response{
+					Request:    req,
+					StatusCode: 204,
+				}, nil
 			}
 			return &http.Client{Transport: tr}, nil
 		},
@@ -514,6 +1041,29 @@ func Test_apiRun_cache(t *testing.T) {
 	assert.Equal(t, "", stderr.String(), "stderr")
 }
 
+func Test_apiRun_clearCache(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+
+	cacheDir := t.TempDir()
+	entry := filepath.Join(cacheDir, "ab", "cd", "0123456789abcdef")
+	require.NoError(t, os.MkdirAll(filepath.Dir(entry), 0755))
+	require.NoError(t, os.WriteFile(entry, []byte("cached"), 0600))
+
+	options := ApiOptions{
+		IO:         stream,
+		Config:     config.ConfigStubFactory,
+		CacheDir:   cacheDir,
+		ClearCache: true,
+	}
+
+	require.NoError(t, apiRun(&options))
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "Cleared 1 cached responses (6 bytes)\n", stderr.String())
+
+	_, err := os.Stat(entry)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func Test_parseFields(t *testing.T) {
 	stream, stdin, _, _ := iostreams.Test()
 	fmt.Fprint(stdin, "pasted contents")
@@ -543,7 +1093,7 @@ func Test_parseFields(t *testing.T) {
 		"destroyer": "false",
 		"helper":    "true",
 		"location":  "@work",
-		"input":     []byte("pasted contents"),
+		"input":     "pasted contents",
 		"enabled":   true,
 		"victories": 123,
 	}
@@ -559,7 +1109,8 @@ func Test_magicFieldValue(t *testing.T) {
 
 	fmt.Fprint(f, "file contents")
 
-	stream, _, _, _ := iostreams.Test()
+	stream, stdin, _, _ := iostreams.Test()
+	fmt.Fprint(stdin, "stdin contents")
 
 	type args struct {
 		v    string
@@ -595,13 +1146,52 @@ func Test_magicFieldValue(t *testing.T) {
 			want:    nil,
 			wantErr: false,
 		},
+		{
+			name:    "integer",
+			args:    args{v: "123"},
+			want:    123,
+			wantErr: false,
+		},
+		{
+			name:    "negative integer",
+			args:    args{v: "-3"},
+			want:    -3,
+			wantErr: false,
+		},
+		{
+			name:    "version string stays a string",
+			args:    args{v: "1.2"},
+			want:    "1.2",
+			wantErr: false,
+		},
+		{
+			name:    "leading backslash forces a string",
+			args:    args{v: `\123`},
+			want:    "123",
+			wantErr: false,
+		},
+		{
+			name:    "backslash escapes the file syntax",
+			args:    args{v: `\@file.txt`},
+			want:    "@file.txt",
+			wantErr: false,
+		},
 		{
 			name: "file",
 			args: args{
 				v:    "@" + f.Name(),
 				opts: &ApiOptions{IO: stream},
 			},
-			want:    []byte("file contents"),
+			want:    "file contents",
+			wantErr: false,
+		},
+		{
+			name: "standard input",
+			args: args{
+				v:    "@-",
+				opts: &ApiOptions{IO: stream},
+			},
+			want:    "stdin contents",
 			wantErr: false,
 		},
 		{
@@ -694,3 +1284,704 @@ func Test_processResponse_template(t *testing.T) {
 	`), stdout.String())
 	assert.Equal(t, "", stderr.String())
 }
+
+func Test_apiRun_dryRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ApiOptions
+		stdout  string
+		wantErr string
+	}{
+		{
+			name: "GET with query parameters",
+			options: ApiOptions{
+				RequestMethod:       "GET",
+				RequestMethodPassed: true,
+				RequestPath:         "vdp/v1alpha/pipelines",
+				RawFields:           []string{"visibility=public"},
+			},
+			stdout: "> GET https://api.instill.tech/vdp/v1alpha/pipelines?visibility=public\n",
+		},
+		{
+			name: "POST with body",
+			options: ApiOptions{
+				RequestPath: "vdp/v1alpha/pipelines",
+				RawFields:   []string{"id=hello"},
+			},
+			stdout: heredoc.Doc(`
+				> POST https://api.instill.tech/vdp/v1alpha/pipelines
+				> Content-Type: application/json; charset=utf-8
+
+				{
+				  "id": "hello"
+				}
+			`),
+		},
+		{
+			name: "redacts authorization header",
+			options: ApiOptions{
+				RequestMethod:  "GET",
+				RequestPath:    "vdp/v1alpha/pipelines",
+				RequestHeaders: []string{"Authorization: Basic s3cret"},
+			},
+			stdout: heredoc.Doc(`
+				> GET https://api.instill.tech/vdp/v1alpha/pipelines
+				> Authorization: ********
+			`),
+		},
+		{
+			name: "invalid jq query",
+			options: ApiOptions{
+				RequestMethod: "GET",
+				RequestPath:   "vdp/v1alpha/pipelines",
+				FilterOutput:  ".[",
+			},
+			wantErr: "invalid `--jq` query",
+		},
+		{
+			name: "invalid template",
+			options: ApiOptions{
+				RequestMethod: "GET",
+				RequestPath:   "vdp/v1alpha/pipelines",
+				Template:      "{{.name",
+			},
+			wantErr: "invalid `--template` format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, stderr := iostreams.Test()
+			tt.options.IO = io
+			tt.options.DryRun = true
+			tt.options.Config = config.ConfigStubFactory
+			tt.options.HTTPClient = func() (*http.Client, error) {
+				t.Fatal("no HTTP request should be made in dry-run mode")
+				return nil, nil
+			}
+
+			err := apiRun(&tt.options)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stdout, stdout.String())
+			assert.Equal(t, "", stderr.String())
+		})
+	}
+}
+
+func Test_apiRun_rateLimit(t *testing.T) {
+	t.Run("fails fast without --respect-rate-limit", func(t *testing.T) {
+		stream, _, _, stderr := iostreams.Test()
+
+		options := ApiOptions{
+			IO:          stream,
+			RequestPath: "vdp/v1alpha/pipelines",
+			Config:      config.ConfigStubFactory,
+			HTTPClient: func() (*http.Client, error) {
+				var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 429,
+						Request:    req,
+						Header:     http.Header{"Retry-After": []string{"30"}},
+						Body:       io.NopCloser(bytes.NewBufferString("")),
+					}, nil
+				}
+				return &http.Client{Transport: tr}, nil
+			},
+		}
+
+		err := apiRun(&options)
+		assert.Equal(t, cmdutil.HTTPStatusError{StatusCode: 429}, err)
+		assert.Contains(t, stderr.String(), "rate limit exceeded; resets at")
+		assert.Contains(t, stderr.String(), "inst: HTTP 429\n")
+	})
+
+	t.Run("waits and retries with --respect-rate-limit", func(t *testing.T) {
+		stream, _, stdout, stderr := iostreams.Test()
+
+		current := time.Now()
+		origNow, origSleep := rateLimitNow, rateLimitSleep
+		rateLimitNow = func() time.Time { return current }
+		var slept time.Duration
+		rateLimitSleep = func(d time.Duration) {
+			slept += d
+			current = current.Add(d)
+		}
+		defer func() {
+			rateLimitNow, rateLimitSleep = origNow, origSleep
+		}()
+
+		requestCount := 0
+		options := ApiOptions{
+			IO:               stream,
+			RequestPath:      "vdp/v1alpha/pipelines",
+			RespectRateLimit: true,
+			Config:           config.ConfigStubFactory,
+			HTTPClient: func() (*http.Client, error) {
+				var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+					requestCount++
+					if requestCount == 1 {
+						return &http.Response{
+							StatusCode: 429,
+							Request:    req,
+							Header:     http.Header{"Retry-After": []string{"5"}},
+							Body:       io.NopCloser(bytes.NewBufferString("")),
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Request:    req,
+						Body:       io.NopCloser(bytes.NewBufferString("ok")),
+					}, nil
+				}
+				return &http.Client{Transport: tr}, nil
+			},
+		}
+
+		err := apiRun(&options)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, requestCount)
+		assert.Greater(t, slept, time.Duration(0))
+		assert.Contains(t, stderr.String(), "rate limit exceeded; retrying in")
+		assert.Equal(t, "ok", stdout.String())
+	})
+}
+
+func Test_apiRun_explicitGETWithBodyWarns(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`{}`), 0600))
+
+	stream, _, _, stderr := iostreams.Test()
+	opts := ApiOptions{
+		IO:                  stream,
+		Config:              config.ConfigStubFactory,
+		RequestMethod:       "GET",
+		RequestMethodPassed: true,
+		RequestPath:         "vdp/v1alpha/pipelines",
+		RequestInputFile:    inputFile,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 204,
+					Request:    req,
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&opts))
+	assert.Contains(t, stderr.String(), "warning: request body may be ignored by the server with `--method GET`")
+}
+
+func Test_apiRun_apiVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    ApiOptions
+		configStub config.ConfigStub
+		wantAccept string
+	}{
+		{
+			name:       "no version pinned",
+			wantAccept: "",
+		},
+		{
+			name:       "version from config",
+			configStub: config.ConfigStub{"api_version": "v1beta"},
+			wantAccept: "application/json; version=v1beta",
+		},
+		{
+			name: "flag overrides config",
+			options: ApiOptions{
+				APIVersion: "v2",
+			},
+			configStub: config.ConfigStub{"api_version": "v1beta"},
+			wantAccept: "application/json; version=v2",
+		},
+		{
+			name: "explicit Accept header wins",
+			options: ApiOptions{
+				APIVersion:     "v2",
+				RequestHeaders: []string{"accept: text/plain"},
+			},
+			wantAccept: "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, _, _, _ := iostreams.Test()
+
+			var gotAccept string
+			tt.options.IO = stream
+			tt.options.Silent = true
+			tt.options.RequestPath = "vdp/v1alpha/pipelines"
+			tt.options.Config = func() (config.Config, error) {
+				if tt.configStub == nil {
+					return config.ConfigStub{}, nil
+				}
+				return tt.configStub, nil
+			}
+			tt.options.HTTPClient = func() (*http.Client, error) {
+				var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+					gotAccept = req.Header.Get("Accept")
+					return &http.Response{
+						StatusCode: 204,
+						Request:    req,
+					}, nil
+				}
+				return &http.Client{Transport: tr}, nil
+			}
+
+			require.NoError(t, apiRun(&tt.options))
+			assert.Equal(t, tt.wantAccept, gotAccept)
+		})
+	}
+}
+
+func Test_apiRun_baseURL(t *testing.T) {
+	yml := heredoc.Doc(`
+		hosts:
+		  api.instill.tech:
+		    access_token: ATOKEN
+	`)
+
+	tests := []struct {
+		name     string
+		noAuth   bool
+		wantAuth string
+	}{
+		{
+			name:     "stored token still authenticates",
+			wantAuth: "bearer ATOKEN",
+		},
+		{
+			name:     "--no-auth omits the token",
+			noAuth:   true,
+			wantAuth: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainBuf := bytes.Buffer{}
+			hostsBuf := bytes.Buffer{}
+			defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+			stream, _, _, _ := iostreams.Test()
+
+			var gotReq *http.Request
+			options := ApiOptions{
+				IO:          stream,
+				Config:      func() (config.Config, error) { return config.MustNewFromString(yml), nil },
+				RequestPath: "vdp/v1alpha/pipelines",
+				BaseURL:     "http://localhost:8080",
+				NoAuth:      tt.noAuth,
+				Silent:      true,
+				HTTPClient: func() (*http.Client, error) {
+					var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+						gotReq = req
+						return &http.Response{
+							StatusCode: 204,
+							Request:    req,
+						}, nil
+					}
+					return &http.Client{Transport: tr}, nil
+				},
+			}
+
+			require.NoError(t, apiRun(&options))
+			assert.Equal(t, "http://localhost:8080/vdp/v1alpha/pipelines", gotReq.URL.String())
+			assert.Equal(t, tt.wantAuth, gotReq.Header.Get("Authorization"))
+		})
+	}
+}
+
+func Test_apiRun_form(t *testing.T) {
+	yml := heredoc.Doc(`
+		hosts:
+		  api.instill.tech:
+		    access_token: ATOKEN
+	`)
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	stream, _, _, _ := iostreams.Test()
+
+	var gotReq *http.Request
+	var gotBody string
+	options := ApiOptions{
+		IO:          stream,
+		Config:      func() (config.Config, error) { return config.MustNewFromString(yml), nil },
+		RequestPath: "vdp/v1alpha/pipelines",
+		RawFields:   []string{"name=hello world", "mode=sync"},
+		Form:        true,
+		Silent:      true,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				gotReq = req
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				gotBody = string(body)
+				return &http.Response{
+					StatusCode: 204,
+					Request:    req,
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+	assert.Equal(t, "POST", gotReq.Method)
+	assert.Equal(t, "application/x-www-form-urlencoded", gotReq.Header.Get("Content-Type"))
+	assert.Equal(t, "mode=sync&name=hello+world", gotBody)
+}
+
+func Test_parseTimeFlag(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "RFC3339 timestamp",
+			value: "2023-01-02T15:04:05Z",
+			want:  time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:  "relative hours",
+			value: "24h",
+			want:  now.Add(-24 * time.Hour),
+		},
+		{
+			name:  "relative days",
+			value: "7d",
+			want:  now.Add(-7 * 24 * time.Hour),
+		},
+		{
+			name:    "not a time",
+			value:   "tomorrow",
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			value:   "-1h",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeFlag(tt.value, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func Test_apiRun_since(t *testing.T) {
+	oldNow := timeFlagNow
+	timeFlagNow = func() time.Time {
+		return time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	}
+	defer func() { timeFlagNow = oldNow }()
+
+	var gotReq *http.Request
+	stream, _, _, _ := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		Since:         "24h",
+		SinceParam:    "since",
+		Until:         "2023-06-10T00:00:00Z",
+		UntilParam:    "before",
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				gotReq = req
+				return &http.Response{
+					StatusCode: 204,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+	require.NotNil(t, gotReq)
+	assert.Equal(t, "2023-06-14T12:00:00Z", gotReq.URL.Query().Get("since"))
+	assert.Equal(t, "2023-06-10T00:00:00Z", gotReq.URL.Query().Get("before"))
+}
+
+func Test_apiRun_showRetries(t *testing.T) {
+	var attempts int
+	stream, _, _, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		ShowRetries:   true,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				attempts++
+				status := http.StatusServiceUnavailable
+				if attempts == 2 {
+					status = http.StatusNoContent
+				}
+				return &http.Response{
+					StatusCode: status,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil
+			}
+			policy := api.RetryPolicy{
+				MaxRetries: 2,
+				Base:       time.Millisecond,
+				Cap:        time.Millisecond,
+			}
+			return api.NewHTTPClient(api.ReplaceTripper(tr), api.Retry(policy)), nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, "inst: retried 1 time:\n- HTTP 503, waited 1ms before retrying\n", stderr.String())
+}
+
+func Test_apiRun_maxResponseSize(t *testing.T) {
+	stream, _, _, _ := iostreams.Test()
+	options := ApiOptions{
+		IO:              stream,
+		RequestPath:     "vdp/v1alpha/pipelines",
+		RequestMethod:   "GET",
+		MaxResponseSize: "1KB",
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(strings.NewReader(strings.Repeat("x", 2048))),
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	err := apiRun(&options)
+	assert.EqualError(t, err, "response body exceeds the maximum size of 1KB; raise `--max-response-size` or stream it with `--output-file`")
+}
+
+func Test_fillPathPlaceholders(t *testing.T) {
+	cfg := config.ConfigStub{
+		"api.instill.tech:owner": "bob",
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no placeholders",
+			path: "vdp/v1alpha/pipelines",
+			want: "vdp/v1alpha/pipelines",
+		},
+		{
+			name: "filled from --arg",
+			path: "vdp/v1alpha/{owner}/pipelines",
+			args: []string{"owner=alice"},
+			want: "vdp/v1alpha/alice/pipelines",
+		},
+		{
+			name: "filled from config when no --arg matches",
+			path: "vdp/v1alpha/{owner}/pipelines",
+			want: "vdp/v1alpha/bob/pipelines",
+		},
+		{
+			name: "values are escaped for path segments",
+			path: "vdp/v1alpha/{owner}/pipelines",
+			args: []string{"owner=a/b c"},
+			want: "vdp/v1alpha/a%2Fb%20c/pipelines",
+		},
+		{
+			name:    "missing placeholders are listed",
+			path:    "vdp/{version}/{name}/pipelines",
+			args:    []string{"owner=alice"},
+			wantErr: "no values for endpoint placeholders: version, name; pass them with `--arg name=value`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fillPathPlaceholders(tt.path, tt.args, cfg, "api.instill.tech")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_expandRequestHeaders(t *testing.T) {
+	t.Setenv("MY_TOKEN", "s3cret")
+
+	headersFile := filepath.Join(t.TempDir(), "headers.txt")
+	require.NoError(t, os.WriteFile(headersFile, []byte("Accept: application/json\n\nX-Token: ${MY_TOKEN}\n"), 0600))
+
+	tests := []struct {
+		name    string
+		input   []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "plain headers pass through",
+			input: []string{"Accept: application/json"},
+			want:  []string{"Accept: application/json"},
+		},
+		{
+			name:  "environment variables are expanded",
+			input: []string{"Authorization: Bearer ${MY_TOKEN}"},
+			want:  []string{"Authorization: Bearer s3cret"},
+		},
+		{
+			name:  "headers from a file",
+			input: []string{"@" + headersFile},
+			want:  []string{"Accept: application/json", "X-Token: s3cret"},
+		},
+		{
+			name:    "missing separator",
+			input:   []string{"not-a-header"},
+			wantErr: `invalid header "not-a-header": expected "name: value" format`,
+		},
+		{
+			name:    "empty header name",
+			input:   []string{": value"},
+			wantErr: `invalid header ": value": empty header name`,
+		},
+		{
+			name:    "missing file",
+			input:   []string{"@no-such-file"},
+			wantErr: `error reading headers from "no-such-file"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandRequestHeaders(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_apiRun_cancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	stream, _, stdout, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:          stream,
+		Context:     ctx,
+		RequestPath: "base/v1alpha/users/me",
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				return nil, req.Context().Err()
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	err := apiRun(&options)
+	assert.Equal(t, cmdutil.SilentError, err)
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "inst: request cancelled; output may be incomplete\n", stderr.String())
+}
+
+func Test_apiRun_maxTime(t *testing.T) {
+	page1 := `{"pipelines":[{"id":"a"}],"next_page_token":"page2"}`
+
+	// the first page arrives immediately; the second request stalls until the
+	// --max-time deadline cancels its context
+	makeOptions := func(stream *iostreams.IOStreams) ApiOptions {
+		var requestCount int
+		return ApiOptions{
+			IO:            stream,
+			RequestPath:   "vdp/v1alpha/pipelines",
+			RequestMethod: "GET",
+			Paginate:      true,
+			MaxTime:       100 * time.Millisecond,
+
+			Config: config.ConfigStubFactory,
+			HTTPClient: func() (*http.Client, error) {
+				var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+					requestCount++
+					if requestCount > 1 {
+						<-req.Context().Done()
+						return nil, req.Context().Err()
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Request:    req,
+						Body:       io.NopCloser(bytes.NewBufferString(page1)),
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+					}, nil
+				}
+				return &http.Client{Transport: tr}, nil
+			},
+		}
+	}
+
+	t.Run("fails outright by default", func(t *testing.T) {
+		stream, _, _, stderr := iostreams.Test()
+		options := makeOptions(stream)
+
+		err := apiRun(&options)
+		require.EqualError(t, err, "exceeded the `--max-time` limit of 100ms")
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("keeps partial output with --partial-ok", func(t *testing.T) {
+		stream, _, stdout, stderr := iostreams.Test()
+		options := makeOptions(stream)
+		options.PartialOK = true
+
+		err := apiRun(&options)
+		assert.Equal(t, cmdutil.SilentError, err)
+		assert.Equal(t, page1, stdout.String())
+		assert.Equal(t, "inst: exceeded `--max-time` after 100ms; output is incomplete\n", stderr.String())
+	})
+}
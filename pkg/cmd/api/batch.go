@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/export"
+)
+
+// batchResult holds the outcome of one line of a newline-delimited input file.
+type batchResult struct {
+	resp    *http.Response
+	err     error
+	skipped bool
+}
+
+// batchRun sends one request per line of the `--input` file, up to
+// `--concurrency` requests in flight at a time, and prints the responses in
+// input order. Individual failures are reported in a summary at the end unless
+// `--fail-fast` aborts the batch on the first one.
+func batchRun(opts *ApiOptions, host *config.HostConfigTyped, params map[string]interface{}) error {
+	file, _, err := openUserFile(opts.RequestInputFile, opts.IO.In)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, append([]byte(nil), line...))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("no requests found in %q", opts.RequestInputFile)
+	}
+
+	method := opts.RequestMethod
+	if !opts.RequestMethodPassed {
+		method = "POST"
+	}
+	requestPath := addQuery(opts.RequestPath, params)
+	requestHeaders := opts.RequestHeaders
+
+	httpClient, err := opts.HTTPClient()
+	if err != nil {
+		return err
+	}
+
+	headersOutputStream := opts.IO.Out
+	if opts.Silent {
+		opts.IO.Out = io.Discard
+	} else {
+		if err := opts.IO.StartPager(); err != nil {
+			return err
+		}
+		defer opts.IO.StopPager()
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(lines) {
+		concurrency = len(lines)
+	}
+
+	results := make([]batchResult, len(lines))
+	jobs := make(chan int)
+	var stopped uint32
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if atomic.LoadUint32(&stopped) == 1 {
+					results[i].skipped = true
+					continue
+				}
+				resp, err := httpRequest(ctx, httpClient, host.APIHostname, method, requestPath, bytes.NewReader(lines[i]), requestHeaders)
+				if err == nil {
+					// buffer the body so responses can be printed in input order
+					var body []byte
+					if body, err = io.ReadAll(resp.Body); err == nil {
+						resp.Body.Close()
+						resp.Body = io.NopCloser(bytes.NewReader(body))
+					}
+				}
+				results[i] = batchResult{resp: resp, err: err}
+				if opts.FailFast && (err != nil || !statusAllowed(opts, resp.StatusCode)) {
+					atomic.StoreUint32(&stopped, 1)
+				}
+			}
+		}()
+	}
+	for i := range lines {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	template := export.NewTemplate(opts.IO, opts.Template)
+	failed := 0
+	for i, result := range results {
+		if result.skipped {
+			continue
+		}
+		if result.err != nil {
+			failed++
+			fmt.Fprintf(opts.IO.ErrOut, "inst: request %d: %v\n", i+1, result.err)
+			if opts.FailFast {
+				return cmdutil.SilentError
+			}
+			continue
+		}
+		if err := processResponse(result.resp, opts, headersOutputStream, &template); err != nil {
+			var httpErr cmdutil.HTTPStatusError
+			if !errors.As(err, &httpErr) {
+				return err
+			}
+			failed++
+			if opts.FailFast {
+				return err
+			}
+		}
+	}
+	if err := template.End(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "inst: %d of %d requests failed\n", failed, len(lines))
+		return cmdutil.SilentError
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_batchRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		concurrency int
+		failFast    bool
+		err         error
+		stdout      string
+		stderr      string
+	}{
+		{
+			name:        "responses in input order",
+			input:       "{\"n\":1}\n{\"n\":2}\n{\"n\":3}\n",
+			concurrency: 3,
+			stdout:      `{"n":1}{"n":2}{"n":3}`,
+		},
+		{
+			name:        "failures are summarized",
+			input:       "{\"n\":1}\nboom\n{\"n\":3}\n",
+			concurrency: 1,
+			err:         cmdutil.SilentError,
+			stdout:      `{"n":1}{"message":"oops"}{"n":3}`,
+			stderr:      "inst: oops (HTTP 500)\ninst: 1 of 3 requests failed\n",
+		},
+		{
+			name:        "fail fast aborts the batch",
+			input:       "boom\n{\"n\":2}\n",
+			concurrency: 1,
+			failFast:    true,
+			err:         cmdutil.HTTPStatusError{StatusCode: 500},
+			stdout:      `{"message":"oops"}`,
+			stderr:      "inst: oops (HTTP 500)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputFile := filepath.Join(t.TempDir(), "bodies.ndjson")
+			require.NoError(t, os.WriteFile(inputFile, []byte(tt.input), 0600))
+
+			stream, _, stdout, stderr := iostreams.Test()
+			opts := ApiOptions{
+				IO:               stream,
+				Config:           config.ConfigStubFactory,
+				RequestMethod:    "GET",
+				RequestPath:      "vdp/v1alpha/pipelines/trigger",
+				RequestInputFile: inputFile,
+				BatchInput:       true,
+				Concurrency:      tt.concurrency,
+				FailFast:         tt.failFast,
+				HTTPClient: func() (*http.Client, error) {
+					var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+						body, _ := io.ReadAll(req.Body)
+						resp := &http.Response{
+							StatusCode: 200,
+							Body:       io.NopCloser(bytes.NewBuffer(body)),
+							Header:     http.Header{"Content-Type": []string{"text/plain"}},
+							Request:    req,
+						}
+						if string(body) == "boom" {
+							resp.StatusCode = 500
+							resp.Body = io.NopCloser(bytes.NewBufferString(`{"message":"oops"}`))
+							resp.Header = http.Header{"Content-Type": []string{"application/json"}}
+						}
+						return resp, nil
+					}
+					return &http.Client{Transport: tr}, nil
+				},
+			}
+
+			err := apiRun(&opts)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.stdout, stdout.String())
+			assert.Equal(t, tt.stderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"io"
+)
+
+// errorExplanation expands a known server error code into a human explanation
+// and a likely remediation.
+type errorExplanation struct {
+	Explanation string
+	Remediation string
+}
+
+// knownErrorCodes is the registry of server error codes the CLI can explain.
+// Add new codes here as the API grows.
+var knownErrorCodes = map[string]errorExplanation{
+	"UNAUTHENTICATED": {
+		Explanation: "the request lacks valid authentication credentials",
+		Remediation: "run `inst auth login` to re-authenticate",
+	},
+	"PERMISSION_DENIED": {
+		Explanation: "the authenticated user is not allowed to perform this operation",
+		Remediation: "check that your account has access to this resource",
+	},
+	"NOT_FOUND": {
+		Explanation: "the requested resource does not exist",
+		Remediation: "verify the resource name and the endpoint path",
+	},
+	"ALREADY_EXISTS": {
+		Explanation: "a resource with the same identifier already exists",
+		Remediation: "pick a different identifier or delete the existing resource first",
+	},
+	"RESOURCE_EXHAUSTED": {
+		Explanation: "a quota or rate limit has been exceeded",
+		Remediation: "wait and retry, or reduce the request rate",
+	},
+	"FAILED_PRECONDITION": {
+		Explanation: "the resource is not in a state that allows this operation",
+		Remediation: "inspect the resource state with `inst api` before retrying",
+	},
+	"UNAVAILABLE": {
+		Explanation: "the service is temporarily unavailable",
+		Remediation: "retry later; check the instance health if the problem persists",
+	},
+}
+
+// explainErrorCodes prints explanations for any known error codes, silently
+// skipping codes that are not in the registry.
+func explainErrorCodes(w io.Writer, codes []string) {
+	for _, code := range codes {
+		if e, ok := knownErrorCodes[code]; ok {
+			fmt.Fprintf(w, "inst: %s: %s (%s)\n", code, e.Explanation, e.Remediation)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_explainErrorCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []string
+		want  string
+	}{
+		{
+			name:  "known code",
+			codes: []string{"NOT_FOUND"},
+			want:  "inst: NOT_FOUND: the requested resource does not exist (verify the resource name and the endpoint path)\n",
+		},
+		{
+			name:  "multiple codes",
+			codes: []string{"UNAUTHENTICATED", "RESOURCE_EXHAUSTED"},
+			want: "inst: UNAUTHENTICATED: the request lacks valid authentication credentials (run `inst auth login` to re-authenticate)\n" +
+				"inst: RESOURCE_EXHAUSTED: a quota or rate limit has been exceeded (wait and retry, or reduce the request rate)\n",
+		},
+		{
+			name:  "unknown code is skipped",
+			codes: []string{"TOTALLY_MADE_UP"},
+			want:  "",
+		},
+		{
+			name:  "no codes",
+			codes: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			explainErrorCodes(buf, tt.codes)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func Test_parseErrorResponse_codes(t *testing.T) {
+	body := bytes.NewBufferString(`{"message": "nope", "code": "NOT_FOUND"}`)
+	_, serverError, codes, err := parseErrorResponse(body, 404)
+	assert.NoError(t, err)
+	assert.Equal(t, "nope (HTTP 404)", serverError)
+	assert.Equal(t, []string{"NOT_FOUND"}, codes)
+
+	// numeric gRPC codes are ignored
+	body = bytes.NewBufferString(`{"message": "nope", "code": 5}`)
+	_, _, codes, err = parseErrorResponse(body, 404)
+	assert.NoError(t, err)
+	assert.Empty(t, codes)
+}
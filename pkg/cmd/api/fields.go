@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/instill-ai/cli/pkg/cmdutil"
+)
+
+// writeFieldsOutput renders a list response projected down to the fields
+// selected with `--fields`, either as JSON (the default), a table, or YAML.
+func writeFieldsOutput(resp *http.Response, opts *ApiOptions) error {
+	var body []byte
+	if resp.Body != nil {
+		defer resp.Body.Close()
+		var err error
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			return err
+		}
+	}
+
+	rows, items, err := projectFields(body, opts.Fields)
+	if err != nil {
+		return err
+	}
+	return cmdutil.RenderOutput(opts.IO, opts.OutputFormat, opts.Fields, rows, items)
+}
+
+// projectFields extracts the selected fields from every object of a list
+// response. Nested fields are addressed with dot notation, and a missing
+// field renders as empty instead of erroring.
+func projectFields(body []byte, fields []string) ([][]string, []map[string]interface{}, error) {
+	objects, err := listItems(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([][]string, 0, len(objects))
+	items := make([]map[string]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		item := map[string]interface{}{}
+		row := make([]string, 0, len(fields))
+		for _, field := range fields {
+			value := lookupField(obj, field)
+			item[field] = value
+			row = append(row, stringifyFieldValue(value))
+		}
+		rows = append(rows, row)
+		items = append(items, item)
+	}
+	return rows, items, nil
+}
+
+// listItems locates the array of objects in a JSON body: either the body is
+// the array itself, or it is the first array value (in key order) of a list
+// response object like {"pipelines": [...], "next_page_token": ""}.
+func listItems(body []byte) ([]map[string]interface{}, error) {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %v", err)
+	}
+
+	switch v := parsed.(type) {
+	case []interface{}:
+		return itemsAsObjects(v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if items, ok := v[key].([]interface{}); ok {
+				return itemsAsObjects(items)
+			}
+		}
+	}
+	return nil, fmt.Errorf("`--fields` requires a list response; the response contains no array of objects")
+}
+
+func itemsAsObjects(items []interface{}) ([]map[string]interface{}, error) {
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("`--fields` requires a list of objects; the list contains %T entries", item)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// lookupField resolves a dot-notation path within an object, returning nil
+// when any segment is missing or not an object.
+func lookupField(obj map[string]interface{}, path string) interface{} {
+	var value interface{} = obj
+	for _, segment := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if value, ok = m[segment]; !ok {
+			return nil
+		}
+	}
+	return value
+}
+
+func stringifyFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		out, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(out)
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_projectFields(t *testing.T) {
+	listBody := `{
+		"pipelines": [
+			{"id": "p1", "state": "ACTIVE", "owner": {"name": "mona"}},
+			{"id": "p2", "state": "INACTIVE", "owner": {}}
+		],
+		"next_page_token": ""
+	}`
+
+	tests := []struct {
+		name      string
+		body      string
+		fields    []string
+		wantRows  [][]string
+		wantItems []map[string]interface{}
+		wantErr   string
+	}{
+		{
+			name:     "list response object",
+			body:     listBody,
+			fields:   []string{"id", "state"},
+			wantRows: [][]string{{"p1", "ACTIVE"}, {"p2", "INACTIVE"}},
+			wantItems: []map[string]interface{}{
+				{"id": "p1", "state": "ACTIVE"},
+				{"id": "p2", "state": "INACTIVE"},
+			},
+		},
+		{
+			name:     "top-level array",
+			body:     `[{"id": "p1"}, {"id": "p2"}]`,
+			fields:   []string{"id"},
+			wantRows: [][]string{{"p1"}, {"p2"}},
+			wantItems: []map[string]interface{}{
+				{"id": "p1"},
+				{"id": "p2"},
+			},
+		},
+		{
+			name:     "nested and missing fields",
+			body:     listBody,
+			fields:   []string{"owner.name", "missing.path"},
+			wantRows: [][]string{{"mona", ""}, {"", ""}},
+			wantItems: []map[string]interface{}{
+				{"owner.name": "mona", "missing.path": nil},
+				{"owner.name": nil, "missing.path": nil},
+			},
+		},
+		{
+			name:     "non-string values are stringified",
+			body:     `[{"count": 3, "private": true}]`,
+			fields:   []string{"count", "private"},
+			wantRows: [][]string{{"3", "true"}},
+			wantItems: []map[string]interface{}{
+				{"count": float64(3), "private": true},
+			},
+		},
+		{
+			name:    "not JSON",
+			body:    `oops`,
+			fields:  []string{"id"},
+			wantErr: "response body is not valid JSON",
+		},
+		{
+			name:    "no array in the response",
+			body:    `{"id": "p1"}`,
+			fields:  []string{"id"},
+			wantErr: "`--fields` requires a list response; the response contains no array of objects",
+		},
+		{
+			name:    "list of scalars",
+			body:    `["a", "b"]`,
+			fields:  []string{"id"},
+			wantErr: "`--fields` requires a list of objects; the list contains string entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, items, err := projectFields([]byte(tt.body), tt.fields)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRows, rows)
+			assert.Equal(t, tt.wantItems, items)
+		})
+	}
+}
+
+func Test_apiRun_fields(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+
+	options := ApiOptions{
+		IO:           stream,
+		Config:       config.ConfigStubFactory,
+		RequestPath:  "vdp/v1alpha/pipelines",
+		Fields:       []string{"id", "owner.name"},
+		OutputFormat: "json",
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				body := `{"pipelines": [{"id": "p1", "owner": {"name": "mona"}}]}`
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(bytes.NewBufferString(body)),
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+	assert.JSONEq(t, `[{"id": "p1", "owner.name": "mona"}]`, stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
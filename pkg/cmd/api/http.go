@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,9 +14,9 @@ import (
 	"github.com/instill-ai/cli/internal/instance"
 )
 
-func httpRequest(client *http.Client, hostname string, method string, path string, params interface{}, headers []string) (*http.Response, error) {
+func httpRequest(ctx context.Context, client *http.Client, hostname string, method string, path string, params interface{}, headers []string) (*http.Response, error) {
 
-	requestURL := instance.GetProtocol(hostname) + strings.TrimPrefix(path, "/")
+	requestURL := resolveRequestURL(hostname, path)
 
 	var body io.Reader
 	var bodyIsJSON bool
@@ -58,7 +59,7 @@ func httpRequest(client *http.Client, hostname string, method string, path strin
 		return nil, fmt.Errorf("unrecognized parameters type: %v", params)
 	}
 
-	req, err := http.NewRequest(method, requestURL, body)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +88,37 @@ func httpRequest(client *http.Client, hostname string, method string, path strin
 	return client.Do(req)
 }
 
+// resolveRequestURL builds the request URL for a path on the host, passing an
+// already absolute URL (e.g. from a `--base-url` override) through untouched.
+func resolveRequestURL(hostname, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return instance.GetProtocol(hostname) + strings.TrimPrefix(path, "/")
+}
+
+// encodeForm serializes fields as an application/x-www-form-urlencoded body.
+func encodeForm(params map[string]interface{}) (string, error) {
+	form := url.Values{}
+	for key, value := range params {
+		switch v := value.(type) {
+		case string:
+			form.Add(key, v)
+		case []byte:
+			form.Add(key, string(v))
+		case nil:
+			form.Add(key, "")
+		case int:
+			form.Add(key, fmt.Sprintf("%d", v))
+		case bool:
+			form.Add(key, fmt.Sprintf("%v", v))
+		default:
+			return "", fmt.Errorf("cannot serialize %q as a form value", key)
+		}
+	}
+	return form.Encode(), nil
+}
+
 func addQuery(path string, params map[string]interface{}) string {
 	if len(params) == 0 {
 		return path
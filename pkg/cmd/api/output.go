@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/instill-ai/cli/pkg/cmdutil"
+)
+
+// writeResponseToFile streams the response body into opts.OutputFile. The
+// body is written to a temporary file and renamed into place, so a failed or
+// interrupted download never creates or clobbers the destination. On a non-2xx
+// response the error body is printed to stderr and no file is touched.
+func writeResponseToFile(resp *http.Response, opts *ApiOptions, headersOutputStream io.Writer) error {
+	if opts.ShowResponseHeaders {
+		fmt.Fprintln(headersOutputStream, resp.Proto, resp.Status)
+		printHeaders(headersOutputStream, resp.Header, opts.IO.ColorEnabled())
+		fmt.Fprint(headersOutputStream, "\r\n")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 299 {
+		var responseBody io.Reader = resp.Body
+		serverError := fmt.Sprintf("HTTP %d", resp.StatusCode)
+
+		isJSON, responseBody := isJSONResponse(resp, responseBody)
+		if isJSON && resp.StatusCode >= 400 {
+			if body, msg, _, err := parseErrorResponse(responseBody, resp.StatusCode); err == nil && msg != "" {
+				responseBody, serverError = body, msg
+			}
+		}
+
+		if _, err := io.Copy(opts.IO.ErrOut, responseBody); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "inst: %s\n", serverError)
+		return cmdutil.HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	opts.IO.StartProgressIndicator()
+	defer opts.IO.StopProgressIndicator()
+
+	f, err := os.CreateTemp(filepath.Dir(opts.OutputFile), ".inst-download-*")
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), opts.OutputFile); err != nil {
+		return err
+	}
+
+	opts.IO.StopProgressIndicator()
+	if opts.IO.IsStdoutTTY() && !opts.IO.IsQuiet() {
+		fmt.Fprintf(opts.IO.ErrOut, "%s wrote %d bytes to %s\n", opts.IO.ColorScheme().SuccessIcon(), written, opts.OutputFile)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func outputFileOptions(io *iostreams.IOStreams, outputFile string, resp *http.Response) *ApiOptions {
+	return &ApiOptions{
+		IO:            io,
+		RequestPath:   "vdp/v1alpha/pipelines/my-pipeline",
+		RequestMethod: "GET",
+		OutputFile:    outputFile,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				resp.Request = req
+				return resp, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+}
+
+func Test_apiRun_outputFile(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+	outputFile := filepath.Join(t.TempDir(), "pipeline.json")
+	options := outputFileOptions(stream, outputFile, &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"id":"my-pipeline"}`)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	})
+
+	require.NoError(t, apiRun(options))
+
+	contents, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"my-pipeline"}`, string(contents))
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "", stderr.String())
+
+	leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(outputFile), ".inst-download-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func Test_apiRun_outputFile_errorResponse(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+	outputFile := filepath.Join(t.TempDir(), "pipeline.json")
+	options := outputFileOptions(stream, outputFile, &http.Response{
+		StatusCode: 404,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"message":"the pipeline is not found"}`)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	})
+
+	err := apiRun(options)
+	assert.Equal(t, cmdutil.HTTPStatusError{StatusCode: 404}, err)
+
+	// the destination must not be created on a failed request
+	_, statErr := os.Stat(outputFile)
+	assert.True(t, os.IsNotExist(statErr))
+	assert.Equal(t, "", stdout.String())
+	assert.Equal(t, "{\"message\":\"the pipeline is not found\"}inst: the pipeline is not found (HTTP 404)\n", stderr.String())
+}
+
+func Test_apiRun_outputFile_showHeaders(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+	outputFile := filepath.Join(t.TempDir(), "pipeline.json")
+	options := outputFileOptions(stream, outputFile, &http.Response{
+		Proto:      "HTTP/1.1",
+		Status:     "200 OK",
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	})
+	options.ShowResponseHeaders = true
+
+	require.NoError(t, apiRun(options))
+
+	// with `--include`, the headers go to stderr while the body goes to the file
+	assert.Equal(t, "", stdout.String())
+	assert.Contains(t, stderr.String(), "HTTP/1.1 200 OK")
+	assert.Contains(t, stderr.String(), "Content-Type: application/json")
+
+	contents, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(contents))
+}
+
+func Test_NewCmdApi_remoteName(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantOutputFile string
+		wantErr        string
+	}{
+		{
+			name:           "derives filename from the endpoint path",
+			args:           []string{"vdp/v1alpha/pipelines/my-pipeline", "-O"},
+			wantOutputFile: "my-pipeline",
+		},
+		{
+			name:           "strips the query string",
+			args:           []string{"vdp/v1alpha/pipelines/my-pipeline?view=FULL", "-O"},
+			wantOutputFile: "my-pipeline",
+		},
+		{
+			name:    "conflicts with --output-file",
+			args:    []string{"vdp/v1alpha/pipelines/my-pipeline", "-O", "--output-file", "out.json"},
+			wantErr: "`-O` cannot be combined with `--output-file`",
+		},
+		{
+			name:    "conflicts with --silent",
+			args:    []string{"vdp/v1alpha/pipelines/my-pipeline", "--output-file", "out.json", "--silent"},
+			wantErr: "`--output-file` cannot be combined with `--silent`, `--jq`, `--fields`, or `--template`",
+		},
+		{
+			name:    "conflicts with --paginate",
+			args:    []string{"vdp/v1alpha/pipelines", "--output-file", "out.json", "--paginate"},
+			wantErr: "`--output-file` cannot be combined with `--paginate`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				IOStreams: iostreams.System(),
+				Config:    config.ConfigStubFactory,
+			}
+
+			var opts *ApiOptions
+			cmd := NewCmdAPI(f, func(o *ApiOptions) error {
+				opts = o
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err := cmd.ExecuteC()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutputFile, opts.OutputFile)
+		})
+	}
+}
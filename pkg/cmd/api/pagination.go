@@ -0,0 +1,227 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// findNextPageToken extracts the pagination cursor from a list response, or
+// returns "" when there are no further pages (or the body is not JSON).
+func findNextPageToken(body []byte) string {
+	var response struct {
+		NextPageToken string `json:"next_page_token"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ""
+	}
+	return response.NextPageToken
+}
+
+// findGraphQLCursor extracts hasNextPage and endCursor from the pageInfo of
+// the paginated connection in a GraphQL response. With an empty path the
+// connection is located by searching the response for an object carrying a
+// pageInfo key.
+func findGraphQLCursor(body []byte, path string) (bool, string, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false, "", fmt.Errorf("response body is not valid JSON: %v", err)
+	}
+
+	conn, err := graphqlConnection(payload, path)
+	if err != nil {
+		return false, "", err
+	}
+
+	pageInfo, ok := conn["pageInfo"].(map[string]interface{})
+	if !ok {
+		return false, "", fmt.Errorf("the object at %q has no pageInfo", path)
+	}
+	hasNextPage, _ := pageInfo["hasNextPage"].(bool)
+	endCursor, _ := pageInfo["endCursor"].(string)
+	return hasNextPage, endCursor, nil
+}
+
+// graphqlConnection resolves the paginated connection object, either at an
+// explicit dot-separated path or by a depth-first search for a pageInfo key.
+func graphqlConnection(payload map[string]interface{}, path string) (map[string]interface{}, error) {
+	if path == "" {
+		if conn := searchPageInfo(payload); conn != nil {
+			return conn, nil
+		}
+		return nil, fmt.Errorf("could not find a pageInfo object in the response; use `--graphql-paginate-path` to point at the connection")
+	}
+
+	current := payload
+	for _, segment := range strings.Split(path, ".") {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("no object found at %q in the response", path)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func searchPageInfo(obj map[string]interface{}) map[string]interface{} {
+	if _, ok := obj["pageInfo"]; ok {
+		return obj
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if nested, ok := obj[key].(map[string]interface{}); ok {
+			if conn := searchPageInfo(nested); conn != nil {
+				return conn
+			}
+		}
+	}
+	return nil
+}
+
+// truncateGraphQLItems caps the nodes and edges arrays of the paginated
+// connection at max entries, mirroring what truncateItems does for REST list
+// responses. It returns the possibly rewritten body along with the number of
+// items counted toward the limit.
+func truncateGraphQLItems(body []byte, path string, max int) ([]byte, int) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body, 0
+	}
+	conn, err := graphqlConnection(payload, path)
+	if err != nil {
+		return body, 0
+	}
+
+	kept := 0
+	truncated := false
+	for _, key := range []string{"nodes", "edges"} {
+		items, ok := conn[key].([]interface{})
+		if !ok {
+			continue
+		}
+		count := len(items)
+		if count > max {
+			conn[key] = items[:max]
+			count = max
+			truncated = true
+		}
+		if count > kept {
+			kept = count
+		}
+	}
+
+	if !truncated {
+		return body, kept
+	}
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return body, kept
+	}
+	return out, kept
+}
+
+// setGraphQLCursor injects the end cursor as the `after` variable of the next
+// request. A `variables` field supplied as a JSON string is parsed into an
+// object first.
+func setGraphQLCursor(params map[string]interface{}, cursor string) error {
+	variables := map[string]interface{}{}
+	switch v := params["variables"].(type) {
+	case nil:
+	case map[string]interface{}:
+		variables = v
+	case string:
+		if err := json.Unmarshal([]byte(v), &variables); err != nil {
+			return fmt.Errorf("could not parse the `variables` field as a JSON object: %v", err)
+		}
+	default:
+		return fmt.Errorf("cannot set the `after` cursor: the `variables` field is not an object")
+	}
+	variables["after"] = cursor
+	params["variables"] = variables
+	return nil
+}
+
+// writeNDJSONOutput streams every element of the top-level JSON arrays of a
+// list response as one compact JSON object per line. `--slurp` invokes it once
+// per page, so items reach downstream tools as pages arrive and only a single
+// page is ever held in memory.
+func writeNDJSONOutput(resp *http.Response, opts *ApiOptions) error {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("response body is not a JSON object: %v", err)
+	}
+
+	keys := make([]string, 0, len(response))
+	for key := range response {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		items, ok := response[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			line, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(opts.IO.Out, "%s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// truncateItems caps every top-level JSON array in a list response at max
+// entries, so `--limit` can cut off a full page mid-way. It returns the
+// possibly rewritten body along with the number of items counted toward the
+// limit; bodies that are not JSON objects pass through unchanged.
+func truncateItems(body []byte, max int) ([]byte, int) {
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body, 0
+	}
+
+	kept := 0
+	truncated := false
+	for key, value := range response {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		count := len(items)
+		if count > max {
+			response[key] = items[:max]
+			count = max
+			truncated = true
+		}
+		if count > kept {
+			kept = count
+		}
+	}
+
+	if !truncated {
+		return body, kept
+	}
+	out, err := json.Marshal(response)
+	if err != nil {
+		return body, kept
+	}
+	return out, kept
+}
@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_apiRun_paginate(t *testing.T) {
+	pages := []string{
+		`{"pipelines":[{"id":"a"},{"id":"b"}],"next_page_token":"page2"}`,
+		`{"pipelines":[{"id":"c"}],"next_page_token":""}`,
+	}
+
+	var requests []*http.Request
+	stream, _, stdout, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		Paginate:      true,
+		PerPage:       2,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req)
+				require.LessOrEqual(t, len(requests), len(pages))
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(pages[len(requests)-1])),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "2", requests[0].URL.Query().Get("page_size"))
+	assert.Equal(t, "", requests[0].URL.Query().Get("page_token"))
+	assert.Equal(t, "2", requests[1].URL.Query().Get("page_size"))
+	assert.Equal(t, "page2", requests[1].URL.Query().Get("page_token"))
+
+	assert.Equal(t, pages[0]+pages[1], stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
+
+func Test_apiRun_paginateSlurp(t *testing.T) {
+	pages := []string{
+		`{"pipelines":[{"id":"a"},{"id":"b"}],"next_page_token":"page2"}`,
+		`{"pipelines":[{"id":"c"}],"next_page_token":""}`,
+	}
+
+	var requestCount int
+	stream, _, stdout, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		Paginate:      true,
+		Slurp:         true,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				requestCount++
+				require.LessOrEqual(t, requestCount, len(pages))
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(pages[requestCount-1])),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+
+	assert.Equal(t, 2, requestCount)
+	assert.Equal(t, "{\"id\":\"a\"}\n{\"id\":\"b\"}\n{\"id\":\"c\"}\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
+
+func Test_apiRun_paginateLimit(t *testing.T) {
+	pages := []string{
+		`{"pipelines":[{"id":"a"},{"id":"b"}],"next_page_token":"page2"}`,
+		`{"pipelines":[{"id":"c"},{"id":"d"}],"next_page_token":"page3"}`,
+	}
+
+	var requestCount int
+	stream, _, stdout, _ := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		Paginate:      true,
+		Limit:         3,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				requestCount++
+				require.LessOrEqual(t, requestCount, len(pages))
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(pages[requestCount-1])),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+
+	// the second page is fetched whole but truncated to honor the limit, and
+	// no third request is made even though a continuation token was returned
+	assert.Equal(t, 2, requestCount)
+	expected := pages[0] + `{"next_page_token":"page3","pipelines":[{"id":"c"}]}`
+	assert.Equal(t, expected, stdout.String())
+}
+
+func Test_apiRun_graphqlPaginate(t *testing.T) {
+	pages := []string{
+		`{"data":{"things":{"nodes":[{"id":"a"},{"id":"b"}],"pageInfo":{"hasNextPage":true,"endCursor":"cursor1"}}}}`,
+		`{"data":{"things":{"nodes":[{"id":"c"}],"pageInfo":{"hasNextPage":false,"endCursor":""}}}}`,
+	}
+
+	var bodies []string
+	stream, _, stdout, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:              stream,
+		RequestPath:     "graphql",
+		RequestMethod:   "POST",
+		RawFields:       []string{"query=QUERY"},
+		GraphQLPaginate: true,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				bodies = append(bodies, string(body))
+				require.LessOrEqual(t, len(bodies), len(pages))
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(pages[len(bodies)-1])),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, `{"query":"QUERY"}`, bodies[0])
+	assert.Equal(t, `{"query":"QUERY","variables":{"after":"cursor1"}}`, bodies[1])
+
+	assert.Equal(t, pages[0]+pages[1], stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
+
+func Test_writeNDJSONOutput_notJSON(t *testing.T) {
+	stream, _, _, _ := iostreams.Test()
+	resp := &http.Response{Body: io.NopCloser(bytes.NewBufferString("plain text"))}
+	err := writeNDJSONOutput(resp, &ApiOptions{IO: stream})
+	assert.ErrorContains(t, err, "response body is not a JSON object")
+}
+
+func Test_findGraphQLCursor(t *testing.T) {
+	body := `{"data":{"things":{"nodes":[],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`
+
+	t.Run("auto-detects the connection", func(t *testing.T) {
+		hasNextPage, endCursor, err := findGraphQLCursor([]byte(body), "")
+		require.NoError(t, err)
+		assert.True(t, hasNextPage)
+		assert.Equal(t, "abc", endCursor)
+	})
+
+	t.Run("explicit path", func(t *testing.T) {
+		hasNextPage, endCursor, err := findGraphQLCursor([]byte(body), "data.things")
+		require.NoError(t, err)
+		assert.True(t, hasNextPage)
+		assert.Equal(t, "abc", endCursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		last := `{"data":{"things":{"pageInfo":{"hasNextPage":false,"endCursor":""}}}}`
+		hasNextPage, _, err := findGraphQLCursor([]byte(last), "")
+		require.NoError(t, err)
+		assert.False(t, hasNextPage)
+	})
+
+	t.Run("no pageInfo anywhere", func(t *testing.T) {
+		_, _, err := findGraphQLCursor([]byte(`{"data":{"things":{"nodes":[]}}}`), "")
+		assert.EqualError(t, err, "could not find a pageInfo object in the response; use `--graphql-paginate-path` to point at the connection")
+	})
+
+	t.Run("wrong path", func(t *testing.T) {
+		_, _, err := findGraphQLCursor([]byte(body), "data.missing")
+		assert.EqualError(t, err, `no object found at "data.missing" in the response`)
+	})
+}
+
+func Test_setGraphQLCursor(t *testing.T) {
+	t.Run("no variables yet", func(t *testing.T) {
+		params := map[string]interface{}{"query": "QUERY"}
+		require.NoError(t, setGraphQLCursor(params, "abc"))
+		assert.Equal(t, map[string]interface{}{"after": "abc"}, params["variables"])
+	})
+
+	t.Run("variables passed as a JSON string", func(t *testing.T) {
+		params := map[string]interface{}{"query": "QUERY", "variables": `{"first":10}`}
+		require.NoError(t, setGraphQLCursor(params, "abc"))
+		assert.Equal(t, map[string]interface{}{"first": float64(10), "after": "abc"}, params["variables"])
+	})
+
+	t.Run("variables is not an object", func(t *testing.T) {
+		params := map[string]interface{}{"query": "QUERY", "variables": 42}
+		assert.Error(t, setGraphQLCursor(params, "abc"))
+	})
+}
+
+func Test_truncateGraphQLItems(t *testing.T) {
+	body := `{"data":{"things":{"nodes":[{"id":"a"},{"id":"b"},{"id":"c"}],"pageInfo":{"hasNextPage":false}}}}`
+
+	t.Run("over the limit", func(t *testing.T) {
+		out, kept := truncateGraphQLItems([]byte(body), "data.things", 2)
+		assert.Equal(t, 2, kept)
+		assert.Contains(t, string(out), `"nodes":[{"id":"a"},{"id":"b"}]`)
+	})
+
+	t.Run("under the limit", func(t *testing.T) {
+		out, kept := truncateGraphQLItems([]byte(body), "", 5)
+		assert.Equal(t, 3, kept)
+		assert.Equal(t, body, string(out))
+	})
+}
+
+func Test_findNextPageToken(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "token present",
+			body: `{"pipelines":[],"next_page_token":"abc"}`,
+			want: "abc",
+		},
+		{
+			name: "empty token",
+			body: `{"pipelines":[],"next_page_token":""}`,
+			want: "",
+		},
+		{
+			name: "no token field",
+			body: `{"pipelines":[]}`,
+			want: "",
+		},
+		{
+			name: "not JSON",
+			body: `plain text`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findNextPageToken([]byte(tt.body)))
+		})
+	}
+}
+
+func Test_truncateItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		max      int
+		wantBody string
+		wantKept int
+	}{
+		{
+			name:     "under the limit",
+			body:     `{"pipelines":[{"id":"a"}],"total_size":1}`,
+			max:      5,
+			wantBody: `{"pipelines":[{"id":"a"}],"total_size":1}`,
+			wantKept: 1,
+		},
+		{
+			name:     "over the limit",
+			body:     `{"pipelines":[{"id":"a"},{"id":"b"},{"id":"c"}]}`,
+			max:      2,
+			wantBody: `{"pipelines":[{"id":"a"},{"id":"b"}]}`,
+			wantKept: 2,
+		},
+		{
+			name:     "not a JSON object",
+			body:     `plain text`,
+			max:      2,
+			wantBody: `plain text`,
+			wantKept: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, kept := truncateItems([]byte(tt.body), tt.max)
+			assert.Equal(t, tt.wantBody, string(body))
+			assert.Equal(t, tt.wantKept, kept)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// applyQueryParams merges `--query` parameters into the endpoint's query
+// string, URL-encoding every value. A key already present in the path keeps
+// its value and gains the new one as a repeated key; with replace, the path's
+// values for that key are dropped first. A value of "@file" is read from a
+// file ("@-" from standard input), with one trailing newline trimmed.
+func applyQueryParams(path string, params []string, replace bool, stdin io.ReadCloser) (string, error) {
+	base, rawQuery, _ := strings.Cut(path, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid query string in the endpoint: %w", err)
+	}
+
+	// clear a replaced key only once, so repeated `--query` values for the
+	// same key still accumulate into an array
+	cleared := map[string]bool{}
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || key == "" {
+			return "", fmt.Errorf("expected the \"key=value\" format for `--query`, got %q", param)
+		}
+		if strings.HasPrefix(value, "@") {
+			if value, err = readQueryValueFile(value[1:], stdin); err != nil {
+				return "", err
+			}
+		}
+		if replace && !cleared[key] {
+			query.Del(key)
+			cleared[key] = true
+		}
+		query.Add(key, value)
+	}
+
+	if len(query) == 0 {
+		return base, nil
+	}
+	return base + "?" + query.Encode(), nil
+}
+
+// readQueryValueFile loads a `--query` value given as "@file", trimming a
+// single trailing newline so `--query key=@file` works with line-oriented
+// tools.
+func readQueryValueFile(fn string, stdin io.ReadCloser) (string, error) {
+	file, _, err := openUserFile(fn, stdin)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
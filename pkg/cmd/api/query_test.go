@@ -0,0 +1,101 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyQueryParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		params  []string
+		replace bool
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "appends to a bare path",
+			path:   "models",
+			params: []string{"visibility=public"},
+			want:   "models?visibility=public",
+		},
+		{
+			name:   "special characters are URL-encoded",
+			path:   "models",
+			params: []string{"filter=name = \"a&b\"", "tag=café"},
+			want:   "models?filter=name+%3D+%22a%26b%22&tag=caf%C3%A9",
+		},
+		{
+			name:   "repeated keys become arrays",
+			path:   "models",
+			params: []string{"id=a", "id=b"},
+			want:   "models?id=a&id=b",
+		},
+		{
+			name:   "merges with a query already in the path",
+			path:   "models?visibility=public",
+			params: []string{"page_size=10"},
+			want:   "models?page_size=10&visibility=public",
+		},
+		{
+			name:   "an existing key gains the new value",
+			path:   "models?id=a",
+			params: []string{"id=b"},
+			want:   "models?id=a&id=b",
+		},
+		{
+			name:    "--query-replace drops the path's value",
+			path:    "models?id=a&visibility=public",
+			params:  []string{"id=b", "id=c"},
+			replace: true,
+			want:    "models?id=b&id=c&visibility=public",
+		},
+		{
+			name:   "empty value is kept",
+			path:   "models",
+			params: []string{"flag="},
+			want:   "models?flag=",
+		},
+		{
+			name:    "missing equals sign",
+			path:    "models",
+			params:  []string{"oops"},
+			wantErr: `expected the "key=value" format for ` + "`--query`" + `, got "oops"`,
+		},
+		{
+			name:    "missing key",
+			path:    "models",
+			params:  []string{"=value"},
+			wantErr: `expected the "key=value" format for ` + "`--query`" + `, got "=value"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyQueryParams(tt.path, tt.params, tt.replace, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_applyQueryParams_fromFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "value")
+	require.NoError(t, os.WriteFile(filename, []byte("a value\n"), 0600))
+
+	got, err := applyQueryParams("models", []string{"filter=@" + filename}, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "models?filter=a+value", got)
+
+	_, err = applyQueryParams("models", []string{"filter=@" + filepath.Join(t.TempDir(), "missing")}, false, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// parseResolveOverrides parses `--resolve host:port:addr` specs, in the style
+// of curl's flag of the same name, into a map of "host:port" to "addr:port"
+// dial overrides. The address may be an IPv6 literal, which keeps its colons.
+func parseResolveOverrides(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	overrides := map[string]string{}
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("expected the \"host:port:addr\" format, got %q", spec)
+		}
+		host, port, addr := parts[0], parts[1], parts[2]
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port %q in %q", port, spec)
+		}
+		overrides[net.JoinHostPort(host, port)] = net.JoinHostPort(addr, port)
+	}
+	return overrides, nil
+}
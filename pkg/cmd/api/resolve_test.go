@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseResolveOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "no specs",
+			specs: nil,
+			want:  nil,
+		},
+		{
+			name:  "single override",
+			specs: []string{"api.instill.tech:443:127.0.0.1"},
+			want:  map[string]string{"api.instill.tech:443": "127.0.0.1:443"},
+		},
+		{
+			name:  "multiple overrides",
+			specs: []string{"one.example.com:443:10.0.0.1", "two.example.com:80:10.0.0.2"},
+			want: map[string]string{
+				"one.example.com:443": "10.0.0.1:443",
+				"two.example.com:80":  "10.0.0.2:80",
+			},
+		},
+		{
+			name:  "IPv6 address keeps its colons",
+			specs: []string{"api.instill.tech:443:::1"},
+			want:  map[string]string{"api.instill.tech:443": "[::1]:443"},
+		},
+		{
+			name:    "missing address",
+			specs:   []string{"api.instill.tech:443"},
+			wantErr: `expected the "host:port:addr" format, got "api.instill.tech:443"`,
+		},
+		{
+			name:    "invalid port",
+			specs:   []string{"api.instill.tech:https:127.0.0.1"},
+			wantErr: `invalid port "https" in "api.instill.tech:https:127.0.0.1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResolveOverrides(tt.specs)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// resourceShorthands maps friendly resource names to their full endpoint
+// paths, so `inst api pipelines` works without memorizing the versioned
+// prefixes. Extend the table to teach the command new shorthands.
+var resourceShorthands = map[string]string{
+	"pipelines": "vdp/v1alpha/pipelines",
+	"models":    "model/v1alpha/models",
+	"me":        "base/v1alpha/users/me",
+}
+
+// expandShorthand resolves a known resource shorthand to its endpoint path.
+// Paths starting with "/" and anything not present in the table are passed
+// through unchanged, so raw endpoints keep working.
+func expandShorthand(endpoint string) string {
+	if strings.HasPrefix(endpoint, "/") {
+		return endpoint
+	}
+	name, query, hasQuery := strings.Cut(endpoint, "?")
+	full, ok := resourceShorthands[name]
+	if !ok {
+		return endpoint
+	}
+	if hasQuery {
+		return full + "?" + query
+	}
+	return full
+}
+
+// shorthandCompletions returns shell-completion candidates for the endpoint
+// argument: every known shorthand whose name matches the typed prefix, with
+// the full endpoint path as its description.
+func shorthandCompletions(toComplete string) []string {
+	names := make([]string, 0, len(resourceShorthands))
+	for name := range resourceShorthands {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	candidates := make([]string, 0, len(names))
+	for _, name := range names {
+		candidates = append(candidates, name+"\t"+resourceShorthands[name])
+	}
+	return candidates
+}
+
+// shorthandList renders the shorthand table for help output, one
+// "name -> path" line per entry in alphabetical order.
+func shorthandList() string {
+	names := make([]string, 0, len(resourceShorthands))
+	for name := range resourceShorthands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString("- ")
+		sb.WriteString(name)
+		sb.WriteString(" -> ")
+		sb.WriteString(resourceShorthands[name])
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_expandShorthand(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+	}{
+		{
+			name:     "known shorthand",
+			endpoint: "pipelines",
+			want:     "vdp/v1alpha/pipelines",
+		},
+		{
+			name:     "known shorthand with query",
+			endpoint: "models?visibility=public",
+			want:     "model/v1alpha/models?visibility=public",
+		},
+		{
+			name:     "unknown shorthand is a literal path",
+			endpoint: "deployments",
+			want:     "deployments",
+		},
+		{
+			name:     "raw path is never expanded",
+			endpoint: "/pipelines",
+			want:     "/pipelines",
+		},
+		{
+			name:     "full endpoint path",
+			endpoint: "vdp/v1alpha/pipelines/my-pipeline",
+			want:     "vdp/v1alpha/pipelines/my-pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandShorthand(tt.endpoint))
+		})
+	}
+}
+
+func Test_shorthandCompletions(t *testing.T) {
+	t.Run("all shorthands with their paths", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"me\tbase/v1alpha/users/me",
+			"models\tmodel/v1alpha/models",
+			"pipelines\tvdp/v1alpha/pipelines",
+		}, shorthandCompletions(""))
+	})
+
+	t.Run("filtered by the typed prefix", func(t *testing.T) {
+		assert.Equal(t, []string{"pipelines\tvdp/v1alpha/pipelines"}, shorthandCompletions("pip"))
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		assert.Empty(t, shorthandCompletions("zzz"))
+	})
+}
+
+func Test_shorthandList(t *testing.T) {
+	list := shorthandList()
+	assert.Contains(t, list, "- pipelines -> vdp/v1alpha/pipelines")
+	assert.Contains(t, list, "- models -> model/v1alpha/models")
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseByteSize parses a human-readable size like "10MB", "500KB", or a plain
+// number of bytes. Suffixes use 1024 multipliers and are case-insensitive.
+func parseByteSize(value string) (int64, error) {
+	size := strings.TrimSpace(value)
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"KB", 1024},
+		{"MB", 1024 * 1024},
+		{"GB", 1024 * 1024 * 1024},
+		{"B", 1},
+	} {
+		if strings.HasSuffix(strings.ToUpper(size), unit.suffix) {
+			multiplier = unit.multiplier
+			size = strings.TrimSpace(size[:len(size)-len(unit.suffix)])
+			break
+		}
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a number with an optional KB, MB, or GB suffix")
+	}
+	return n * multiplier, nil
+}
+
+// formatByteSize renders a byte count the way a user would write it, using
+// the largest suffix that divides it evenly.
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1024*1024*1024 && n%(1024*1024*1024) == 0:
+		return fmt.Sprintf("%dGB", n/(1024*1024*1024))
+	case n >= 1024*1024 && n%(1024*1024) == 0:
+		return fmt.Sprintf("%dMB", n/(1024*1024))
+	case n >= 1024 && n%1024 == 0:
+		return fmt.Sprintf("%dKB", n/1024)
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
+
+// limitedResponseBody guards against runaway response bodies. Beyond the
+// limit a read fails with a clear error, or, when the body is streaming to a
+// file anyway, prints a one-time warning and keeps going.
+type limitedResponseBody struct {
+	rc        io.ReadCloser
+	remaining int64
+	limit     int64
+	warnOnly  bool
+	warned    bool
+	errOut    io.Writer
+}
+
+func newLimitedResponseBody(rc io.ReadCloser, limit int64, warnOnly bool, errOut io.Writer) io.ReadCloser {
+	return &limitedResponseBody{rc: rc, remaining: limit, limit: limit, warnOnly: warnOnly, errOut: errOut}
+}
+
+func (b *limitedResponseBody) Read(p []byte) (int, error) {
+	n, err := b.rc.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		if !b.warnOnly {
+			return n, fmt.Errorf("response body exceeds the maximum size of %s; raise `--max-response-size` or stream it with `--output-file`", formatByteSize(b.limit))
+		}
+		if !b.warned {
+			b.warned = true
+			fmt.Fprintf(b.errOut, "warning: response body exceeds %s\n", formatByteSize(b.limit))
+		}
+	}
+	return n, err
+}
+
+func (b *limitedResponseBody) Close() error {
+	return b.rc.Close()
+}
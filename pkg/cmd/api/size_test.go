@@ -0,0 +1,71 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseByteSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr string
+	}{
+		{input: "1024", want: 1024},
+		{input: "500KB", want: 500 * 1024},
+		{input: "10MB", want: 10 * 1024 * 1024},
+		{input: "2gb", want: 2 * 1024 * 1024 * 1024},
+		{input: "128B", want: 128},
+		{input: "10 MB", want: 10 * 1024 * 1024},
+		{input: "ten", wantErr: "expected a number with an optional KB, MB, or GB suffix"},
+		{input: "-1MB", wantErr: "expected a number with an optional KB, MB, or GB suffix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseByteSize(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formatByteSize(t *testing.T) {
+	assert.Equal(t, "10MB", formatByteSize(10*1024*1024))
+	assert.Equal(t, "500KB", formatByteSize(500*1024))
+	assert.Equal(t, "2GB", formatByteSize(2*1024*1024*1024))
+	assert.Equal(t, "1000 bytes", formatByteSize(1000))
+}
+
+func Test_limitedResponseBody(t *testing.T) {
+	t.Run("small bodies pass through", func(t *testing.T) {
+		body := newLimitedResponseBody(io.NopCloser(strings.NewReader("hello")), 10, false, io.Discard)
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+
+	t.Run("exceeding the limit fails the read", func(t *testing.T) {
+		body := newLimitedResponseBody(io.NopCloser(strings.NewReader(strings.Repeat("x", 2048))), 1024, false, io.Discard)
+		_, err := io.ReadAll(body)
+		assert.EqualError(t, err, "response body exceeds the maximum size of 1KB; raise `--max-response-size` or stream it with `--output-file`")
+	})
+
+	t.Run("streaming to disk only warns, once", func(t *testing.T) {
+		errOut := &bytes.Buffer{}
+		body := newLimitedResponseBody(io.NopCloser(strings.NewReader(strings.Repeat("x", 4096))), 1024, true, errOut)
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Len(t, data, 4096)
+		assert.Equal(t, "warning: response body exceeds 1KB\n", errOut.String())
+	})
+}
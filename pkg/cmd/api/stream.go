@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// streamEvents reads a text/event-stream response and prints each event's
+// data the moment it arrives, one event per line, so live monitoring works
+// through pipes without buffering the whole response. It returns when the
+// server closes the stream; a cancelled context (Ctrl-C or `--max-time`) is
+// the normal way to leave a live stream and ends it cleanly.
+func streamEvents(ctx context.Context, resp *http.Response, opts *ApiOptions) error {
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	// an event's data may span several "data:" lines; a blank line ends the
+	// event, and comment lines starting with ":" keep the connection alive
+	var data []string
+	flush := func() {
+		if len(data) == 0 {
+			return
+		}
+		fmt.Fprintln(opts.IO.Out, strings.Join(data, "\n"))
+		data = data[:0]
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, ":"):
+			// keep-alive comment
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		default:
+			// other fields like "event:" or "id:" do not carry a payload
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
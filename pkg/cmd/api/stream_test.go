@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_streamEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantOut string
+	}{
+		{
+			name:    "one event per line",
+			body:    "data: {\"status\":\"running\"}\n\ndata: {\"status\":\"done\"}\n\n",
+			wantOut: "{\"status\":\"running\"}\n{\"status\":\"done\"}\n",
+		},
+		{
+			name:    "multi-line data joins within the event",
+			body:    "data: first\ndata: second\n\n",
+			wantOut: "first\nsecond\n",
+		},
+		{
+			name:    "comments and non-data fields are skipped",
+			body:    ": keep-alive\nevent: update\nid: 42\ndata: payload\n\n",
+			wantOut: "payload\n",
+		},
+		{
+			name:    "final event without a trailing blank line",
+			body:    "data: last",
+			wantOut: "last\n",
+		},
+		{
+			name:    "empty stream prints nothing",
+			body:    "",
+			wantOut: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, _, stdout, _ := iostreams.Test()
+			resp := &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			err := streamEvents(context.Background(), resp, &ApiOptions{IO: stream})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, stdout.String())
+		})
+	}
+}
+
+// errReader yields some data and then fails, like a connection torn down
+// mid-stream.
+type errReader struct {
+	data io.Reader
+	err  error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, r.err
+	}
+	return n, err
+}
+
+func Test_streamEvents_interrupted(t *testing.T) {
+	t.Run("a cancelled context ends the stream cleanly", func(t *testing.T) {
+		stream, _, stdout, _ := iostreams.Test()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		resp := &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(&errReader{data: strings.NewReader("data: partial\n\n"), err: ctx.Err()}),
+		}
+
+		err := streamEvents(ctx, resp, &ApiOptions{IO: stream})
+		require.NoError(t, err)
+		assert.Equal(t, "partial\n", stdout.String())
+	})
+
+	t.Run("other read errors surface", func(t *testing.T) {
+		stream, _, _, _ := iostreams.Test()
+		readErr := errors.New("connection reset")
+		resp := &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(&errReader{data: strings.NewReader(""), err: readErr}),
+		}
+
+		err := streamEvents(context.Background(), resp, &ApiOptions{IO: stream})
+		assert.Equal(t, readErr, err)
+	})
+}
+
+func Test_apiRun_stream(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+
+	var gotReq *http.Request
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines/my-pipeline/events",
+		RequestMethod: "GET",
+		Stream:        true,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				gotReq = req
+				body := "data: {\"status\":\"running\"}\n\n: ping\ndata: {\"status\":\"done\"}\n\n"
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	err := apiRun(&options)
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream", gotReq.Header.Get("Accept"))
+	assert.Equal(t, "{\"status\":\"running\"}\n{\"status\":\"done\"}\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
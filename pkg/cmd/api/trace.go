@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// requestTrace collects per-phase timings for a single HTTP request. The
+// hooks fire from transport goroutines, so every write is guarded by the
+// mutex; phases that never happen (e.g. TLS over a unix socket) stay zero.
+type requestTrace struct {
+	mu    sync.Mutex
+	start time.Time
+
+	dns          time.Duration
+	connect      time.Duration
+	tlsHandshake time.Duration
+	firstByte    time.Duration
+}
+
+// startRequestTrace begins timing a request; attach the result to the request
+// context with withClientTrace.
+func startRequestTrace() *requestTrace {
+	return &requestTrace{start: time.Now()}
+}
+
+// withClientTrace registers httptrace hooks that feed the requestTrace. It
+// works with any context-aware transport, including the unix-socket one.
+func withClientTrace(ctx context.Context, rt *requestTrace) context.Context {
+	var dnsStart, connectStart, tlsStart time.Time
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			rt.dns = time.Since(dnsStart)
+		},
+		ConnectStart: func(network, addr string) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			connectStart = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			rt.connect = time.Since(connectStart)
+		},
+		TLSHandshakeStart: func() {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			rt.tlsHandshake = time.Since(tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			rt.firstByte = time.Since(rt.start)
+		},
+	}
+
+	return httptrace.WithClientTrace(ctx, trace)
+}
+
+// report prints the timing breakdown, including the total elapsed time up to
+// the moment it is called (i.e. after the body has been consumed).
+func (rt *requestTrace) report(w io.Writer) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	total := time.Since(rt.start)
+	fmt.Fprintf(w, "* timing: dns=%s connect=%s tls=%s ttfb=%s total=%s\n",
+		rt.dns.Round(time.Microsecond),
+		rt.connect.Round(time.Microsecond),
+		rt.tlsHandshake.Round(time.Microsecond),
+		rt.firstByte.Round(time.Microsecond),
+		total.Round(time.Microsecond))
+}
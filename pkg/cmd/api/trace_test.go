@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_requestTrace_report(t *testing.T) {
+	rt := startRequestTrace()
+	rt.dns = 2 * time.Millisecond
+	rt.connect = 3 * time.Millisecond
+	rt.tlsHandshake = 4 * time.Millisecond
+	rt.firstByte = 10 * time.Millisecond
+
+	out := &bytes.Buffer{}
+	rt.report(out)
+
+	assert.Regexp(t, `^\* timing: dns=2ms connect=3ms tls=4ms ttfb=10ms total=\S+\n$`, out.String())
+}
+
+func Test_withClientTrace(t *testing.T) {
+	rt := startRequestTrace()
+	ctx := withClientTrace(context.Background(), rt)
+
+	// the hooks must be wired into the context for the transport to call
+	trace := httptrace.ContextClientTrace(ctx)
+	require.NotNil(t, trace)
+
+	trace.DNSStart(httptrace.DNSStartInfo{})
+	trace.DNSDone(httptrace.DNSDoneInfo{})
+	trace.ConnectStart("tcp", "127.0.0.1:443")
+	trace.ConnectDone("tcp", "127.0.0.1:443", nil)
+	trace.GotFirstResponseByte()
+
+	assert.GreaterOrEqual(t, rt.dns, time.Duration(0))
+	assert.GreaterOrEqual(t, rt.connect, time.Duration(0))
+	assert.Greater(t, rt.firstByte, time.Duration(0))
+}
+
+func Test_apiRun_trace(t *testing.T) {
+	stream, _, stdout, stderr := iostreams.Test()
+	options := ApiOptions{
+		IO:            stream,
+		RequestPath:   "vdp/v1alpha/pipelines",
+		RequestMethod: "GET",
+		Trace:         true,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+
+	require.NoError(t, apiRun(&options))
+
+	assert.Equal(t, `{}`, stdout.String())
+	assert.Regexp(t, regexp.MustCompile(`^\* timing: dns=\S+ connect=\S+ tls=\S+ ttfb=\S+ total=\S+\n$`), stderr.String())
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// compileSchema loads a JSON Schema for `--validate` from disk.
+func compileSchema(filename string) (*jsonschema.Schema, error) {
+	schema, err := jsonschema.Compile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile JSON Schema from %s: %w", filename, err)
+	}
+	return schema, nil
+}
+
+// validateResponse checks the response body against the schema and returns the
+// list of violations, if any. The body is buffered and restored so it can
+// still be printed afterwards.
+func validateResponse(resp *http.Response, schema *jsonschema.Schema) ([]string, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return []string{fmt.Sprintf("response body is not valid JSON: %v", err)}, nil
+	}
+
+	err = schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+	return flattenViolations(validationErr), nil
+}
+
+// flattenViolations walks the cause tree down to the leaf errors, which carry
+// the most specific message for each violating location.
+func flattenViolations(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		return []string{fmt.Sprintf("%s: %s", location, err.Message)}
+	}
+	var violations []string
+	for _, cause := range err.Causes {
+		violations = append(violations, flattenViolations(cause)...)
+	}
+	return violations
+}
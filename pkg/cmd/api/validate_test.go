@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func writeSchemaFile(t *testing.T, contents string) string {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(fn, []byte(contents), 0600))
+	return fn
+}
+
+func validateOptions(t *testing.T, schemaFile, responseBody string) (*ApiOptions, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	stream, _, stdout, stderr := iostreams.Test()
+	options := &ApiOptions{
+		IO:             stream,
+		RequestPath:    "vdp/v1alpha/pipelines",
+		RequestMethod:  "GET",
+		ValidateSchema: schemaFile,
+
+		Config: config.ConfigStubFactory,
+		HTTPClient: func() (*http.Client, error) {
+			var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}
+			return &http.Client{Transport: tr}, nil
+		},
+	}
+	return options, stdout, stderr
+}
+
+const pipelinesSchema = `{
+	"type": "object",
+	"required": ["pipelines"],
+	"properties": {
+		"pipelines": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string"}
+				}
+			}
+		}
+	}
+}`
+
+func Test_apiRun_validate(t *testing.T) {
+	t.Run("conforming response", func(t *testing.T) {
+		schemaFile := writeSchemaFile(t, pipelinesSchema)
+		opts, stdout, stderr := validateOptions(t, schemaFile, `{"pipelines":[{"id":"p1"}]}`)
+
+		require.NoError(t, apiRun(opts))
+		assert.Equal(t, `{"pipelines":[{"id":"p1"}]}`, stdout.String())
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("violations are listed and the body still prints", func(t *testing.T) {
+		schemaFile := writeSchemaFile(t, pipelinesSchema)
+		opts, stdout, stderr := validateOptions(t, schemaFile, `{"pipelines":[{"id":42}]}`)
+
+		err := apiRun(opts)
+		assert.Equal(t, cmdutil.SilentError, err)
+		assert.Equal(t, `{"pipelines":[{"id":42}]}`, stdout.String())
+		assert.Equal(t, heredoc.Doc(`
+			inst: response does not conform to the schema:
+			- /pipelines/0/id: expected string, but got number
+		`), stderr.String())
+	})
+
+	t.Run("non-JSON response", func(t *testing.T) {
+		schemaFile := writeSchemaFile(t, pipelinesSchema)
+		opts, _, stderr := validateOptions(t, schemaFile, `not json`)
+
+		err := apiRun(opts)
+		assert.Equal(t, cmdutil.SilentError, err)
+		assert.Contains(t, stderr.String(), "response body is not valid JSON")
+	})
+
+	t.Run("invalid schema file", func(t *testing.T) {
+		schemaFile := writeSchemaFile(t, `{"type": 42}`)
+		opts, _, _ := validateOptions(t, schemaFile, `{}`)
+
+		err := apiRun(opts)
+		assert.ErrorContains(t, err, "failed to compile JSON Schema")
+	})
+}
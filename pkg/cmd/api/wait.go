@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// swappable for tests
+var waitNow = time.Now
+var waitSleep = time.Sleep
+
+// waitForCondition polls a long-running operation until the watched response
+// field reaches the target value or the timeout elapses. Each poll hits the
+// operation's status_url field when the response carries one, and the original
+// request URL otherwise. The final response is returned with a buffered body
+// so normal output processing applies to it.
+func waitForCondition(ctx context.Context, opts *ApiOptions, client *http.Client, hostname string, resp *http.Response) (*http.Response, error) {
+	deadline := waitNow().Add(opts.WaitTimeout)
+
+	for {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		value, found := lookupWaitField(body, opts.WaitField)
+		if found && value == opts.WaitUntil {
+			return resp, nil
+		}
+
+		observed := value
+		if !found {
+			observed = "<missing>"
+		}
+		if !waitNow().Before(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for %q to become %q (last observed: %s)",
+				opts.WaitTimeout, opts.WaitField, opts.WaitUntil, observed)
+		}
+
+		fmt.Fprintf(opts.IO.ErrOut, "inst: waiting for %q to become %q (currently %s)\n",
+			opts.WaitField, opts.WaitUntil, observed)
+		waitSleep(opts.WaitInterval)
+
+		resp, err = httpRequest(ctx, client, hostname, "GET", pollPath(body, resp), nil, opts.RequestHeaders)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// pollPath picks the URL for the next poll: the status_url reported by the
+// operation when present, else the URL the previous response came from.
+func pollPath(body []byte, resp *http.Response) string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if statusURL, ok := payload["status_url"].(string); ok && statusURL != "" {
+			return statusURL
+		}
+	}
+	return resp.Request.URL.String()
+}
+
+// lookupWaitField resolves a dot-separated field path in a JSON response body
+// and renders the value as a string for comparison.
+func lookupWaitField(body []byte, field string) (string, bool) {
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", false
+	}
+
+	current := payload
+	for _, part := range strings.Split(field, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = obj[part]; !ok {
+			return "", false
+		}
+	}
+
+	switch v := current.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", false
+	default:
+		return fmt.Sprintf("%v", v), true
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_lookupWaitField(t *testing.T) {
+	body := []byte(`{"status":"pending","operation":{"state":"RUNNING","progress":42},"done":false}`)
+
+	tests := []struct {
+		field     string
+		want      string
+		wantFound bool
+	}{
+		{field: "status", want: "pending", wantFound: true},
+		{field: "operation.state", want: "RUNNING", wantFound: true},
+		{field: "operation.progress", want: "42", wantFound: true},
+		{field: "done", want: "false", wantFound: true},
+		{field: "missing", wantFound: false},
+		{field: "status.nested", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, found := lookupWaitField(body, tt.field)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, found := lookupWaitField([]byte("not json"), "status")
+	assert.False(t, found)
+}
+
+func Test_waitForCondition(t *testing.T) {
+	oldSleep := waitSleep
+	waitSleep = func(time.Duration) {}
+	defer func() { waitSleep = oldSleep }()
+
+	makeResponse := func(req *http.Request, body string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Request:    req,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+
+	t.Run("polls until the field reaches the target", func(t *testing.T) {
+		stream, _, _, stderr := iostreams.Test()
+		opts := &ApiOptions{
+			IO:           stream,
+			Wait:         true,
+			WaitField:    "status",
+			WaitUntil:    "done",
+			WaitInterval: time.Millisecond,
+			WaitTimeout:  time.Minute,
+		}
+
+		polls := 0
+		var tr roundTripper = func(req *http.Request) (*http.Response, error) {
+			polls++
+			// the second poll reports the operation as settled
+			if polls >= 2 {
+				return makeResponse(req, `{"status":"done","result":"ok"}`), nil
+			}
+			return makeResponse(req, `{"status":"pending"}`), nil
+		}
+		client := &http.Client{Transport: tr}
+
+		initialReq, err := http.NewRequest("GET", "https://instill.tech/operations/1", nil)
+		require.NoError(t, err)
+		initial := makeResponse(initialReq, `{"status":"pending","status_url":"https://instill.tech/operations/1/status"}`)
+
+		final, err := waitForCondition(context.Background(), opts, client, "instill.tech", initial)
+		require.NoError(t, err)
+		body, err := io.ReadAll(final.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"status":"done","result":"ok"}`, string(body))
+		assert.Equal(t, 2, polls)
+		assert.Contains(t, stderr.String(), `waiting for "status" to become "done" (currently pending)`)
+	})
+
+	t.Run("times out with the last observed state", func(t *testing.T) {
+		stream, _, _, _ := iostreams.Test()
+		opts := &ApiOptions{
+			IO:           stream,
+			Wait:         true,
+			WaitField:    "status",
+			WaitUntil:    "done",
+			WaitInterval: time.Millisecond,
+			WaitTimeout:  0,
+		}
+
+		initialReq, err := http.NewRequest("GET", "https://instill.tech/operations/1", nil)
+		require.NoError(t, err)
+		initial := makeResponse(initialReq, `{"status":"stuck"}`)
+
+		_, err = waitForCondition(context.Background(), opts, &http.Client{}, "instill.tech", initial)
+		assert.EqualError(t, err, `timed out after 0s waiting for "status" to become "done" (last observed: stuck)`)
+	})
+}
+
+func Test_pollPath(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "instill.tech", Path: "/operations/1"}}
+	resp := &http.Response{Request: req}
+
+	assert.Equal(t, "https://example.com/status", pollPath([]byte(`{"status_url":"https://example.com/status"}`), resp))
+	assert.Equal(t, "https://instill.tech/operations/1", pollPath([]byte(`{"status":"pending"}`), resp))
+	assert.Equal(t, "https://instill.tech/operations/1", pollPath([]byte(`not json`), resp))
+}
@@ -7,7 +7,10 @@ import (
 
 	authLoginCmd "github.com/instill-ai/cli/pkg/cmd/auth/login"
 	authLogoutCmd "github.com/instill-ai/cli/pkg/cmd/auth/logout"
+	authMigrateCmd "github.com/instill-ai/cli/pkg/cmd/auth/migrate"
+	authRefreshCmd "github.com/instill-ai/cli/pkg/cmd/auth/refresh"
 	authStatusCmd "github.com/instill-ai/cli/pkg/cmd/auth/status"
+	authSwitchCmd "github.com/instill-ai/cli/pkg/cmd/auth/switch"
 )
 
 // NewCmdAuth creates the `auth` command
@@ -23,6 +26,9 @@ func NewCmdAuth(f *cmdutil.Factory) *cobra.Command {
 	cmd.AddCommand(authLoginCmd.NewCmdLogin(f, nil))
 	cmd.AddCommand(authLogoutCmd.NewCmdLogout(f, nil))
 	cmd.AddCommand(authStatusCmd.NewCmdStatus(f, nil))
+	cmd.AddCommand(authRefreshCmd.NewCmdRefresh(f, nil))
+	cmd.AddCommand(authMigrateCmd.NewCmdMigrate(f, nil))
+	cmd.AddCommand(authSwitchCmd.NewCmdSwitch(f, nil))
 
 	return cmd
 }
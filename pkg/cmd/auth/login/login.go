@@ -3,10 +3,13 @@ package login
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/MakeNowJust/heredoc"
@@ -28,6 +31,12 @@ type LoginOptions struct {
 	MainExecutable string
 	Interactive    bool
 	Hostname       string
+	Device         bool
+	Force          bool
+	TokenFile      string
+	Check          bool
+	Scopes         []string
+	GitProtocol    string
 }
 
 // NewCmdLogin creates a new command
@@ -44,11 +53,40 @@ func NewCmdLogin(f *cmdutil.Factory, runF func(*LoginOptions) error) *cobra.Comm
 		Long: heredoc.Docf(`
 			Authenticate with an Instill Core/Cloud host.
 
-			The default authentication mode is an authorization code flow.
-		`),
+			The default authentication mode is an authorization code flow. In
+			environments without a browser, pass %[1]s--device%[1]s to use the OAuth
+			device-code flow instead: a one-time code is printed to the terminal to
+			be entered on another machine.
+
+			When a still-valid token already exists for the host, the command reports
+			it and exits without re-authenticating; pass %[1]s--force%[1]s to discard
+			the token and run the flow anyway.
+
+			A token minted elsewhere can be imported with %[1]s--token-file%[1]s,
+			which stores the file's contents for the host without running a flow.
+			Add %[1]s--check%[1]s to only validate the token against the host and report
+			the authenticated identity: nothing is written to the config, and the
+			command exits non-zero when the token is rejected, so CI can verify a
+			secret before using it.
+		`, "`"),
 		Example: heredoc.Doc(`
 			# login to the default host
 			$ inst auth login
+
+			# login from a browserless environment
+			$ inst auth login --device
+
+			# discard a still-valid token and authenticate again
+			$ inst auth login --force
+
+			# import an access token from a file instead of running the OAuth flow
+			$ inst auth login --token-file ~/.secrets/instill
+
+			# verify a token is valid without storing it
+			$ inst auth login --token-file ~/.secrets/instill --check
+
+			# request additional OAuth scopes
+			$ inst auth login --scopes read:pipelines,write:pipelines
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -56,6 +94,39 @@ func NewCmdLogin(f *cmdutil.Factory, runF func(*LoginOptions) error) *cobra.Comm
 				opts.Interactive = true
 			}
 
+			if opts.GitProtocol != "" {
+				opts.GitProtocol = config.NormalizeValue("protocol", opts.GitProtocol)
+				if err := config.ValidateValue("protocol", opts.GitProtocol); err != nil {
+					var invalidValue *config.InvalidValueError
+					if errors.As(err, &invalidValue) {
+						return cmdutil.FlagErrorf("invalid value %q for `--git-protocol`: valid values are %s",
+							opts.GitProtocol, strings.Join(invalidValue.ValidValues, ", "))
+					}
+					return err
+				}
+			}
+
+			if opts.Hostname != "" {
+				hostname, err := cmdutil.NormalizeHostname(opts.Hostname)
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--hostname`: %v", opts.Hostname, err)
+				}
+				opts.Hostname = hostname
+			}
+
+			// fail fast on an unreadable token file, before any flow starts
+			if opts.TokenFile != "" {
+				if opts.Device {
+					return cmdutil.FlagErrorf("`--token-file` cannot be combined with `--device`")
+				}
+				if _, err := os.Stat(opts.TokenFile); err != nil {
+					return cmdutil.FlagErrorf("cannot read the token file: %v", err)
+				}
+			}
+			if opts.Check && opts.TokenFile == "" {
+				return cmdutil.FlagErrorf("`--check` requires `--token-file`")
+			}
+
 			opts.MainExecutable = f.Executable()
 			if runF != nil {
 				return runF(opts)
@@ -69,12 +140,24 @@ func NewCmdLogin(f *cmdutil.Factory, runF func(*LoginOptions) error) *cobra.Comm
 	cfg, _ := opts.Config()
 
 	cmd.Flags().StringVarP(&opts.Hostname, "hostname", "h", cfg.DefaultHostname(), "Hostname of an already added Instill Core/Cloud instance")
+	cmd.Flags().BoolVar(&opts.Device, "device", false, "Authenticate with the device-code flow (for browserless environments)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Re-authenticate even when a still-valid token exists")
+	cmd.Flags().StringVar(&opts.TokenFile, "token-file", "", "Read the access token from `file` instead of running an OAuth flow")
+	cmd.Flags().BoolVar(&opts.Check, "check", false, "With `--token-file`, validate the token against the host without storing it")
+	cmd.Flags().StringSliceVar(&opts.Scopes, "scopes", nil, "Additional OAuth scopes to request, comma separated")
+	cmd.Flags().StringVar(&opts.GitProtocol, "git-protocol", "", "Additionally set the `protocol` config for the host after login: {https|ssh}")
 
 	return cmd
 }
 
 func loginRun(f *cmdutil.Factory, opts *LoginOptions) error {
 
+	// a dry-run check never touches the config, not even the hosts.yml
+	// bootstrap below
+	if opts.Check {
+		return checkRun(nil, opts)
+	}
+
 	var host *config.HostConfigTyped
 
 	cfg, err := opts.Config()
@@ -108,6 +191,34 @@ func loginRun(f *cmdutil.Factory, opts *LoginOptions) error {
 		}
 	}
 
+	// skip the whole flow when a still-valid token exists, so a stray
+	// `auth login` does not churn tokens; --force overwrites as before
+	if !opts.Force && tokenStillValid(host) {
+		if user, _ := cfg.Get(host.APIHostname, "user"); user != "" {
+			fmt.Fprintf(opts.IO.Out, "You are already logged into %s as %s. Use `--force` to re-authenticate.\n", host.APIHostname, user)
+		} else {
+			fmt.Fprintf(opts.IO.Out, "You are already logged into %s. Use `--force` to re-authenticate.\n", host.APIHostname)
+		}
+		return nil
+	}
+
+	// an imported token replaces any flow; it was read up front so only
+	// content problems can surface here
+	if opts.TokenFile != "" {
+		token, err := readTokenFile(opts.TokenFile)
+		if err != nil {
+			return err
+		}
+		host.AccessToken = token
+		if err := cfg.SaveTyped(host); err != nil {
+			return err
+		}
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.Out, "%s Logged in to %s with the token from %s\n",
+			cs.SuccessIcon(), cs.Bold(host.APIHostname), opts.TokenFile)
+		return setGitProtocol(cfg, host.APIHostname, opts.GitProtocol)
+	}
+
 	// TODO INS-1659 drop in favor of OAuth2
 	if instance.IsLocal(host.APIHostname) {
 		fmt.Println("Logging into the local Instill Core instance...")
@@ -127,7 +238,7 @@ func loginRun(f *cmdutil.Factory, opts *LoginOptions) error {
 		if err != nil {
 			return err
 		}
-		return nil
+		return setGitProtocol(cfg, host.APIHostname, opts.GitProtocol)
 	}
 
 	if host.Oauth2Hostname == "" || host.Oauth2ClientID == "" || host.Oauth2ClientSecret == "" {
@@ -157,7 +268,113 @@ func loginRun(f *cmdutil.Factory, opts *LoginOptions) error {
 		}
 	}
 
-	return oauth2.AuthCodeFlowWithConfig(f, host, cfg, opts.IO)
+	if opts.Device {
+		if err := oauth2.DeviceCodeFlowWithScopes(host, cfg, opts.IO, opts.Scopes); err != nil {
+			return err
+		}
+	} else if err := oauth2.AuthCodeFlowWithScopes(f, host, cfg, opts.IO, opts.Scopes); err != nil {
+		return err
+	}
+
+	return setGitProtocol(cfg, host.APIHostname, opts.GitProtocol)
+}
+
+// checkRun validates the token from `--token-file` against the host and
+// reports the authenticated identity, without storing anything.
+func checkRun(transport http.RoundTripper, opts *LoginOptions) error {
+	token, err := readTokenFile(opts.TokenFile)
+	if err != nil {
+		return err
+	}
+
+	cs := opts.IO.ColorScheme()
+	user, err := fetchAuthenticatedUser(transport, opts.Hostname, token)
+	if err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s The token is not valid for %s: %v\n", cs.FailureIcon(), opts.Hostname, err)
+		return cmdutil.SilentError
+	}
+	fmt.Fprintf(opts.IO.Out, "%s The token is valid for %s; authenticated as %s\n",
+		cs.SuccessIcon(), cs.Bold(opts.Hostname), cs.Bold(user))
+	return nil
+}
+
+type authenticatedUserResponse struct {
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+// fetchAuthenticatedUser resolves the identity behind an access token via the
+// host's "users/me" endpoint.
+func fetchAuthenticatedUser(transport http.RoundTripper, hostname, token string) (string, error) {
+	url := instance.GetProtocol(hostname) + "base/v1alpha/users/me"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	client := &http.Client{Transport: transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	var response authenticatedUserResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", err
+	}
+	if response.User.ID == "" {
+		return "", fmt.Errorf("the response did not include a user")
+	}
+	return response.User.ID, nil
+}
+
+// readTokenFile reads an access token from a file, trimming surrounding
+// whitespace, and rejects files that do not hold exactly one token.
+func readTokenFile(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("cannot read the token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("the token file %s is empty", filename)
+	}
+	if strings.ContainsAny(token, " \t\n") {
+		return "", fmt.Errorf("the token file %s must contain a single token", filename)
+	}
+	return token, nil
+}
+
+// tokenStillValid reports whether the host already holds an access token
+// whose recorded expiry, if any, is still in the future.
+func tokenStillValid(host *config.HostConfigTyped) bool {
+	if host.AccessToken == "" {
+		return false
+	}
+	if t, err := time.Parse(time.RFC1123, host.Expiry); err == nil && time.Now().After(t) {
+		return false
+	}
+	return true
+}
+
+// setGitProtocol persists the --git-protocol choice for the host after a
+// successful login; an empty protocol leaves the existing config untouched.
+func setGitProtocol(cfg config.Config, hostname, protocol string) error {
+	if protocol == "" {
+		return nil
+	}
+	if err := cfg.Set(hostname, "protocol", protocol); err != nil {
+		return err
+	}
+	return cfg.Write()
 }
 
 type localLoginResponse struct {
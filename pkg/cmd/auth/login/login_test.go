@@ -2,12 +2,17 @@ package login
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/internal/instance"
@@ -42,6 +47,60 @@ func Test_NewCmdLogin(t *testing.T) {
 				Interactive: true,
 			},
 		},
+		{
+			name:     "tty, scopes",
+			stdinTTY: true,
+			cli:      "--scopes read:pipelines,write:pipelines",
+			wants: LoginOptions{
+				Hostname:    instance.FallbackHostname(),
+				Interactive: true,
+				Scopes:      []string{"read:pipelines", "write:pipelines"},
+			},
+		},
+		{
+			name:     "tty, git protocol is normalized",
+			stdinTTY: true,
+			cli:      "--git-protocol SSH",
+			wants: LoginOptions{
+				Hostname:    instance.FallbackHostname(),
+				Interactive: true,
+				GitProtocol: "ssh",
+			},
+		},
+		{
+			name:     "tty, force",
+			stdinTTY: true,
+			cli:      "--force",
+			wants: LoginOptions{
+				Hostname:    instance.FallbackHostname(),
+				Interactive: true,
+				Force:       true,
+			},
+		},
+		{
+			name:     "invalid git protocol",
+			stdinTTY: true,
+			cli:      "--git-protocol ftp",
+			wantsErr: true,
+		},
+		{
+			name:     "missing token file",
+			stdinTTY: true,
+			cli:      "--token-file does-not-exist",
+			wantsErr: true,
+		},
+		{
+			name:     "token file with device flow",
+			stdinTTY: true,
+			cli:      "--token-file some-file --device",
+			wantsErr: true,
+		},
+		{
+			name:     "--check without --token-file",
+			stdinTTY: true,
+			cli:      "--check",
+			wantsErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,10 +143,151 @@ func Test_NewCmdLogin(t *testing.T) {
 
 			assert.Equal(t, tt.wants.Hostname, gotOpts.Hostname)
 			assert.Equal(t, tt.wants.Interactive, gotOpts.Interactive)
+			assert.Equal(t, tt.wants.Force, gotOpts.Force)
+			assert.Equal(t, tt.wants.Scopes, gotOpts.Scopes)
+			assert.Equal(t, tt.wants.GitProtocol, gotOpts.GitProtocol)
 		})
 	}
 }
 
+func Test_readTokenFile(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		t.Helper()
+		filename := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+		return filename
+	}
+
+	t.Run("trims surrounding whitespace", func(t *testing.T) {
+		token, err := readTokenFile(writeFile(t, "  TOKEN\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "TOKEN", token)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		filename := writeFile(t, "\n")
+		_, err := readTokenFile(filename)
+		assert.EqualError(t, err, fmt.Sprintf("the token file %s is empty", filename))
+	})
+
+	t.Run("more than one token", func(t *testing.T) {
+		filename := writeFile(t, "TOKEN1\nTOKEN2\n")
+		_, err := readTokenFile(filename)
+		assert.EqualError(t, err, fmt.Sprintf("the token file %s must contain a single token", filename))
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		_, err := readTokenFile(filepath.Join(t.TempDir(), "missing"))
+		assert.ErrorContains(t, err, "cannot read the token file")
+	})
+}
+
+func Test_tokenStillValid(t *testing.T) {
+	tests := []struct {
+		name string
+		host config.HostConfigTyped
+		want bool
+	}{
+		{
+			name: "no token",
+			host: config.HostConfigTyped{},
+			want: false,
+		},
+		{
+			name: "token without recorded expiry",
+			host: config.HostConfigTyped{AccessToken: "TOKEN"},
+			want: true,
+		},
+		{
+			name: "token with future expiry",
+			host: config.HostConfigTyped{
+				AccessToken: "TOKEN",
+				Expiry:      time.Now().Add(time.Hour).Format(time.RFC1123),
+			},
+			want: true,
+		},
+		{
+			name: "expired token",
+			host: config.HostConfigTyped{
+				AccessToken: "TOKEN",
+				Expiry:      time.Now().Add(-time.Hour).Format(time.RFC1123),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenStillValid(&tt.host))
+		})
+	}
+}
+
+func Test_checkRun(t *testing.T) {
+	writeToken := func(t *testing.T) string {
+		t.Helper()
+		filename := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(filename, []byte("TOKEN\n"), 0600))
+		return filename
+	}
+
+	makeResponse := func(t *testing.T, status int, body string) *http.Response {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		recorder.WriteHeader(status)
+		_, err := recorder.WriteString(body)
+		require.NoError(t, err)
+		return recorder.Result()
+	}
+
+	t.Run("valid token reports the identity", func(t *testing.T) {
+		io, _, stdout, stderr := iostreams.Test()
+		opts := &LoginOptions{
+			IO:        io,
+			Hostname:  "api.instill.tech",
+			TokenFile: writeToken(t),
+			Check:     true,
+		}
+
+		transport := &mockRoundTripper{makeResponse(t, 200, `{"user":{"id":"alice"}}`)}
+		err := checkRun(transport, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "✓ The token is valid for api.instill.tech; authenticated as alice\n", stdout.String())
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("rejected token exits non-zero", func(t *testing.T) {
+		io, _, stdout, stderr := iostreams.Test()
+		opts := &LoginOptions{
+			IO:        io,
+			Hostname:  "api.instill.tech",
+			TokenFile: writeToken(t),
+			Check:     true,
+		}
+
+		transport := &mockRoundTripper{makeResponse(t, 401, `{}`)}
+		err := checkRun(transport, opts)
+		assert.Equal(t, cmdutil.SilentError, err)
+		assert.Equal(t, "", stdout.String())
+		assert.Equal(t, "X The token is not valid for api.instill.tech: HTTP 401\n", stderr.String())
+	})
+
+	t.Run("response without a user", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		opts := &LoginOptions{
+			IO:        io,
+			Hostname:  "api.instill.tech",
+			TokenFile: writeToken(t),
+			Check:     true,
+		}
+
+		transport := &mockRoundTripper{makeResponse(t, 200, `{}`)}
+		err := checkRun(transport, opts)
+		assert.Equal(t, cmdutil.SilentError, err)
+		assert.Contains(t, stderr.String(), "the response did not include a user")
+	})
+}
+
 type mockRoundTripper struct {
 	response *http.Response
 }
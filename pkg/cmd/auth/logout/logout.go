@@ -17,6 +17,7 @@ type LogoutOptions struct {
 	IO       *iostreams.IOStreams
 	Config   func() (config.Config, error)
 	Hostname string
+	Yes      bool
 }
 
 func NewCmdLogout(f *cmdutil.Factory, runF func(*LogoutOptions) error) *cobra.Command {
@@ -55,6 +56,8 @@ func NewCmdLogout(f *cmdutil.Factory, runF func(*LogoutOptions) error) *cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&opts.Hostname, "hostname", "h", "", "The hostname of the Instill instance to log out of")
+	_ = cmd.RegisterFlagCompletionFunc("hostname", cmdutil.HostnameCompletion(f))
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Log out without asking for confirmation")
 
 	return cmd
 }
@@ -107,12 +110,8 @@ func logoutRun(opts *LogoutOptions) error {
 		return err
 	}
 
-	if opts.IO.CanPrompt() {
-		var keepGoing bool
-		err := prompt.SurveyAskOne(&survey.Confirm{
-			Message: fmt.Sprintf("Are you sure you want to log out of %s?", hostname),
-			Default: true,
-		}, &keepGoing)
+	if !opts.Yes {
+		keepGoing, err := cmdutil.Confirm(opts.IO, fmt.Sprintf("Are you sure you want to log out of %s?", hostname), true)
 		if err != nil {
 			return fmt.Errorf("could not prompt: %w", err)
 		}
@@ -131,7 +130,7 @@ func logoutRun(opts *LogoutOptions) error {
 
 	isTTY := opts.IO.IsStdinTTY() && opts.IO.IsStdoutTTY()
 
-	if isTTY {
+	if isTTY && !opts.IO.IsQuiet() {
 		cs := opts.IO.ColorScheme()
 		fmt.Fprintf(opts.IO.ErrOut, "%s Logged out of %s\n",
 			cs.SuccessIcon(), cs.Bold(hostname))
@@ -0,0 +1,82 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+// MigrateOptions contains the command line options
+type MigrateOptions struct {
+	IO     *iostreams.IOStreams
+	Config func() (config.Config, error)
+}
+
+// NewCmdMigrate creates a new command
+func NewCmdMigrate(f *cmdutil.Factory, runF func(*MigrateOptions) error) *cobra.Command {
+	opts := &MigrateOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate-credentials",
+		Args:  cobra.ExactArgs(0),
+		Short: "Move stored OAuth tokens into the OS keyring",
+		Long: heredoc.Doc(`
+			Move OAuth tokens stored in plaintext in the config file into the OS keyring.
+
+			This sets the 'credential_store' config key to "keyring" and re-saves every
+			configured host, so that the config file contains only keyring references.
+		`),
+		Example: heredoc.Doc(`
+			$ inst auth migrate-credentials
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return migrateRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func migrateRun(opts *MigrateOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Set("", "credential_store", "keyring")
+	if err != nil {
+		return err
+	}
+
+	hosts, err := cfg.HostsTyped()
+	if err != nil {
+		return err
+	}
+
+	for i := range hosts {
+		err = cfg.SaveTyped(&hosts[i])
+		if err != nil {
+			return fmt.Errorf("failed to migrate credentials for %s: %w", hosts[i].APIHostname, err)
+		}
+	}
+
+	if opts.IO.IsStdoutTTY() && !opts.IO.IsQuiet() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.ErrOut, "%s Migrated credentials of %d host(s) into the OS keyring\n",
+			cs.SuccessIcon(), len(hosts))
+	}
+
+	return nil
+}
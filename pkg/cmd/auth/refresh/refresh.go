@@ -0,0 +1,94 @@
+package refresh
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/internal/oauth2"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type RefreshOptions struct {
+	IO       *iostreams.IOStreams
+	Config   config.Config
+	Factory  *cmdutil.Factory
+	Hostname string
+	Scopes   []string
+}
+
+func NewCmdRefresh(f *cmdutil.Factory, runF func(*RefreshOptions) error) *cobra.Command {
+	opts := &RefreshOptions{
+		IO:      f.IOStreams,
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Args:  cobra.ExactArgs(0),
+		Short: "Refresh stored authentication credentials",
+		Long: heredoc.Doc(`
+			Expand or fix the permission scopes of a stored token by re-running the
+			OAuth flow for an already-configured host. The new token replaces the old
+			one; all other host settings are preserved.
+		`),
+		Example: heredoc.Doc(`
+			# refresh credentials for the default host
+			$ inst auth refresh
+
+			# refresh credentials for a specific host, requesting extra scopes
+			$ inst auth refresh --hostname api.instill.tech --scopes read:org
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = cfg
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return refreshRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Hostname, "hostname", "", "The hostname of the instance to refresh credentials for")
+	cmd.Flags().StringSliceVar(&opts.Scopes, "scopes", nil, "Additional authentication scopes to request")
+
+	return cmd
+}
+
+func refreshRun(opts *RefreshOptions) error {
+	cfg := opts.Config
+
+	hostname := opts.Hostname
+	if hostname == "" {
+		hostname = cfg.DefaultHostname()
+	}
+
+	hosts, err := cfg.HostsTyped()
+	if err != nil {
+		return err
+	}
+	var host *config.HostConfigTyped
+	for i := range hosts {
+		if hosts[i].APIHostname == hostname {
+			host = &hosts[i]
+			break
+		}
+	}
+	if host == nil {
+		return fmt.Errorf(heredoc.Docf(
+			`ERROR: instance '%s' is not configured
+
+			To authenticate, run:
+			$ inst auth login`,
+			hostname))
+	}
+
+	return oauth2.AuthCodeFlowWithScopes(opts.Factory, host, cfg, opts.IO, opts.Scopes)
+}
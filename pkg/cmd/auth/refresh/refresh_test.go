@@ -0,0 +1,87 @@
+package refresh
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdRefresh(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		output   RefreshOptions
+		wantsErr bool
+	}{
+		{
+			name:   "no arguments",
+			input:  "",
+			output: RefreshOptions{},
+		},
+		{
+			name:  "hostname and scopes",
+			input: "--hostname api.instill.tech --scopes read:org,repo",
+			output: RefreshOptions{
+				Hostname: "api.instill.tech",
+				Scopes:   []string{"read:org", "repo"},
+			},
+		},
+		{
+			name:     "positional arguments",
+			input:    "api.instill.tech",
+			wantsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, _ := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: io,
+				Config:    config.ConfigStubFactory,
+			}
+
+			argv, err := shlex.Split(tt.input)
+			assert.NoError(t, err)
+
+			var gotOpts *RefreshOptions
+			cmd := NewCmdRefresh(f, func(opts *RefreshOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output.Hostname, gotOpts.Hostname)
+			assert.Equal(t, tt.output.Scopes, gotOpts.Scopes)
+		})
+	}
+}
+
+func Test_refreshRun_unknownHost(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	opts := &RefreshOptions{
+		IO:       io,
+		Config:   config.ConfigStub{},
+		Hostname: "unknown.instill.tech",
+	}
+
+	err := refreshRun(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not configured")
+	assert.Contains(t, err.Error(), "inst auth login")
+}
@@ -0,0 +1,158 @@
+package status
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/internal/instance"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+)
+
+// connectivityWorkers bounds how many hosts are pinged concurrently.
+const connectivityWorkers = 4
+
+// connectivityEntry is the machine-readable shape of one host's connectivity
+// check result.
+type connectivityEntry struct {
+	Host      string `json:"host" yaml:"host"`
+	Reachable bool   `json:"reachable" yaml:"reachable"`
+	Latency   string `json:"latency,omitempty" yaml:"latency,omitempty"`
+	TLS       string `json:"tls,omitempty" yaml:"tls,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// checkConnectivityRun pings every configured host's base URL concurrently and
+// prints a summary of latency and TLS state per host.
+func checkConnectivityRun(opts *StatusOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	stderr := opts.IO.ErrOut
+	cs := opts.IO.ColorScheme()
+
+	hostnames, err := cfg.Hosts()
+	if err != nil {
+		return err
+	}
+	if len(hostnames) == 0 {
+		fmt.Fprintf(stderr,
+			"You are not logged into any Instill Core/Cloud instance. Run %s to authenticate.\n", cs.Bold("inst auth login"))
+		return cmdutil.SilentError
+	}
+
+	var targets []string
+	for _, hostname := range hostnames {
+		if opts.Hostname != "" && opts.Hostname != hostname {
+			continue
+		}
+		targets = append(targets, hostname)
+	}
+	if len(targets) == 0 {
+		fmt.Fprintf(stderr,
+			"Hostname %q not found among authenticated Instill Core/Cloud hosts\n", opts.Hostname)
+		return cmdutil.SilentError
+	}
+
+	client := &http.Client{Timeout: httpTimeout(cfg)}
+	entries := checkConnectivity(client, targets)
+
+	cols := []string{"Hostname", "Reachable", "Latency", "TLS", "Error"}
+	var data [][]string
+	failed := false
+	for _, e := range entries {
+		if !e.Reachable {
+			failed = true
+		}
+		data = append(data, []string{e.Host, fmt.Sprintf("%v", e.Reachable), e.Latency, e.TLS, e.Error})
+	}
+
+	if err := cmdutil.RenderOutput(opts.IO, opts.Output, cols, data, entries); err != nil {
+		return err
+	}
+	if failed {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+// checkConnectivity pings the hosts through a bounded worker pool and returns
+// one entry per host, in the input order.
+func checkConnectivity(client *http.Client, hostnames []string) []connectivityEntry {
+	entries := make([]connectivityEntry, len(hostnames))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := connectivityWorkers
+	if len(hostnames) < workers {
+		workers = len(hostnames)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				entries[i] = checkHost(client, hostnames[i])
+			}
+		}()
+	}
+	for i := range hostnames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return entries
+}
+
+// checkHost issues a single request against the host's base URL. Any HTTP
+// response counts as reachable; only transport-level failures do not.
+func checkHost(client *http.Client, hostname string) connectivityEntry {
+	entry := connectivityEntry{Host: hostname}
+
+	req, err := http.NewRequest("HEAD", instance.GetProtocol(hostname), nil)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	start := time.Now()
+	res, err := client.Do(req)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	defer res.Body.Close()
+
+	entry.Reachable = true
+	entry.Latency = time.Since(start).Round(time.Millisecond).String()
+
+	if res.TLS == nil {
+		entry.TLS = "none"
+	} else if len(res.TLS.PeerCertificates) > 0 {
+		cert := res.TLS.PeerCertificates[0]
+		if time.Now().After(cert.NotAfter) {
+			entry.TLS = fmt.Sprintf("expired on %s", cert.NotAfter.Format("2006-01-02"))
+		} else {
+			entry.TLS = fmt.Sprintf("valid until %s", cert.NotAfter.Format("2006-01-02"))
+		}
+	}
+
+	return entry
+}
+
+// httpTimeout resolves the http_timeout config key, falling back to the
+// default when the value does not parse.
+func httpTimeout(cfg config.Config) time.Duration {
+	timeout := 10 * time.Second
+	if raw, _ := cfg.Get("", "http_timeout"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			timeout = d
+		}
+	}
+	return timeout
+}
@@ -0,0 +1,46 @@
+package status
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func Test_checkConnectivity(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	// the test server listens on 127.0.0.1; a localhost hostname keeps
+	// instance.GetProtocol on plain http
+	reachable := "localhost:" + strings.TrimPrefix(ts.URL, "http://127.0.0.1:")
+	unreachable := "localhost:1"
+
+	client := &http.Client{Timeout: time.Second}
+	entries := checkConnectivity(client, []string{reachable, unreachable})
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, reachable, entries[0].Host)
+	assert.True(t, entries[0].Reachable)
+	assert.NotEmpty(t, entries[0].Latency)
+	assert.Equal(t, "none", entries[0].TLS)
+	assert.Empty(t, entries[0].Error)
+
+	assert.Equal(t, unreachable, entries[1].Host)
+	assert.False(t, entries[1].Reachable)
+	assert.NotEmpty(t, entries[1].Error)
+}
+
+func Test_httpTimeout(t *testing.T) {
+	assert.Equal(t, 10*time.Second, httpTimeout(config.ConfigStub{}))
+	assert.Equal(t, 3*time.Second, httpTimeout(config.ConfigStub{"http_timeout": "3s"}))
+	assert.Equal(t, 10*time.Second, httpTimeout(config.ConfigStub{"http_timeout": "nope"}))
+}
@@ -2,6 +2,8 @@ package status
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
@@ -12,9 +14,24 @@ import (
 )
 
 type StatusOptions struct {
-	IO       *iostreams.IOStreams
-	Config   func() (config.Config, error)
-	Hostname string
+	IO                *iostreams.IOStreams
+	Config            func() (config.Config, error)
+	Hostname          string
+	Output            string
+	ShowToken         bool
+	CheckConnectivity bool
+}
+
+// statusEntry is the machine-readable shape of one host's auth state. Token
+// is only populated when --show-token is passed.
+type statusEntry struct {
+	Host        string   `json:"host" yaml:"host"`
+	User        string   `json:"user,omitempty" yaml:"user,omitempty"`
+	TokenValid  bool     `json:"tokenValid" yaml:"tokenValid"`
+	Scopes      []string `json:"scopes" yaml:"scopes"`
+	TokenExpiry string   `json:"tokenExpiry,omitempty" yaml:"tokenExpiry,omitempty"`
+	TokenSource string   `json:"tokenSource,omitempty" yaml:"tokenSource,omitempty"`
+	Token       string   `json:"token,omitempty" yaml:"token,omitempty"`
 }
 
 func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Command {
@@ -31,21 +48,39 @@ func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Co
 
 			This command will test your authentication state for each Instill host that inst knows about and
 			report on any issues.
+
+			With --check-connectivity, every configured host is pinged concurrently and a
+			summary of latency and TLS state is printed instead. The command exits
+			non-zero if any host is unreachable.
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateFormat(opts.Output); err != nil {
+				return err
+			}
+
 			if runF != nil {
 				return runF(opts)
 			}
 
+			if opts.CheckConnectivity {
+				return checkConnectivityRun(opts)
+			}
 			return statusRun(opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.Hostname, "hostname", "h", "", "Check a specific hostname's auth status")
+	_ = cmd.RegisterFlagCompletionFunc("hostname", cmdutil.HostnameCompletion(f))
+	cmd.Flags().BoolVar(&opts.ShowToken, "show-token", false, "Include the access token in machine-readable output")
+	cmd.Flags().BoolVar(&opts.CheckConnectivity, "check-connectivity", false, "Ping each configured host concurrently and report latency and TLS state")
+	cmdutil.AddFormatFlag(cmd, &opts.Output)
 
 	return cmd
 }
 
+// swappable for tests
+var statusNow = time.Now
+
 func statusRun(opts *StatusOptions) error {
 	cfg, err := opts.Config()
 	if err != nil {
@@ -70,6 +105,51 @@ func statusRun(opts *StatusOptions) error {
 		return cmdutil.SilentError
 	}
 
+	if opts.Output == "json" || opts.Output == "yaml" {
+		var entries []statusEntry
+		for _, hostname := range hostnames {
+			if opts.Hostname != "" && opts.Hostname != hostname {
+				continue
+			}
+			expiry, source, _ := cfg.GetWithSource(hostname, "expiry")
+			token, _ := cfg.Get(hostname, "access_token")
+			user, _ := cfg.Get(hostname, "user")
+
+			// a token is considered valid while its recorded expiry, if any,
+			// is still in the future
+			tokenValid := token != ""
+			if t, err := time.Parse(time.RFC1123, expiry); err == nil && time.Now().After(t) {
+				tokenValid = false
+			}
+
+			scopes := []string{}
+			if raw, _ := cfg.Get(hostname, "oauth_scopes"); raw != "" {
+				scopes = strings.FieldsFunc(raw, func(r rune) bool {
+					return r == ',' || r == ' '
+				})
+			}
+
+			entry := statusEntry{
+				Host:        hostname,
+				User:        user,
+				TokenValid:  tokenValid,
+				Scopes:      scopes,
+				TokenExpiry: expiry,
+				TokenSource: source,
+			}
+			if opts.ShowToken {
+				entry.Token = token
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) == 0 {
+			fmt.Fprintf(stderr,
+				"Hostname %q not found among authenticated Instill Core/Cloud hosts\n", opts.Hostname)
+			return cmdutil.SilentError
+		}
+		return cmdutil.RenderOutput(opts.IO, opts.Output, nil, nil, entries)
+	}
+
 	var failed bool
 	var isHostnameFound bool
 
@@ -89,7 +169,14 @@ func statusRun(opts *StatusOptions) error {
 
 		addMsg("%s Logged in to %s (%s)", cs.SuccessIcon(), hostname, accessTokenSource)
 		addMsg("%s Access token expires on: %s", cs.SuccessIcon(), cs.Bold(accessTokenExpire))
-
+		if t, err := time.Parse(time.RFC1123, accessTokenExpire); err == nil {
+			if remaining := t.Sub(statusNow()); remaining > 0 {
+				addMsg("%s Token lifetime remaining: %s", cs.SuccessIcon(), cs.Bold(remaining.Round(time.Minute).String()))
+			} else {
+				addMsg("%s Token has expired; run %s to renew it", cs.FailureIcon(), cs.Bold("inst auth refresh"))
+				failed = true
+			}
+		}
 	}
 
 	if !isHostnameFound {
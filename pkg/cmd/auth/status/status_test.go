@@ -2,12 +2,18 @@ package status
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
 func Test_NewCmdStatus(t *testing.T) {
@@ -28,6 +34,13 @@ func Test_NewCmdStatus(t *testing.T) {
 				Hostname: "ellie.williams",
 			},
 		},
+		{
+			name: "check connectivity",
+			cli:  "--check-connectivity",
+			wants: StatusOptions{
+				CheckConnectivity: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -55,6 +68,115 @@ func Test_NewCmdStatus(t *testing.T) {
 			assert.NoError(t, err)
 
 			assert.Equal(t, tt.wants.Hostname, gotOpts.Hostname)
+			assert.Equal(t, tt.wants.CheckConnectivity, gotOpts.CheckConnectivity)
 		})
 	}
 }
+
+func Test_statusRun_tokenLifetime(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	statusNow = func() time.Time { return now }
+	defer func() { statusNow = time.Now }()
+
+	hostConfig := func(expiry time.Time) func() (config.Config, error) {
+		return func() (config.Config, error) {
+			return config.MustNewFromString(heredoc.Docf(`
+				hosts:
+				  instill.tech:
+				    access_token: TOKEN123
+				    expiry: %s
+			`, expiry.Format(time.RFC1123))), nil
+		}
+	}
+
+	t.Run("remaining lifetime", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		opts := &StatusOptions{IO: io, Config: hostConfig(now.Add(2 * time.Hour))}
+
+		require.NoError(t, statusRun(opts))
+		assert.Contains(t, stderr.String(), "Token lifetime remaining: 2h0m0s")
+	})
+
+	t.Run("expired token suggests a refresh", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		opts := &StatusOptions{IO: io, Config: hostConfig(now.Add(-time.Hour))}
+
+		assert.Equal(t, cmdutil.SilentError, statusRun(opts))
+		assert.Contains(t, stderr.String(), "Token has expired; run inst auth refresh to renew it")
+	})
+}
+
+func Test_statusRun_json(t *testing.T) {
+	configYML := heredoc.Doc(`
+		hosts:
+		  instill.tech:
+		    access_token: TOKEN123
+		    expiry: Mon, 02 Jan 2034 15:04:05 UTC
+		    user: zubeen
+		    oauth_scopes: openid profile
+	`)
+
+	t.Run("token redacted by default", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &StatusOptions{
+			IO:     io,
+			Output: "json",
+			Config: func() (config.Config, error) {
+				return config.MustNewFromString(configYML), nil
+			},
+		}
+
+		require.NoError(t, statusRun(opts))
+
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "instill.tech", entries[0]["host"])
+		assert.Equal(t, "zubeen", entries[0]["user"])
+		assert.Equal(t, true, entries[0]["tokenValid"])
+		assert.Equal(t, []interface{}{"openid", "profile"}, entries[0]["scopes"])
+		assert.NotContains(t, entries[0], "token")
+	})
+
+	t.Run("token included with --show-token", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &StatusOptions{
+			IO:        io,
+			Output:    "json",
+			ShowToken: true,
+			Config: func() (config.Config, error) {
+				return config.MustNewFromString(configYML), nil
+			},
+		}
+
+		require.NoError(t, statusRun(opts))
+
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "TOKEN123", entries[0]["token"])
+	})
+
+	t.Run("expired token is not valid", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &StatusOptions{
+			IO:     io,
+			Output: "json",
+			Config: func() (config.Config, error) {
+				return config.MustNewFromString(heredoc.Doc(`
+					hosts:
+					  instill.tech:
+					    access_token: TOKEN123
+					    expiry: Mon, 02 Jan 2006 15:04:05 UTC
+				`)), nil
+			},
+		}
+
+		require.NoError(t, statusRun(opts))
+
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, false, entries[0]["tokenValid"])
+	})
+}
@@ -0,0 +1,123 @@
+package authswitch
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+type SwitchOptions struct {
+	IO       *iostreams.IOStreams
+	Config   func() (config.Config, error)
+	Hostname string
+}
+
+func NewCmdSwitch(f *cmdutil.Factory, runF func(*SwitchOptions) error) *cobra.Command {
+	opts := &SwitchOptions{
+		IO:     f.IOStreams,
+		Config: f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "switch [<hostname>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Switch the default Instill Core/Cloud host",
+		Long: heredoc.Doc(`Make another configured host the default one.
+
+			This is a friendlier front-end over the default_hostname config value:
+			the host is picked from the configured ones, either as an argument or
+			interactively, instead of being typed out in full.
+		`),
+		Example: heredoc.Doc(`
+			$ inst auth switch
+			# => select the new default host via a prompt
+
+			$ inst auth switch api.instill.tech
+			# => make the given host the default
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				hostname, err := cmdutil.NormalizeHostname(args[0])
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid hostname %q: %v", args[0], err)
+				}
+				opts.Hostname = hostname
+			}
+
+			if opts.Hostname == "" && !opts.IO.CanPrompt() {
+				return cmdutil.FlagErrorf("a hostname argument is required when not running interactively")
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return switchRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func switchRun(opts *SwitchOptions) error {
+	hostname := opts.Hostname
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	candidates, err := cfg.Hosts()
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no hosts are configured")
+	}
+
+	if hostname == "" {
+		err = prompt.SurveyAskOne(&survey.Select{
+			Message: "Which host should be the default?",
+			Options: candidates,
+			Default: cfg.DefaultHostname(),
+		}, &hostname)
+		if err != nil {
+			return fmt.Errorf("could not prompt: %w", err)
+		}
+	} else {
+		var found bool
+		for _, c := range candidates {
+			if c == hostname {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("instance '%s' is not configured", hostname)
+		}
+	}
+
+	cs := opts.IO.ColorScheme()
+
+	if hostname == cfg.DefaultHostname() {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %s is already the default host\n", cs.SuccessIcon(), cs.Bold(hostname))
+		return nil
+	}
+
+	if err := cfg.Set("", "default_hostname", hostname); err != nil {
+		return err
+	}
+	if err := cfg.Write(); err != nil {
+		return fmt.Errorf("failed to write config, default host not updated: %w", err)
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%s Switched the default host to %s\n", cs.SuccessIcon(), cs.Bold(hostname))
+	return nil
+}
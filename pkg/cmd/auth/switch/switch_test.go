@@ -0,0 +1,187 @@
+package authswitch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+func Test_NewCmdSwitch(t *testing.T) {
+	tests := []struct {
+		name     string
+		cli      string
+		wants    SwitchOptions
+		wantsErr bool
+		tty      bool
+	}{
+		{
+			name: "tty with hostname",
+			tty:  true,
+			cli:  "harry.mason",
+			wants: SwitchOptions{
+				Hostname: "harry.mason",
+			},
+		},
+		{
+			name: "tty no arguments",
+			tty:  true,
+			cli:  "",
+			wants: SwitchOptions{
+				Hostname: "",
+			},
+		},
+		{
+			name: "hostname is normalized",
+			tty:  true,
+			cli:  "https://Harry.Mason/path",
+			wants: SwitchOptions{
+				Hostname: "harry.mason",
+			},
+		},
+		{
+			name: "nontty with hostname",
+			cli:  "harry.mason",
+			wants: SwitchOptions{
+				Hostname: "harry.mason",
+			},
+		},
+		{
+			name:     "nontty no arguments",
+			cli:      "",
+			wantsErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, _ := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: io,
+			}
+			io.SetStdinTTY(tt.tty)
+			io.SetStdoutTTY(tt.tty)
+
+			argv, err := shlex.Split(tt.cli)
+			assert.NoError(t, err)
+
+			var gotOpts *SwitchOptions
+			cmd := NewCmdSwitch(f, func(opts *SwitchOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			// TODO cobra hack-around
+			cmd.Flags().BoolP("help", "x", false, "")
+
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wants.Hostname, gotOpts.Hostname)
+		})
+
+	}
+}
+
+func Test_switchRun(t *testing.T) {
+	configYaml := heredoc.Doc(`
+		default_hostname: harry.mason
+		hosts:
+		  harry.mason:
+		    access_token: TOKEN1
+		  james.sunderland:
+		    access_token: TOKEN2
+	`)
+
+	tests := []struct {
+		name       string
+		opts       *SwitchOptions
+		askStubs   func(*prompt.AskStubber)
+		config     string
+		wantErr    string
+		wantStderr string
+		wantHost   string
+	}{
+		{
+			name:       "switch to a configured host",
+			opts:       &SwitchOptions{Hostname: "james.sunderland"},
+			config:     configYaml,
+			wantStderr: "✓ Switched the default host to james.sunderland\n",
+			wantHost:   "james.sunderland",
+		},
+		{
+			name:       "host is already the default",
+			opts:       &SwitchOptions{Hostname: "harry.mason"},
+			config:     configYaml,
+			wantStderr: "✓ harry.mason is already the default host\n",
+			wantHost:   "harry.mason",
+		},
+		{
+			name:    "host is not configured",
+			opts:    &SwitchOptions{Hostname: "heather.mason"},
+			config:  configYaml,
+			wantErr: "instance 'heather.mason' is not configured",
+		},
+		{
+			name:    "no hosts configured",
+			opts:    &SwitchOptions{Hostname: "harry.mason"},
+			config:  "",
+			wantErr: "no hosts are configured",
+		},
+		{
+			name: "prompts when no host is given",
+			opts: &SwitchOptions{},
+			askStubs: func(as *prompt.AskStubber) {
+				as.StubOne("james.sunderland")
+			},
+			config:     configYaml,
+			wantStderr: "✓ Switched the default host to james.sunderland\n",
+			wantHost:   "james.sunderland",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, stderr := iostreams.Test()
+			tt.opts.IO = io
+
+			cfg := config.MustNewFromString(tt.config)
+			tt.opts.Config = func() (config.Config, error) {
+				return cfg, nil
+			}
+
+			as, teardown := prompt.InitAskStubber()
+			defer teardown()
+			if tt.askStubs != nil {
+				tt.askStubs(as)
+			}
+
+			err := switchRun(tt.opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantStderr, stderr.String())
+
+			hostname, err := cfg.Get("", "default_hostname")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantHost, hostname)
+		})
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 
+	"github.com/instill-ai/cli/internal/build"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
 func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 	var shellType string
+	var noDescriptions bool
 
 	cmd := &cobra.Command{
 		Use:   "completion -s <shell>",
@@ -23,9 +25,10 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 			no additional shell configuration is necessary to gain completion support. For
 			Homebrew, see <https://docs.brew.sh/Shell-Completion>
 
-			If you need to set up completions manually, follow the instructions below. The exact
-			config file locations might vary based on your system. Make sure to restart your
-			shell before testing whether completions are working.
+			If you need to set up completions manually, follow the instructions below, or run
+			%[1]s%[2]s completion install%[1]s to write the script to the conventional location
+			for your shell. The exact config file locations might vary based on your system.
+			Make sure to restart your shell before testing whether completions are working.
 
 			### bash
 
@@ -33,13 +36,13 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 
 			After, add this to your %[1]s~/.bash_profile%[1]s:
 
-				eval "$(inst completion -s bash)"
+				eval "$(%[2]s completion -s bash)"
 
 			### zsh
 
-			Generate a %[1]s_inst%[1]s completion script and put it somewhere in your %[1]s$fpath%[1]s:
+			Generate a %[1]s_%[2]s%[1]s completion script and put it somewhere in your %[1]s$fpath%[1]s:
 
-				inst completion -s zsh > /usr/local/share/zsh/site-functions/_inst
+				%[2]s completion -s zsh > /usr/local/share/zsh/site-functions/_%[2]s
 
 			Ensure that the following is present in your %[1]s~/.zshrc%[1]s:
 
@@ -50,9 +53,9 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 
 			### fish
 
-			Generate a %[1]sinst.fish%[1]s completion script:
+			Generate a %[1]s%[2]s.fish%[1]s completion script:
 
-				inst completion -s fish > ~/.config/fish/completions/inst.fish
+				%[2]s completion -s fish > ~/.config/fish/completions/%[2]s.fish
 
 			### PowerShell
 
@@ -63,8 +66,8 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 
 			Add the line and save the file:
 
-				Invoke-Expression -Command $(inst completion -s powershell | Out-String)
-		`, "`"),
+				Invoke-Expression -Command $(%[2]s completion -s powershell | Out-String)
+		`, "`", build.ExecutableName),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if shellType == "" {
 				if io.IsStdoutTTY() {
@@ -78,13 +81,19 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 
 			switch shellType {
 			case "bash":
-				return rootCmd.GenBashCompletionV2(w, true)
+				return rootCmd.GenBashCompletionV2(w, !noDescriptions)
 			case "zsh":
+				if noDescriptions {
+					return rootCmd.GenZshCompletionNoDesc(w)
+				}
 				return rootCmd.GenZshCompletion(w)
 			case "powershell":
+				if noDescriptions {
+					return rootCmd.GenPowerShellCompletion(w)
+				}
 				return rootCmd.GenPowerShellCompletionWithDesc(w)
 			case "fish":
-				return rootCmd.GenFishCompletion(w, true)
+				return rootCmd.GenFishCompletion(w, !noDescriptions)
 			default:
 				return fmt.Errorf("unsupported shell type %q", shellType)
 			}
@@ -94,7 +103,10 @@ func NewCmdCompletion(io *iostreams.IOStreams) *cobra.Command {
 
 	cmdutil.DisableAuthCheck(cmd)
 
+	cmd.AddCommand(newCmdCompletionInstall(io))
+
 	cmd.Flags().StringVarP(&shellType, "shell", "s", "", "Shell type: {bash|zsh|fish|powershell}")
+	cmd.Flags().BoolVar(&noDescriptions, "no-descriptions", false, "Generate completions without descriptions, for shells where they break parsing")
 
 	return cmd
 }
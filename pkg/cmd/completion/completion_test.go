@@ -25,7 +25,22 @@ func TestNewCmdCompletion(t *testing.T) {
 		{
 			name:    "zsh completion",
 			args:    "completion -s zsh",
-			wantOut: "compdef _inst inst",
+			wantOut: "#compdef inst",
+		},
+		{
+			name:    "zsh completion without descriptions",
+			args:    "completion -s zsh --no-descriptions",
+			wantOut: "#compdef inst",
+		},
+		{
+			name:    "bash completion without descriptions",
+			args:    "completion -s bash --no-descriptions",
+			wantOut: "complete -o default -F __start_inst inst",
+		},
+		{
+			name:    "fish completion without descriptions",
+			args:    "completion -s fish --no-descriptions",
+			wantOut: "complete -c inst",
 		},
 		{
 			name:    "fish completion",
@@ -0,0 +1,129 @@
+package completion
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/build"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type installOptions struct {
+	io     *iostreams.IOStreams
+	shell  string
+	dryRun bool
+}
+
+func newCmdCompletionInstall(io *iostreams.IOStreams) *cobra.Command {
+	opts := &installOptions{io: io}
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the completion script for your shell",
+		Long: heredoc.Docf(`
+			Write the completion script to the conventional location for your shell,
+			so completions work without piping the script around by hand.
+
+			The shell is detected from the SHELL environment variable unless
+			%[1]s--shell%[1]s selects one explicitly; bash, zsh, and fish are supported.
+			When the shell needs an extra line in its rc file to pick the script up,
+			the line is printed after installing. %[1]s--dry-run%[1]s only shows the
+			target path without writing anything.
+		`, "`"),
+		Example: heredoc.Docf(`
+			# install completions for the current shell
+			$ %[1]s completion install
+
+			# see where the zsh script would go
+			$ %[1]s completion install --shell zsh --dry-run
+		`, build.ExecutableName),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.shell == "" {
+				opts.shell = filepath.Base(os.Getenv("SHELL"))
+			}
+			if opts.shell == "" || opts.shell == "." {
+				return cmdutil.FlagErrorf("could not detect the shell; pass `--shell` explicitly")
+			}
+			return installRun(cmd.Parent().Parent(), opts)
+		},
+	}
+
+	cmdutil.DisableAuthCheck(cmd)
+
+	cmd.Flags().StringVarP(&opts.shell, "shell", "s", "", "Shell type: {bash|zsh|fish}")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the target path without writing the script")
+
+	return cmd
+}
+
+func installRun(rootCmd *cobra.Command, opts *installOptions) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	target, rcFile, rcLine, err := installTarget(opts.shell, home)
+	if err != nil {
+		return err
+	}
+
+	cs := opts.io.ColorScheme()
+
+	if opts.dryRun {
+		fmt.Fprintf(opts.io.Out, "would write the %s completion script to %s\n", opts.shell, target)
+	} else {
+		script := &bytes.Buffer{}
+		switch opts.shell {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(script, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(script)
+		case "fish":
+			err = rootCmd.GenFishCompletion(script, true)
+		}
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, script.Bytes(), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.io.Out, "%s wrote the %s completion script to %s\n", cs.SuccessIcon(), opts.shell, target)
+	}
+
+	if rcLine != "" {
+		fmt.Fprintf(opts.io.Out, "ensure this is present in your %s, then restart the shell:\n\n  %s\n", rcFile, rcLine)
+	} else {
+		fmt.Fprintln(opts.io.Out, "restart the shell for completions to take effect")
+	}
+	return nil
+}
+
+// installTarget returns the conventional completion script location for a
+// shell, plus the rc file and line the user must have for the script to load,
+// when the shell does not pick the directory up on its own.
+func installTarget(shell, home string) (target, rcFile, rcLine string, err error) {
+	exe := build.ExecutableName
+	switch shell {
+	case "bash":
+		// loaded on demand by the bash-completion package
+		return filepath.Join(home, ".local", "share", "bash-completion", "completions", exe), "", "", nil
+	case "zsh":
+		return filepath.Join(home, ".zsh", "completions", "_"+exe),
+			"~/.zshrc",
+			"fpath=(~/.zsh/completions $fpath); autoload -U compinit && compinit",
+			nil
+	case "fish":
+		return filepath.Join(home, ".config", "fish", "completions", exe+".fish"), "", "", nil
+	default:
+		return "", "", "", fmt.Errorf("unsupported shell type %q", shell)
+	}
+}
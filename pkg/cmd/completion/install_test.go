@@ -0,0 +1,126 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdCompletionInstall(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		shellEnv string
+		wantOut  []string
+		wantFile string
+		wantIn   string
+		wantErr  string
+	}{
+		{
+			name:     "zsh install",
+			args:     "completion install -s zsh",
+			wantOut:  []string{"wrote the zsh completion script to", "~/.zshrc", "fpath=(~/.zsh/completions $fpath)"},
+			wantFile: filepath.Join(".zsh", "completions", "_inst"),
+			wantIn:   "#compdef inst",
+		},
+		{
+			name:     "fish install",
+			args:     "completion install -s fish",
+			wantOut:  []string{"wrote the fish completion script to", "restart the shell"},
+			wantFile: filepath.Join(".config", "fish", "completions", "inst.fish"),
+			wantIn:   "complete -c inst",
+		},
+		{
+			name:     "bash install",
+			args:     "completion install -s bash",
+			wantOut:  []string{"wrote the bash completion script to"},
+			wantFile: filepath.Join(".local", "share", "bash-completion", "completions", "inst"),
+			wantIn:   "__start_inst",
+		},
+		{
+			name:     "shell detected from the environment",
+			args:     "completion install --dry-run",
+			shellEnv: "/usr/bin/zsh",
+			wantOut:  []string{"would write the zsh completion script to", filepath.Join(".zsh", "completions", "_inst")},
+		},
+		{
+			name:    "dry run writes nothing",
+			args:    "completion install -s fish --dry-run",
+			wantOut: []string{"would write the fish completion script to"},
+		},
+		{
+			name:    "unsupported shell",
+			args:    "completion install -s powershell",
+			wantErr: "unsupported shell type \"powershell\"",
+		},
+		{
+			name:    "shell cannot be detected",
+			args:    "completion install",
+			wantErr: "could not detect the shell; pass `--shell` explicitly",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("SHELL", tt.shellEnv)
+
+			io, _, stdout, stderr := iostreams.Test()
+			completeCmd := NewCmdCompletion(io)
+			rootCmd := &cobra.Command{Use: "inst"}
+			rootCmd.AddCommand(completeCmd)
+
+			argv, err := shlex.Split(tt.args)
+			if err != nil {
+				t.Fatalf("argument splitting error: %v", err)
+			}
+			rootCmd.SetArgs(argv)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+
+			_, err = rootCmd.ExecuteC()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %q", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error executing command: %v", err)
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output did not contain %q:\n%s", want, stdout.String())
+				}
+			}
+			if len(stderr.String()) > 0 {
+				t.Errorf("expected nothing on stderr, got %q", stderr.String())
+			}
+
+			if tt.wantFile != "" {
+				data, err := os.ReadFile(filepath.Join(home, tt.wantFile))
+				if err != nil {
+					t.Fatalf("error reading installed script: %v", err)
+				}
+				if !strings.Contains(string(data), tt.wantIn) {
+					t.Errorf("installed script did not contain %q", tt.wantIn)
+				}
+			} else {
+				entries, err := os.ReadDir(home)
+				if err != nil {
+					t.Fatalf("error listing home directory: %v", err)
+				}
+				if len(entries) > 0 {
+					t.Errorf("expected nothing written under the home directory, found %v", entries)
+				}
+			}
+		})
+	}
+}
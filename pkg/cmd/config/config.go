@@ -9,8 +9,20 @@ import (
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 
+	cmdDefaults "github.com/instill-ai/cli/pkg/cmd/config/defaults"
+	cmdDoctor "github.com/instill-ai/cli/pkg/cmd/config/doctor"
+	cmdEdit "github.com/instill-ai/cli/pkg/cmd/config/edit"
+	cmdExport "github.com/instill-ai/cli/pkg/cmd/config/export"
 	cmdGet "github.com/instill-ai/cli/pkg/cmd/config/get"
+	cmdHosts "github.com/instill-ai/cli/pkg/cmd/config/hosts"
+	cmdImport "github.com/instill-ai/cli/pkg/cmd/config/import"
+	cmdMigrate "github.com/instill-ai/cli/pkg/cmd/config/migrate"
+	cmdPath "github.com/instill-ai/cli/pkg/cmd/config/path"
+	cmdPrompt "github.com/instill-ai/cli/pkg/cmd/config/prompt"
+	cmdRenameHost "github.com/instill-ai/cli/pkg/cmd/config/rename-host"
+	cmdSchema "github.com/instill-ai/cli/pkg/cmd/config/schema"
 	cmdSet "github.com/instill-ai/cli/pkg/cmd/config/set"
+	cmdValidate "github.com/instill-ai/cli/pkg/cmd/config/validate"
 )
 
 func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
@@ -35,6 +47,19 @@ func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
 
 	cmd.AddCommand(cmdGet.NewCmdConfigGet(f, nil))
 	cmd.AddCommand(cmdSet.NewCmdConfigSet(f, nil))
+	cmd.AddCommand(cmdHosts.NewCmdConfigHosts(f, nil))
+	cmd.AddCommand(cmdEdit.NewCmdConfigEdit(f, nil))
+	cmd.AddCommand(cmdValidate.NewCmdConfigValidate(f, nil))
+	cmd.AddCommand(cmdDoctor.NewCmdConfigDoctor(f, nil))
+	cmd.AddCommand(cmdRenameHost.NewCmdConfigRenameHost(f, nil))
+	cmd.AddCommand(cmdMigrate.NewCmdConfigMigrate(f, nil))
+	cmd.AddCommand(cmdDefaults.NewCmdConfigDefaults(f, nil))
+	cmd.AddCommand(cmdImport.NewCmdConfigImport(f, nil))
+	cmd.AddCommand(cmdExport.NewCmdConfigExport(f, nil))
+	cmd.AddCommand(cmdPath.NewCmdConfigPath(f, nil))
+	cmd.AddCommand(cmdSchema.NewCmdConfigSchema(f, nil))
+	cmd.AddCommand(cmdPrompt.NewCmdConfigEnablePrompt(f, nil))
+	cmd.AddCommand(cmdPrompt.NewCmdConfigDisablePrompt(f, nil))
 
 	return cmd
 }
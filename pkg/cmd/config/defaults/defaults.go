@@ -0,0 +1,115 @@
+package defaults
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type DefaultsOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	Keys     []string
+	Hostname string
+	Yes      bool
+}
+
+func NewCmdConfigDefaults(f *cmdutil.Factory, runF func(*DefaultsOptions) error) *cobra.Command {
+	opts := &DefaultsOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "defaults [<key>...]",
+		Short: "Reset configuration keys to their built-in default values",
+		Long: heredoc.Doc(`
+			Reset the named configuration keys to their built-in default values.
+			When no keys are given, every known key is reset; this asks for
+			confirmation unless --yes is passed.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config defaults editor
+			$ inst config defaults protocol prompt
+			$ inst config defaults --yes
+		`),
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: cmdutil.ConfigKeyCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+			opts.Keys = args
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return defaultsRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Hostname, "host", "h", "", "Reset per-host settings")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Reset all keys without confirmation")
+
+	return cmd
+}
+
+func defaultsRun(opts *DefaultsOptions) error {
+	keys := opts.Keys
+	if len(keys) == 0 {
+		if !opts.Yes {
+			if !opts.IO.CanPrompt() {
+				return fmt.Errorf("resetting all keys requires `--yes` when prompts are disabled")
+			}
+			confirmed, err := cmdutil.Confirm(opts.IO, "Reset all configuration keys to their default values?", false)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintf(opts.IO.ErrOut, "%s nothing was reset\n", opts.IO.ColorScheme().WarningIcon())
+				return cmdutil.SilentError
+			}
+		}
+		for _, co := range config.ConfigOptions() {
+			keys = append(keys, co.Key)
+		}
+	}
+
+	cs := opts.IO.ColorScheme()
+	for _, key := range keys {
+		defaultValue, found := defaultValueFor(key)
+		if !found {
+			return fmt.Errorf("unknown configuration key %q", key)
+		}
+		if err := opts.Config.Set(opts.Hostname, key, defaultValue); err != nil {
+			return fmt.Errorf("failed to reset %q: %w", key, err)
+		}
+		if defaultValue == "" {
+			fmt.Fprintf(opts.IO.Out, "%s reset %s\n", cs.SuccessIcon(), key)
+		} else {
+			fmt.Fprintf(opts.IO.Out, "%s reset %s to %q\n", cs.SuccessIcon(), key, defaultValue)
+		}
+	}
+
+	if err := opts.Config.Write(); err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+	return nil
+}
+
+func defaultValueFor(key string) (string, bool) {
+	for _, co := range config.ConfigOptions() {
+		if co.Key == key {
+			return co.DefaultValue, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,178 @@
+package defaults
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+func TestNewCmdConfigDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		output   DefaultsOptions
+		wantsErr bool
+	}{
+		{
+			name:   "no arguments",
+			input:  "",
+			output: DefaultsOptions{},
+		},
+		{
+			name:   "single key",
+			input:  "editor",
+			output: DefaultsOptions{Keys: []string{"editor"}},
+		},
+		{
+			name:   "multiple keys with host",
+			input:  "editor prompt --host test.com",
+			output: DefaultsOptions{Keys: []string{"editor", "prompt"}, Hostname: "test.com"},
+		},
+		{
+			name:   "yes flag",
+			input:  "--yes",
+			output: DefaultsOptions{Yes: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				Config: config.ConfigStubFactory,
+			}
+
+			argv, err := shlex.Split(tt.input)
+			assert.NoError(t, err)
+
+			var gotOpts *DefaultsOptions
+			cmd := NewCmdConfigDefaults(f, func(opts *DefaultsOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.Flags().BoolP("help", "x", false, "")
+
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tt.output.Keys, gotOpts.Keys)
+			assert.Equal(t, tt.output.Hostname, gotOpts.Hostname)
+			assert.Equal(t, tt.output.Yes, gotOpts.Yes)
+		})
+	}
+}
+
+func Test_defaultsRun(t *testing.T) {
+	t.Run("single key", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		cfg := config.ConfigStub{"editor": "vim"}
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: cfg,
+			Keys:   []string{"editor"},
+		}
+
+		assert.NoError(t, defaultsRun(opts))
+		assert.Equal(t, "", cfg["editor"])
+		assert.Equal(t, "true", cfg["_written"])
+		assert.Equal(t, "✓ reset editor\n", stdout.String())
+	})
+
+	t.Run("key with non-empty default", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		cfg := config.ConfigStub{"protocol": "ssh"}
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: cfg,
+			Keys:   []string{"protocol"},
+		}
+
+		assert.NoError(t, defaultsRun(opts))
+		assert.Equal(t, "https", cfg["protocol"])
+		assert.Equal(t, "✓ reset protocol to \"https\"\n", stdout.String())
+	})
+
+	t.Run("host-scoped key", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		cfg := config.ConfigStub{"test.com:protocol": "ssh"}
+		opts := &DefaultsOptions{
+			IO:       io,
+			Config:   cfg,
+			Keys:     []string{"protocol"},
+			Hostname: "test.com",
+		}
+
+		assert.NoError(t, defaultsRun(opts))
+		assert.Equal(t, "https", cfg["test.com:protocol"])
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: config.ConfigStub{},
+			Keys:   []string{"bogus"},
+		}
+
+		assert.EqualError(t, defaultsRun(opts), `unknown configuration key "bogus"`)
+	})
+
+	t.Run("all keys require --yes when prompts are disabled", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: config.ConfigStub{},
+		}
+
+		assert.EqualError(t, defaultsRun(opts), "resetting all keys requires `--yes` when prompts are disabled")
+	})
+
+	t.Run("all keys with --yes", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		cfg := config.ConfigStub{"editor": "vim", "protocol": "ssh"}
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: cfg,
+			Yes:    true,
+		}
+
+		assert.NoError(t, defaultsRun(opts))
+		assert.Equal(t, "", cfg["editor"])
+		assert.Equal(t, "https", cfg["protocol"])
+	})
+
+	t.Run("all keys declined at the prompt", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		io.SetStdinTTY(true)
+		io.SetStdoutTTY(true)
+
+		as, teardown := prompt.InitAskStubber()
+		defer teardown()
+		as.StubOne(false)
+
+		cfg := config.ConfigStub{"editor": "vim"}
+		opts := &DefaultsOptions{
+			IO:     io,
+			Config: cfg,
+		}
+
+		assert.Equal(t, cmdutil.SilentError, defaultsRun(opts))
+		assert.Equal(t, "vim", cfg["editor"])
+		assert.Equal(t, "! nothing was reset\n", stderr.String())
+	})
+}
@@ -0,0 +1,279 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type DoctorOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	Fix bool
+	Yes bool
+
+	// the files to inspect, overridable in tests
+	ConfigFile string
+	HostsFile  string
+}
+
+func NewCmdConfigDoctor(f *cmdutil.Factory, runF func(*DoctorOptions) error) *cobra.Command {
+	opts := &DoctorOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common configuration problems",
+		Long: heredoc.Doc(`
+			Check that the configuration files parse, that their keys and values are
+			valid, and that they are writable by the current user.
+
+			Permission problems come with actionable hints; a config file owned by
+			root after running inst under sudo is the usual culprit. Exits non-zero
+			when any problem is found.
+
+			With --fix, safe problems are repaired in place: file permissions are
+			tightened to 0600, a missing trailing newline is added, and option values
+			that only differ from an allowed value by casing are normalized. Removing
+			unknown keys is destructive and additionally requires --yes; without it,
+			they are reported and left in place. Each applied fix is reported.
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Yes && !opts.Fix {
+				return cmdutil.FlagErrorf("`--yes` requires `--fix`")
+			}
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = cfg
+			if opts.ConfigFile == "" {
+				opts.ConfigFile = config.ConfigFile()
+				opts.HostsFile = config.HostsConfigFile()
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return doctorRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Fix, "fix", false, "Repair safe problems in place and report each fix")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "With `--fix`, also apply destructive fixes such as removing unknown keys")
+
+	return cmd
+}
+
+func doctorRun(opts *DoctorOptions) error {
+	cs := opts.IO.ColorScheme()
+	problems := 0
+
+	removed := map[string]bool{}
+	if opts.Fix {
+		for _, fn := range []string{opts.ConfigFile, opts.HostsFile} {
+			if fn == "" {
+				continue
+			}
+			fixFile(opts.IO, fn)
+		}
+		removed = fixConfigValues(opts)
+	}
+
+	for _, fn := range []string{opts.ConfigFile, opts.HostsFile} {
+		if fn == "" {
+			continue
+		}
+		problems += checkFile(opts.IO, fn)
+	}
+
+	for _, key := range opts.Config.UnknownKeys() {
+		if removed[key] {
+			continue
+		}
+		if opts.Fix && !opts.Yes {
+			fmt.Fprintf(opts.IO.ErrOut, "%s unknown config key %q left in place; re-run with `--yes` to remove it\n", cs.WarningIcon(), key)
+			continue
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "%s unknown config key %q\n", cs.WarningIcon(), key)
+	}
+
+	for _, co := range config.ConfigOptions() {
+		val, err := opts.Config.Get("", co.Key)
+		if err != nil {
+			continue
+		}
+		if err := config.ValidateValue(co.Key, val); err != nil {
+			var invalidValue *config.InvalidValueError
+			if errors.As(err, &invalidValue) {
+				if invalidValue.ExpectedType != "" {
+					fmt.Fprintf(opts.IO.ErrOut, "%s invalid value %q for %q: expected %s\n",
+						cs.FailureIcon(), val, co.Key, invalidValue.ExpectedType.Description())
+				} else {
+					fmt.Fprintf(opts.IO.ErrOut, "%s invalid value %q for %q: valid values are %s\n",
+						cs.FailureIcon(), val, co.Key, strings.Join(invalidValue.ValidValues, ", "))
+				}
+				problems++
+			}
+		}
+	}
+
+	// the hosts file backs every host-scoped key
+	if err := opts.Config.CheckWriteable("", ""); err != nil {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %v\n", cs.FailureIcon(), err)
+		problems++
+	}
+	if hosts, err := opts.Config.Hosts(); err == nil && len(hosts) > 0 {
+		if err := opts.Config.CheckWriteable(hosts[0], ""); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s %v\n", cs.FailureIcon(), err)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		return cmdutil.SilentError
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s no problems found\n", cs.SuccessIcon())
+	return nil
+}
+
+// checkFile verifies that fn parses as YAML and is writable by the current
+// user, and returns the number of problems found.
+func checkFile(io *iostreams.IOStreams, fn string) int {
+	cs := io.ColorScheme()
+
+	data, err := os.ReadFile(fn)
+	if os.IsNotExist(err) {
+		fmt.Fprintf(io.Out, "%s %s: not present (will be created on demand)\n", cs.SuccessIcon(), fn)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "%s %s: %v\n", cs.FailureIcon(), fn, err)
+		if os.IsPermission(err) {
+			printOwnershipHint(io, fn)
+		}
+		return 1
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		fmt.Fprintf(io.ErrOut, "%s %s: does not parse: %v\n", cs.FailureIcon(), fn, err)
+		return 1
+	}
+
+	f, err := os.OpenFile(fn, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "%s %s: not writeable: %v\n", cs.FailureIcon(), fn, err)
+		if os.IsPermission(err) {
+			printOwnershipHint(io, fn)
+		}
+		return 1
+	}
+	f.Close()
+
+	fmt.Fprintf(io.Out, "%s %s: ok\n", cs.SuccessIcon(), fn)
+	return 0
+}
+
+// fixFile repairs file-level problems that are always safe: permissions wider
+// than 0600 and a missing trailing newline. Failures are left for checkFile to
+// report.
+func fixFile(io *iostreams.IOStreams, fn string) {
+	cs := io.ColorScheme()
+
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		if err := os.Chmod(fn, 0600); err == nil {
+			fmt.Fprintf(io.Out, "%s fixed %s: tightened permissions to 0600\n", cs.SuccessIcon(), fn)
+		}
+	}
+
+	data, err := os.ReadFile(fn)
+	if err != nil || len(data) == 0 || data[len(data)-1] == '\n' {
+		return
+	}
+	if err := os.WriteFile(fn, append(data, '\n'), 0600); err == nil {
+		fmt.Fprintf(io.Out, "%s fixed %s: added a missing trailing newline\n", cs.SuccessIcon(), fn)
+	}
+}
+
+// fixConfigValues rewrites the main config file to normalize the casing of
+// invalid option values and, with --yes, to drop unknown keys. It returns the
+// set of keys it removed so they are not re-reported.
+func fixConfigValues(opts *DoctorOptions) map[string]bool {
+	cs := opts.IO.ColorScheme()
+	removed := map[string]bool{}
+
+	data, err := os.ReadFile(opts.ConfigFile)
+	if err != nil {
+		return removed
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return removed
+	}
+
+	changed := false
+	content := doc.Content[0].Content
+	var kept []*yaml.Node
+	for i := 0; i < len(content)-1; i += 2 {
+		key, valNode := content[i], content[i+1]
+		if !isStructural(key.Value) && config.ValidateKey(key.Value) != nil {
+			if opts.Yes {
+				fmt.Fprintf(opts.IO.Out, "%s fixed %s: removed unknown key %q\n", cs.SuccessIcon(), opts.ConfigFile, key.Value)
+				removed[key.Value] = true
+				changed = true
+				continue
+			}
+			kept = append(kept, key, valNode)
+			continue
+		}
+		if canonical := config.NormalizeValue(key.Value, valNode.Value); canonical != valNode.Value {
+			fmt.Fprintf(opts.IO.Out, "%s fixed %s: normalized the value of %q to %q\n", cs.SuccessIcon(), opts.ConfigFile, key.Value, canonical)
+			valNode.Value = canonical
+			// keep the diagnosis below in sync with the repaired file
+			_ = opts.Config.Set("", key.Value, canonical)
+			changed = true
+		}
+		kept = append(kept, key, valNode)
+	}
+
+	if changed {
+		doc.Content[0].Content = kept
+		if out, err := yaml.Marshal(doc.Content[0]); err == nil {
+			_ = os.WriteFile(opts.ConfigFile, out, 0600)
+		}
+	}
+	return removed
+}
+
+// isStructural reports whether a top-level key is part of the file layout
+// rather than a configuration option, mirroring config.UnknownKeys.
+func isStructural(key string) bool {
+	switch key {
+	case "hosts", "aliases", "profiles", "default_headers":
+		return true
+	}
+	return false
+}
+
+func printOwnershipHint(io *iostreams.IOStreams, fn string) {
+	fmt.Fprintf(io.ErrOut, "  the file may be owned by another user (e.g. after running inst under sudo); to fix:\n")
+	fmt.Fprintf(io.ErrOut, "  $ sudo chown $(whoami) %s\n", fn)
+}
@@ -0,0 +1,167 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_doctorRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		configYML  string
+		hostsYML   string
+		config     config.Config
+		wantErr    error
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "healthy configuration",
+			configYML:  "editor: vim\n",
+			hostsYML:   "my.instance:\n    protocol: https\n",
+			config:     config.ConfigStub{},
+			wantStdout: "ok",
+		},
+		{
+			name:       "missing files are fine",
+			config:     config.ConfigStub{},
+			wantStdout: "not present (will be created on demand)",
+		},
+		{
+			name:       "unparseable config file",
+			configYML:  "editor: [unclosed\n",
+			config:     config.ConfigStub{},
+			wantErr:    cmdutil.SilentError,
+			wantStderr: "does not parse",
+		},
+		{
+			name:       "invalid option value",
+			configYML:  "prompt: bananas\n",
+			config:     config.ConfigStub{"prompt": "bananas"},
+			wantErr:    cmdutil.SilentError,
+			wantStderr: `invalid value "bananas" for "prompt"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configFile := filepath.Join(dir, "config.yml")
+			hostsFile := filepath.Join(dir, "hosts.yml")
+			if tt.configYML != "" {
+				require.NoError(t, os.WriteFile(configFile, []byte(tt.configYML), 0600))
+			}
+			if tt.hostsYML != "" {
+				require.NoError(t, os.WriteFile(hostsFile, []byte(tt.hostsYML), 0600))
+			}
+
+			io, _, stdout, stderr := iostreams.Test()
+			opts := &DoctorOptions{
+				IO:         io,
+				Config:     tt.config,
+				ConfigFile: configFile,
+				HostsFile:  hostsFile,
+			}
+
+			err := doctorRun(opts)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, stdout.String(), "no problems found")
+			}
+			if tt.wantStdout != "" {
+				assert.Contains(t, stdout.String(), tt.wantStdout)
+			}
+			if tt.wantStderr != "" {
+				assert.Contains(t, stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_doctorRun_fix(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte("prompt: Enabled\nprotcol: ssh"), 0644))
+
+	io, _, stdout, stderr := iostreams.Test()
+	opts := &DoctorOptions{
+		IO:         io,
+		Config:     config.MustNewFromString("prompt: Enabled\nprotcol: ssh\n"),
+		Fix:        true,
+		ConfigFile: configFile,
+	}
+
+	err := doctorRun(opts)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), "tightened permissions to 0600")
+	assert.Contains(t, stdout.String(), "added a missing trailing newline")
+	assert.Contains(t, stdout.String(), `normalized the value of "prompt" to "enabled"`)
+	assert.Contains(t, stdout.String(), "no problems found")
+	assert.Contains(t, stderr.String(), "unknown config key \"protcol\" left in place; re-run with `--yes` to remove it")
+
+	fi, statErr := os.Stat(configFile)
+	require.NoError(t, statErr)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	data, readErr := os.ReadFile(configFile)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "prompt: enabled")
+	assert.Contains(t, string(data), "protcol: ssh")
+}
+
+func Test_doctorRun_fixYes(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte("editor: vim\nprotcol: ssh\n"), 0600))
+
+	io, _, stdout, stderr := iostreams.Test()
+	opts := &DoctorOptions{
+		IO:         io,
+		Config:     config.MustNewFromString("editor: vim\nprotcol: ssh\n"),
+		Fix:        true,
+		Yes:        true,
+		ConfigFile: configFile,
+	}
+
+	err := doctorRun(opts)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), `removed unknown key "protcol"`)
+	assert.Contains(t, stdout.String(), "no problems found")
+	assert.Empty(t, stderr.String())
+
+	data, readErr := os.ReadFile(configFile)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "editor: vim")
+	assert.NotContains(t, string(data), "protcol")
+}
+
+func Test_doctorRun_unwritableFile(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("file permissions are not enforced for root")
+	}
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte("editor: vim\n"), 0400))
+
+	io, _, _, stderr := iostreams.Test()
+	opts := &DoctorOptions{
+		IO:         io,
+		Config:     config.ConfigStub{},
+		ConfigFile: configFile,
+	}
+
+	err := doctorRun(opts)
+	assert.Equal(t, cmdutil.SilentError, err)
+	assert.Contains(t, stderr.String(), "not writeable")
+	assert.Contains(t, stderr.String(), "sudo chown")
+}
@@ -0,0 +1,146 @@
+package edit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+type EditOptions struct {
+	IO          *iostreams.IOStreams
+	Config      config.Config
+	ConfigFile  string
+	Interactive bool
+
+	// RunEditor launches the editor on a file; swappable for tests.
+	RunEditor func(editor string, args []string, path string) error
+}
+
+func NewCmdConfigEdit(f *cmdutil.Factory, runF func(*EditOptions) error) *cobra.Command {
+	opts := &EditOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Open the configuration file in the configured editor",
+		Long: heredoc.Doc(`
+			Open the raw YAML configuration in the editor resolved from the editor
+			option or the EDITOR environment variable.
+
+			The edited content is re-parsed before it replaces the configuration file.
+			When it fails to parse, the original file is kept and the parse error is
+			reported, with the option to reopen the editor and fix it.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config edit
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = cfg
+			opts.ConfigFile = config.ConfigFile()
+
+			if opts.IO.CanPrompt() {
+				opts.Interactive = true
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return editRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func editRun(opts *EditOptions) error {
+	editor, editorArgs, err := cmdutil.DetermineEditor(opts.Config)
+	if err != nil {
+		return err
+	}
+
+	runEditor := opts.RunEditor
+	if runEditor == nil {
+		runEditor = func(editor string, args []string, path string) error {
+			cmd := exec.Command(editor, append(args, path)...)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		}
+	}
+
+	original, err := os.ReadFile(opts.ConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// edit a scratch copy so a failed parse never clobbers the original
+	tmp, err := os.CreateTemp(filepath.Dir(opts.ConfigFile), "config-*.yml")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	if _, err := tmp.Write(original); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	cs := opts.IO.ColorScheme()
+	for {
+		if err := runEditor(editor, editorArgs, tmpPath); err != nil {
+			return fmt.Errorf("editor %q exited with an error: %w", editor, err)
+		}
+
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return err
+		}
+
+		if _, err := config.NewFromBytes(edited); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s the edited configuration does not parse: %s\n", cs.FailureIcon(), err)
+
+			if opts.Interactive {
+				var reopen bool
+				err = prompt.SurveyAskOne(&survey.Confirm{
+					Message: "Reopen the editor to fix it?",
+					Default: true,
+				}, &reopen)
+				if err != nil {
+					return fmt.Errorf("could not prompt: %w", err)
+				}
+				if reopen {
+					continue
+				}
+			}
+
+			fmt.Fprintf(opts.IO.ErrOut, "%s keeping the original configuration\n", cs.WarningIcon())
+			return cmdutil.SilentError
+		}
+
+		if err := os.WriteFile(opts.ConfigFile, edited, 0600); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.IO.Out, "%s configuration updated\n", cs.SuccessIcon())
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package edit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_editRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		original   string
+		edited     string
+		wantErr    error
+		wantFile   string
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "valid edit is saved",
+			original:   "editor: vim\n",
+			edited:     "editor: emacs\n",
+			wantFile:   "editor: emacs\n",
+			wantStdout: "✓ configuration updated\n",
+		},
+		{
+			name:       "invalid edit keeps the original",
+			original:   "editor: vim\n",
+			edited:     "editor: [unclosed\n",
+			wantErr:    cmdutil.SilentError,
+			wantFile:   "editor: vim\n",
+			wantStderr: "X the edited configuration does not parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(configFile, []byte(tt.original), 0600))
+
+			io, _, stdout, stderr := iostreams.Test()
+			opts := &EditOptions{
+				IO:         io,
+				Config:     config.ConfigStub{"editor": "vim"},
+				ConfigFile: configFile,
+				RunEditor: func(editor string, args []string, path string) error {
+					assert.Equal(t, "vim", editor)
+					return os.WriteFile(path, []byte(tt.edited), 0600)
+				},
+			}
+
+			err := editRun(opts)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			content, err := os.ReadFile(configFile)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, string(content))
+
+			if tt.wantStdout != "" {
+				assert.Equal(t, tt.wantStdout, stdout.String())
+			}
+			if tt.wantStderr != "" {
+				assert.Contains(t, stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_editRun_noEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	io, _, _, _ := iostreams.Test()
+	opts := &EditOptions{
+		IO:         io,
+		Config:     config.ConfigStub{"editor": ""},
+		ConfigFile: filepath.Join(t.TempDir(), "config.yml"),
+	}
+
+	err := editRun(opts)
+	assert.ErrorContains(t, err, "no editor configured")
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type ExportOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	File string
+}
+
+func NewCmdConfigExport(f *cmdutil.Factory, runF func(*ExportOptions) error) *cobra.Command {
+	opts := &ExportOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the configuration with secrets removed",
+		Long: heredoc.Doc(`
+			Print the current configuration as YAML with every stored token
+			stripped from all hosts, so the file is safe to share with a
+			teammate. Everything else - hosts, defaults, and settings - is kept
+			as-is, and the output loads back as a regular config file.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config export
+			$ inst config export --file team-config.yml
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return exportRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Write the export to a `file` instead of standard output")
+
+	return cmd
+}
+
+func exportRun(opts *ExportOptions) error {
+	data, err := config.Export(opts.Config)
+	if err != nil {
+		return err
+	}
+
+	if opts.File == "" {
+		_, err = opts.IO.Out.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(opts.File, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.File, err)
+	}
+
+	cs := opts.IO.ColorScheme()
+	fmt.Fprintf(opts.IO.ErrOut, "%s Exported the configuration without secrets to %s\n", cs.SuccessIcon(), opts.File)
+	return nil
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdConfigExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		output   ExportOptions
+		wantsErr bool
+	}{
+		{
+			name:   "no arguments",
+			input:  "",
+			output: ExportOptions{},
+		},
+		{
+			name:   "with file",
+			input:  "--file out.yml",
+			output: ExportOptions{File: "out.yml"},
+		},
+		{
+			name:     "positional argument",
+			input:    "out.yml",
+			wantsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				Config: config.ConfigStubFactory,
+			}
+
+			argv, err := shlex.Split(tt.input)
+			assert.NoError(t, err)
+
+			var gotOpts *ExportOptions
+			cmd := NewCmdConfigExport(f, func(opts *ExportOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.Flags().BoolP("help", "x", false, "")
+
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output.File, gotOpts.File)
+		})
+	}
+}
+
+func Test_exportRun(t *testing.T) {
+	cfg := config.MustNewFromString(heredoc.Doc(`
+		editor: vim
+		hosts:
+		  api.instill.tech:
+		    access_token: SECRET1
+		    oauth2_hostname: auth.instill.tech
+	`))
+
+	t.Run("to stdout", func(t *testing.T) {
+		io, _, stdout, stderr := iostreams.Test()
+		opts := &ExportOptions{
+			IO:     io,
+			Config: cfg,
+		}
+
+		require.NoError(t, exportRun(opts))
+
+		assert.NotContains(t, stdout.String(), "SECRET1")
+		assert.Contains(t, stdout.String(), "editor: vim")
+		assert.Contains(t, stdout.String(), "oauth2_hostname: auth.instill.tech")
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("to a file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "out.yml")
+		io, _, stdout, stderr := iostreams.Test()
+		opts := &ExportOptions{
+			IO:     io,
+			Config: cfg,
+			File:   filename,
+		}
+
+		require.NoError(t, exportRun(opts))
+
+		data, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "SECRET1")
+		assert.Contains(t, string(data), "editor: vim")
+
+		assert.Equal(t, "", stdout.String())
+		assert.Equal(t, "✓ Exported the configuration without secrets to "+filename+"\n", stderr.String())
+	})
+}
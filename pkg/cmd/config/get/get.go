@@ -2,6 +2,7 @@ package get
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
@@ -15,8 +16,22 @@ type GetOptions struct {
 	IO     *iostreams.IOStreams
 	Config config.Config
 
-	Hostname string
-	Key      string
+	Hostname   string
+	Key        string
+	Fallback   string
+	AllHosts   bool
+	Expand     bool
+	ShowSource bool
+	Output     string
+	JSON       bool
+}
+
+// hostKeyValue is the machine-readable shape of one host's value for a key in
+// `--all-hosts` output.
+type hostKeyValue struct {
+	Hostname string `json:"hostname" yaml:"hostname"`
+	Value    string `json:"value" yaml:"value"`
+	Source   string `json:"source" yaml:"source"`
 }
 
 func NewCmdConfigGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Command {
@@ -30,8 +45,18 @@ func NewCmdConfigGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Co
 		Example: heredoc.Doc(`
 			$ inst config get protocol
 			https
+
+			# compare a key across every configured host
+			$ inst config get protocol --all-hosts
+
+			# print the value the CLI actually uses at runtime
+			$ inst config get editor --expand --show-source
+
+			# print a fallback when the key resolves to nothing
+			$ inst config get editor --or vim
 		`),
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cmdutil.ConfigKeyCompletion,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config, err := f.Config()
 			if err != nil {
@@ -40,6 +65,37 @@ func NewCmdConfigGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Co
 			opts.Config = config
 			opts.Key = args[0]
 
+			if err := cmdutil.MutuallyExclusive(
+				"only one of `--host` or `--all-hosts` may be used",
+				opts.Hostname != "",
+				opts.AllHosts,
+			); err != nil {
+				return err
+			}
+			if err := cmdutil.MutuallyExclusive(
+				"only one of `--expand` or `--all-hosts` may be used",
+				opts.Expand,
+				opts.AllHosts,
+			); err != nil {
+				return err
+			}
+			if err := cmdutil.MutuallyExclusive(
+				"only one of `--or` or `--all-hosts` may be used",
+				opts.Fallback != "",
+				opts.AllHosts,
+			); err != nil {
+				return err
+			}
+			if opts.ShowSource && !opts.Expand {
+				return cmdutil.FlagErrorf("`--show-source` requires `--expand`")
+			}
+			if opts.JSON {
+				opts.Output = "json"
+			}
+			if err := cmdutil.ValidateFormat(opts.Output); err != nil {
+				return err
+			}
+
 			if runF != nil {
 				return runF(opts)
 			}
@@ -49,18 +105,145 @@ func NewCmdConfigGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&opts.Hostname, "host", "h", "", "Get per-host setting")
+	cmd.Flags().BoolVar(&opts.AllHosts, "all-hosts", false, "Print the key's value for every configured host")
+	cmd.Flags().BoolVar(&opts.Expand, "expand", false, "Print the value as the CLI resolves it at runtime, with environment overrides and variable expansion applied")
+	cmd.Flags().BoolVar(&opts.ShowSource, "show-source", false, "Explain where the resolved value comes from (with `--expand`)")
+	cmd.Flags().StringVar(&opts.Fallback, "or", "", "Print this `value` instead when the key resolves to an empty value")
+	cmdutil.AddFormatFlag(cmd, &opts.Output)
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output JSON (shorthand for `--output json`)")
+	_ = cmd.RegisterFlagCompletionFunc("host", cmdutil.HostnameCompletion(f))
 
 	return cmd
 }
 
 func getRun(opts *GetOptions) error {
+	if opts.AllHosts {
+		return getAllHostsRun(opts)
+	}
+
+	if opts.Expand {
+		return getExpandRun(opts)
+	}
+
 	val, err := opts.Config.Get(opts.Hostname, opts.Key)
 	if err != nil {
 		return err
 	}
 
+	// secrets are never printed in full; auth status --show-token is the
+	// explicit way to retrieve a stored token
+	if config.IsSensitiveKey(opts.Key) {
+		val = config.RedactSecret(val)
+	}
+
+	if val == "" {
+		val = opts.Fallback
+	}
 	if val != "" {
 		fmt.Fprintf(opts.IO.Out, "%s\n", val)
 	}
 	return nil
 }
+
+// getExpandRun prints the value the CLI would actually use for the key at
+// runtime, rather than the raw stored value.
+func getExpandRun(opts *GetOptions) error {
+	value, source, err := resolveRuntimeValue(opts.Config, opts.Hostname, opts.Key)
+	if err != nil {
+		return err
+	}
+
+	if config.IsSensitiveKey(opts.Key) {
+		value = config.RedactSecret(value)
+	}
+
+	// the fallback only kicks in when nothing resolved, after environment
+	// overrides and built-in defaults had their chance
+	if value == "" && opts.Fallback != "" {
+		value = opts.Fallback
+		source = "`--or` fallback"
+	}
+	if value != "" {
+		fmt.Fprintf(opts.IO.Out, "%s\n", value)
+	}
+	if opts.ShowSource {
+		fmt.Fprintf(opts.IO.Out, "source: %s\n", source)
+	}
+	return nil
+}
+
+// resolveRuntimeValue resolves a key the way the CLI does when it uses it:
+// environment overrides take their usual precedence over stored values, empty
+// values fall back to built-in defaults, and environment variables inside the
+// value are expanded.
+func resolveRuntimeValue(cfg config.Config, hostname, key string) (string, string, error) {
+	if key == "pager" {
+		if pager, ok := os.LookupEnv("INSTILL_PAGER"); ok {
+			return os.ExpandEnv(pager), "INSTILL_PAGER environment variable", nil
+		}
+	}
+
+	value, source, err := cfg.GetWithSource(hostname, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	if value == "" {
+		switch key {
+		case "editor":
+			if visual := os.Getenv("VISUAL"); visual != "" {
+				return visual, "VISUAL environment variable", nil
+			}
+			if editor := os.Getenv("EDITOR"); editor != "" {
+				return editor, "EDITOR environment variable", nil
+			}
+		case "pager":
+			if pager := os.Getenv("PAGER"); pager != "" {
+				return pager, "PAGER environment variable", nil
+			}
+		}
+		return "", source, nil
+	}
+
+	if expanded := os.ExpandEnv(value); expanded != value {
+		return expanded, fmt.Sprintf("%s (expanded)", source), nil
+	}
+	return value, source, nil
+}
+
+// getAllHostsRun prints the key's effective value for every configured host
+// along with where that value comes from. Hosts that do not set the key show
+// the value they fall back to, with the source marked as a default.
+func getAllHostsRun(opts *GetOptions) error {
+	hosts, err := opts.Config.Hosts()
+	if err != nil {
+		return err
+	}
+
+	var entries []hostKeyValue
+	for _, hostname := range hosts {
+		value, source, err := opts.Config.GetWithSource(hostname, opts.Key)
+		if err != nil {
+			return err
+		}
+		if config.IsSensitiveKey(opts.Key) {
+			value = config.RedactSecret(value)
+		}
+		if source != config.HostsConfigFile() {
+			source = fmt.Sprintf("%s (default)", source)
+		}
+		entries = append(entries, hostKeyValue{
+			Hostname: hostname,
+			Value:    value,
+			Source:   source,
+		})
+	}
+
+	cols := []string{"Hostname", "Value", "Source"}
+	var data [][]string
+	for _, e := range entries {
+		data = append(data, []string{e.Hostname, e.Value, e.Source})
+	}
+
+	return cmdutil.RenderOutput(opts.IO, opts.Output, cols, data, entries)
+}
@@ -2,8 +2,12 @@ package get
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/assert"
 
@@ -37,6 +41,44 @@ func TestNewCmdConfigGet(t *testing.T) {
 			output:   GetOptions{Hostname: "test.com", Key: "key"},
 			wantsErr: false,
 		},
+		{
+			name:     "get key for all hosts",
+			input:    "key --all-hosts",
+			output:   GetOptions{Key: "key", AllHosts: true},
+			wantsErr: false,
+		},
+		{
+			name:     "--host conflicts with --all-hosts",
+			input:    "key --host test.com --all-hosts",
+			wantsErr: true,
+		},
+		{
+			name:     "get expanded key with source",
+			input:    "key --expand --show-source",
+			output:   GetOptions{Key: "key", Expand: true, ShowSource: true},
+			wantsErr: false,
+		},
+		{
+			name:     "--expand conflicts with --all-hosts",
+			input:    "key --expand --all-hosts",
+			wantsErr: true,
+		},
+		{
+			name:     "--show-source requires --expand",
+			input:    "key --show-source",
+			wantsErr: true,
+		},
+		{
+			name:     "get key with fallback",
+			input:    "key --or fallback",
+			output:   GetOptions{Key: "key", Fallback: "fallback"},
+			wantsErr: false,
+		},
+		{
+			name:     "--or conflicts with --all-hosts",
+			input:    "key --or fallback --all-hosts",
+			wantsErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,6 +113,10 @@ func TestNewCmdConfigGet(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, tt.output.Hostname, gotOpts.Hostname)
 			assert.Equal(t, tt.output.Key, gotOpts.Key)
+			assert.Equal(t, tt.output.AllHosts, gotOpts.AllHosts)
+			assert.Equal(t, tt.output.Expand, gotOpts.Expand)
+			assert.Equal(t, tt.output.ShowSource, gotOpts.ShowSource)
+			assert.Equal(t, tt.output.Fallback, gotOpts.Fallback)
 		})
 	}
 }
@@ -105,6 +151,28 @@ func Test_getRun(t *testing.T) {
 			},
 			stdout: "vim\n",
 		},
+		{
+			name: "fallback when the key is unset",
+			input: &GetOptions{
+				Key:      "editor",
+				Fallback: "vim",
+				Config: config.ConfigStub{
+					"editor": "",
+				},
+			},
+			stdout: "vim\n",
+		},
+		{
+			name: "configured value wins over the fallback",
+			input: &GetOptions{
+				Key:      "editor",
+				Fallback: "vim",
+				Config: config.ConfigStub{
+					"editor": "ed",
+				},
+			},
+			stdout: "ed\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,3 +189,154 @@ func Test_getRun(t *testing.T) {
 		})
 	}
 }
+
+func Test_getRun_redactsSecrets(t *testing.T) {
+	io, _, stdout, _ := iostreams.Test()
+	opts := &GetOptions{
+		IO:       io,
+		Hostname: "instill.tech",
+		Key:      "access_token",
+		Config: config.ConfigStub{
+			"instill.tech:access_token": "super-secret-token-wxyz",
+		},
+	}
+	assert.NoError(t, getRun(opts))
+	assert.Equal(t, "********wxyz\n", stdout.String())
+	assert.NotContains(t, stdout.String(), "super-secret-token-wxyz")
+}
+
+func Test_getRun_expand(t *testing.T) {
+	t.Setenv("TEST_EDITOR_DIR", "/opt/editors")
+	t.Setenv("VISUAL", "vim --wait")
+	t.Setenv("EDITOR", "nano")
+
+	t.Run("expands environment variables in the stored value", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &GetOptions{
+			IO:     io,
+			Key:    "editor",
+			Expand: true,
+			Config: config.ConfigStub{
+				"editor": "$TEST_EDITOR_DIR/code",
+			},
+		}
+		assert.NoError(t, getRun(opts))
+		assert.Equal(t, "/opt/editors/code\n", stdout.String())
+	})
+
+	t.Run("falls back to the environment when the key is unset", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &GetOptions{
+			IO:     io,
+			Key:    "editor",
+			Expand: true,
+			Config: config.ConfigStub{
+				"editor": "",
+			},
+		}
+		assert.NoError(t, getRun(opts))
+		assert.Equal(t, "vim --wait\n", stdout.String())
+	})
+
+	t.Run("show-source explains the resolution", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &GetOptions{
+			IO:         io,
+			Key:        "editor",
+			Expand:     true,
+			ShowSource: true,
+			Config: config.ConfigStub{
+				"editor": "$TEST_EDITOR_DIR/code",
+			},
+		}
+		assert.NoError(t, getRun(opts))
+		assert.Equal(t, "/opt/editors/code\nsource: (memory) (expanded)\n", stdout.String())
+	})
+
+	t.Run("--or fallback applies after environment overrides", func(t *testing.T) {
+		t.Setenv("PAGER", "")
+		io, _, stdout, _ := iostreams.Test()
+		opts := &GetOptions{
+			IO:         io,
+			Key:        "pager",
+			Expand:     true,
+			ShowSource: true,
+			Fallback:   "more",
+			Config: config.ConfigStub{
+				"pager": "",
+			},
+		}
+		assert.NoError(t, getRun(opts))
+		assert.Equal(t, "more\nsource: `--or` fallback\n", stdout.String())
+	})
+
+	t.Run("INSTILL_PAGER overrides the stored pager", func(t *testing.T) {
+		t.Setenv("INSTILL_PAGER", "less -R")
+		io, _, stdout, _ := iostreams.Test()
+		opts := &GetOptions{
+			IO:         io,
+			Key:        "pager",
+			Expand:     true,
+			ShowSource: true,
+			Config: config.ConfigStub{
+				"pager": "more",
+			},
+		}
+		assert.NoError(t, getRun(opts))
+		assert.Equal(t, "less -R\nsource: INSTILL_PAGER environment variable\n", stdout.String())
+	})
+}
+
+func Test_getRun_allHosts(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), ".local", "instill")
+	os.Setenv(config.INSTILL_CONFIG_DIR, configDir)
+	defer os.Unsetenv(config.INSTILL_CONFIG_DIR)
+
+	yml := heredoc.Doc(`
+		editor: ed
+		hosts:
+		  host1.example.com:
+		    editor: vim
+		  host2.example.com:
+		    access_token: TOKEN
+	`)
+
+	t.Run("table output", func(t *testing.T) {
+		io, _, stdout, stderr := iostreams.Test()
+		io.SetStdoutTTY(true)
+
+		err := getRun(&GetOptions{
+			IO:       io,
+			Config:   config.MustNewFromString(yml),
+			Key:      "editor",
+			AllHosts: true,
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, stdout.String(), "host1.example.com")
+		assert.Contains(t, stdout.String(), "vim")
+		assert.Contains(t, stdout.String(), "host2.example.com")
+		assert.Contains(t, stdout.String(), "(default)")
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		io, _, stdout, stderr := iostreams.Test()
+
+		err := getRun(&GetOptions{
+			IO:       io,
+			Config:   config.MustNewFromString(yml),
+			Key:      "editor",
+			AllHosts: true,
+			Output:   "json",
+		})
+		assert.NoError(t, err)
+
+		var entries []hostKeyValue
+		assert.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		assert.Equal(t, []hostKeyValue{
+			{Hostname: "host1.example.com", Value: "vim", Source: config.HostsConfigFile()},
+			{Hostname: "host2.example.com", Value: "ed", Source: config.ConfigFile() + " (default)"},
+		}, entries)
+		assert.Equal(t, "", stderr.String())
+	})
+}
@@ -0,0 +1,144 @@
+package hosts
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type HostsOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	Output string
+	JSON   bool
+}
+
+// hostEntry is the machine-readable shape of a configured host. Tokens are
+// never included, only whether one is present.
+type hostEntry struct {
+	Hostname   string `json:"hostname" yaml:"hostname"`
+	Default    bool   `json:"default" yaml:"default"`
+	User       string `json:"user,omitempty" yaml:"user,omitempty"`
+	HasToken   bool   `json:"has_token" yaml:"has_token"`
+	APIVersion string `json:"api_version" yaml:"api_version"`
+}
+
+func NewCmdConfigHosts(f *cmdutil.Factory, runF func(*HostsOptions) error) *cobra.Command {
+	opts := &HostsOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Print the configured hosts",
+		Long: heredoc.Doc(`
+			Print an overview of the configured hosts without exposing any secrets.
+
+			For every host the authenticated user and whether a token is stored are
+			shown, but never the token itself.
+		`),
+		Example: heredoc.Doc(`
+			# list configured hosts
+			$ inst config hosts
+
+			# machine-readable output
+			$ inst config hosts --json
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+			if opts.JSON {
+				opts.Output = "json"
+			}
+			if err := cmdutil.ValidateFormat(opts.Output); err != nil {
+				return err
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return hostsRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlag(cmd, &opts.Output)
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output JSON (shorthand for `--output json`)")
+
+	return cmd
+}
+
+func hostsRun(opts *HostsOptions) error {
+	hosts, err := opts.Config.HostsTyped()
+	if err != nil {
+		return err
+	}
+
+	var entries []hostEntry
+	for _, h := range hosts {
+		entries = append(entries, hostEntry{
+			Hostname:   h.APIHostname,
+			Default:    h.APIHostname == opts.Config.DefaultHostname(),
+			User:       userFromIDToken(h.IDToken),
+			HasToken:   h.AccessToken != "" || h.RefreshToken != "",
+			APIVersion: h.APIVersion,
+		})
+	}
+
+	cols := []string{"Default", "Hostname", "User", "Token"}
+	var data [][]string
+	for _, e := range entries {
+		def := ""
+		if e.Default {
+			def = "*"
+		}
+		token := "none"
+		if e.HasToken {
+			token = "*******"
+		}
+		data = append(data, []string{def, e.Hostname, e.User, token})
+	}
+
+	err = cmdutil.RenderOutput(opts.IO, opts.Output, cols, data, entries)
+	if err != nil {
+		return fmt.Errorf("ERROR: failed to list hosts: %w", err)
+	}
+
+	return nil
+}
+
+// userFromIDToken extracts the user identity from the claims of an OIDC ID
+// token, or returns an empty string when none can be determined.
+func userFromIDToken(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Email string `json:"email"`
+		Sub   string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.Sub
+}
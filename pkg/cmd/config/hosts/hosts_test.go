@@ -0,0 +1,89 @@
+package hosts
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestConfigHostsRun(t *testing.T) {
+	io, _, stdout, stderr := iostreams.Test()
+	io.SetStdoutTTY(true)
+	opts := &HostsOptions{
+		IO:     io,
+		Config: config.ConfigStub{},
+	}
+
+	err := hostsRun(opts)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), "api.instill.tech")
+	assert.Contains(t, stdout.String(), "none")
+	assert.NotContains(t, stdout.String(), "foobar")
+	assert.Equal(t, "", stderr.String())
+}
+
+func TestConfigHostsRunJSON(t *testing.T) {
+	io, _, stdout, stderr := iostreams.Test()
+	opts := &HostsOptions{
+		IO:     io,
+		Config: config.ConfigStub{},
+		Output: "json",
+	}
+
+	err := hostsRun(opts)
+	assert.NoError(t, err)
+	expected := `[
+  {
+    "hostname": "api.instill.tech",
+    "default": true,
+    "has_token": false,
+    "api_version": "v1alpha"
+  }
+]
+`
+	assert.Equal(t, expected, stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
+
+func Test_userFromIDToken(t *testing.T) {
+	encode := func(payload string) string {
+		return "x." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".y"
+	}
+
+	tests := []struct {
+		name    string
+		idToken string
+		want    string
+	}{
+		{
+			name:    "empty token",
+			idToken: "",
+			want:    "",
+		},
+		{
+			name:    "malformed token",
+			idToken: "not-a-jwt",
+			want:    "",
+		},
+		{
+			name:    "email claim",
+			idToken: encode(`{"sub":"auth0|123","email":"me@example.com"}`),
+			want:    "me@example.com",
+		},
+		{
+			name:    "subject fallback",
+			idToken: encode(`{"sub":"auth0|123"}`),
+			want:    "auth0|123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, userFromIDToken(tt.idToken))
+		})
+	}
+}
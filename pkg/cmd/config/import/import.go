@@ -0,0 +1,147 @@
+package importcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type ImportOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	File     string
+	Hostname string
+	DryRun   bool
+}
+
+func NewCmdConfigImport(f *cmdutil.Factory, runF func(*ImportOptions) error) *cobra.Command {
+	opts := &ImportOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Apply multiple configuration settings from a file",
+		Long: heredoc.Doc(`
+			Apply every setting from a YAML file holding a flat mapping of
+			configuration keys to values. All keys and values are validated
+			before anything is applied, so either every setting is written or
+			none are. Pass "-" to read the mapping from standard input.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config import settings.yml
+			$ inst config import settings.yml --host my.instance
+			$ inst config import settings.yml --dry-run
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+			opts.File = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return importRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Hostname, "host", "h", "", "Apply the settings to a specific host")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be set without writing the config")
+
+	return cmd
+}
+
+func importRun(opts *ImportOptions) error {
+	data, err := opts.IO.ReadUserFile(opts.File)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.File, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", opts.File, err)
+	}
+	if len(root.Content) == 0 {
+		return fmt.Errorf("no settings found in %s", opts.File)
+	}
+	if root.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("expected a top level map of configuration keys in %s", opts.File)
+	}
+
+	type setting struct {
+		key   string
+		value string
+	}
+
+	// validate everything up front so a bad entry never leaves the config
+	// half-applied
+	var settings []setting
+	var problems []string
+	pairs := root.Content[0].Content
+	for i := 0; i < len(pairs)-1; i += 2 {
+		key, value := pairs[i].Value, pairs[i+1].Value
+
+		if err := config.ValidateKey(key); err != nil {
+			problems = append(problems, fmt.Sprintf("%q is not a known configuration key", key))
+			continue
+		}
+
+		if err := config.ValidateValue(key, value); err != nil {
+			var invalidValue *config.InvalidValueError
+			if errors.As(err, &invalidValue) {
+				if invalidValue.ExpectedType != "" {
+					problems = append(problems, fmt.Sprintf("invalid value %q for %q: expected %s", value, key, invalidValue.ExpectedType.Description()))
+					continue
+				}
+				var values []string
+				for _, v := range invalidValue.ValidValues {
+					values = append(values, fmt.Sprintf("%q", v))
+				}
+				problems = append(problems, fmt.Sprintf("invalid value %q for %q: valid values are %s", value, key, strings.Join(values, ", ")))
+				continue
+			}
+			return err
+		}
+
+		settings = append(settings, setting{key, config.NormalizeValue(key, value)})
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("no settings were imported:\n- %s", strings.Join(problems, "\n- "))
+	}
+
+	cs := opts.IO.ColorScheme()
+	for _, s := range settings {
+		if opts.DryRun {
+			fmt.Fprintf(opts.IO.Out, "would set %s to %q\n", s.key, s.value)
+			continue
+		}
+		if err := opts.Config.Set(opts.Hostname, s.key, s.value); err != nil {
+			return fmt.Errorf("failed to set %q: %w", s.key, err)
+		}
+		fmt.Fprintf(opts.IO.Out, "%s set %s to %q\n", cs.SuccessIcon(), s.key, s.value)
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+
+	if err := opts.Config.Write(); err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+	return nil
+}
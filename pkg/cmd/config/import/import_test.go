@@ -0,0 +1,188 @@
+package importcfg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdConfigImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		output   ImportOptions
+		wantsErr bool
+	}{
+		{
+			name:   "file argument",
+			input:  "settings.yml",
+			output: ImportOptions{File: "settings.yml"},
+		},
+		{
+			name:   "with host",
+			input:  "settings.yml --host test.com",
+			output: ImportOptions{File: "settings.yml", Hostname: "test.com"},
+		},
+		{
+			name:   "dry run",
+			input:  "settings.yml --dry-run",
+			output: ImportOptions{File: "settings.yml", DryRun: true},
+		},
+		{
+			name:     "no arguments",
+			input:    "",
+			wantsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				Config: config.ConfigStubFactory,
+			}
+
+			argv, err := shlex.Split(tt.input)
+			assert.NoError(t, err)
+
+			var gotOpts *ImportOptions
+			cmd := NewCmdConfigImport(f, func(opts *ImportOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.Flags().BoolP("help", "x", false, "")
+
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output.File, gotOpts.File)
+			assert.Equal(t, tt.output.Hostname, gotOpts.Hostname)
+			assert.Equal(t, tt.output.DryRun, gotOpts.DryRun)
+		})
+	}
+}
+
+func writeSettingsFile(t *testing.T, contents string) string {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), "settings.yml")
+	require.NoError(t, os.WriteFile(fn, []byte(contents), 0600))
+	return fn
+}
+
+func Test_importRun(t *testing.T) {
+	t.Run("applies all settings and writes once", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		cfg := config.ConfigStub{}
+		opts := &ImportOptions{
+			IO:     io,
+			Config: cfg,
+			File: writeSettingsFile(t, heredoc.Doc(`
+				editor: vim
+				prompt: enabled
+			`)),
+		}
+
+		require.NoError(t, importRun(opts))
+		assert.Equal(t, "vim", cfg["editor"])
+		assert.Equal(t, "enabled", cfg["prompt"])
+		assert.Equal(t, "true", cfg["_written"])
+		assert.Equal(t, heredoc.Doc(`
+			✓ set editor to "vim"
+			✓ set prompt to "enabled"
+		`), stdout.String())
+	})
+
+	t.Run("reports every validation problem and writes nothing", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		cfg := config.ConfigStub{}
+		opts := &ImportOptions{
+			IO:     io,
+			Config: cfg,
+			File: writeSettingsFile(t, heredoc.Doc(`
+				bogus: value
+				prompt: maybe
+				editor: vim
+			`)),
+		}
+
+		err := importRun(opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"bogus" is not a known configuration key`)
+		assert.Contains(t, err.Error(), `invalid value "maybe" for "prompt"`)
+		// the valid key must not be applied either
+		assert.NotContains(t, cfg, "editor")
+		assert.NotContains(t, cfg, "_written")
+	})
+
+	t.Run("host-scoped settings", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		cfg := config.ConfigStub{}
+		opts := &ImportOptions{
+			IO:       io,
+			Config:   cfg,
+			Hostname: "test.com",
+			File:     writeSettingsFile(t, "protocol: ssh\n"),
+		}
+
+		require.NoError(t, importRun(opts))
+		assert.Equal(t, "ssh", cfg["test.com:protocol"])
+	})
+
+	t.Run("dry run previews without writing", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		cfg := config.ConfigStub{}
+		opts := &ImportOptions{
+			IO:     io,
+			Config: cfg,
+			DryRun: true,
+			File:   writeSettingsFile(t, "editor: vim\n"),
+		}
+
+		require.NoError(t, importRun(opts))
+		assert.Equal(t, "would set editor to \"vim\"\n", stdout.String())
+		assert.NotContains(t, cfg, "editor")
+		assert.NotContains(t, cfg, "_written")
+	})
+
+	t.Run("not a mapping", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		opts := &ImportOptions{
+			IO:     io,
+			Config: config.ConfigStub{},
+			File:   writeSettingsFile(t, "- editor\n- vim\n"),
+		}
+
+		err := importRun(opts)
+		assert.ErrorContains(t, err, "expected a top level map of configuration keys")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		opts := &ImportOptions{
+			IO:     io,
+			Config: config.ConfigStub{},
+			File:   filepath.Join(t.TempDir(), "nope.yml"),
+		}
+
+		err := importRun(opts)
+		assert.ErrorContains(t, err, "failed to read")
+	})
+}
@@ -0,0 +1,231 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+// ghKeyMapping maps gh config keys to their inst equivalents. Keys absent
+// from the source config are skipped silently.
+var ghKeyMapping = [][2]string{
+	{"git_protocol", "protocol"},
+	{"editor", "editor"},
+	{"prompt", "prompt"},
+	{"pager", "pager"},
+}
+
+type MigrateOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	From      string
+	Overwrite bool
+
+	// SourceDir overrides the gh config directory, for tests.
+	SourceDir string
+}
+
+func NewCmdConfigMigrate(f *cmdutil.Factory, runF func(*MigrateOptions) error) *cobra.Command {
+	opts := &MigrateOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate --from gh",
+		Short: "Import settings from another CLI",
+		Long: heredoc.Doc(`
+			Import compatible settings from the GitHub CLI into the inst config.
+
+			The mapped keys are git_protocol (imported as protocol), editor, prompt,
+			and pager. Existing non-empty values are kept unless --overwrite is given,
+			and OAuth tokens are only imported after an interactive confirmation.
+			The current config files are backed up with a ".bak" suffix before
+			anything is written.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config migrate --from gh
+			$ inst config migrate --from gh --overwrite
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return migrateRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.From, "from", "", "The CLI to import settings from: {gh}")
+	cmd.Flags().BoolVar(&opts.Overwrite, "overwrite", false, "Replace existing non-empty values")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+func migrateRun(opts *MigrateOptions) error {
+	if opts.From != "gh" {
+		return fmt.Errorf("unsupported migration source %q: only \"gh\" is supported", opts.From)
+	}
+
+	srcDir := opts.SourceDir
+	if srcDir == "" {
+		srcDir = ghConfigDir()
+	}
+
+	srcData, err := os.ReadFile(filepath.Join(srcDir, "config.yml"))
+	if err != nil {
+		return fmt.Errorf("could not read gh config: %w", err)
+	}
+	var src map[string]string
+	if err := yaml.Unmarshal(srcData, &src); err != nil {
+		return fmt.Errorf("could not parse gh config: %w", err)
+	}
+
+	cs := opts.IO.ColorScheme()
+	imported := 0
+
+	for _, mapping := range ghKeyMapping {
+		srcKey, dstKey := mapping[0], mapping[1]
+		value, found := src[srcKey]
+		if !found || value == "" {
+			continue
+		}
+		if err := config.ValidateValue(dstKey, value); err != nil {
+			fmt.Fprintf(opts.IO.ErrOut, "%s skipped %s: invalid value %q\n", cs.WarningIcon(), dstKey, value)
+			continue
+		}
+		if current, err := opts.Config.Get("", dstKey); err == nil &&
+			current != "" && current != defaultValueFor(dstKey) && !opts.Overwrite {
+			fmt.Fprintf(opts.IO.ErrOut, "%s skipped %s: already set to %q (use --overwrite to replace)\n", cs.WarningIcon(), dstKey, current)
+			continue
+		}
+		value = config.NormalizeValue(dstKey, value)
+		if err := opts.Config.Set("", dstKey, value); err != nil {
+			return fmt.Errorf("failed to set %q: %w", dstKey, err)
+		}
+		fmt.Fprintf(opts.IO.Out, "%s imported %s: %s\n", cs.SuccessIcon(), dstKey, value)
+		imported++
+	}
+
+	tokens, err := migrateTokens(opts, srcDir)
+	if err != nil {
+		return err
+	}
+	imported += tokens
+
+	if imported == 0 {
+		fmt.Fprintln(opts.IO.Out, "nothing to import")
+		return nil
+	}
+
+	if err := backupConfigFiles(); err != nil {
+		return fmt.Errorf("failed to back up current config: %w", err)
+	}
+
+	if err := opts.Config.Write(); err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+	return nil
+}
+
+// migrateTokens offers to carry over per-host OAuth tokens from gh's
+// hosts.yml. Tokens are never imported without an explicit confirmation, so
+// non-interactive runs skip them with a notice.
+func migrateTokens(opts *MigrateOptions, srcDir string) (int, error) {
+	hostsData, err := os.ReadFile(filepath.Join(srcDir, "hosts.yml"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("could not read gh hosts config: %w", err)
+	}
+
+	var hosts map[string]map[string]string
+	if err := yaml.Unmarshal(hostsData, &hosts); err != nil {
+		return 0, fmt.Errorf("could not parse gh hosts config: %w", err)
+	}
+
+	cs := opts.IO.ColorScheme()
+	imported := 0
+	for hostname, hostCfg := range hosts {
+		token := hostCfg["oauth_token"]
+		if token == "" {
+			continue
+		}
+		if !opts.IO.CanPrompt() {
+			fmt.Fprintf(opts.IO.ErrOut, "%s skipped token for %s: confirmation required (re-run interactively to import tokens)\n", cs.WarningIcon(), hostname)
+			continue
+		}
+		var confirmed bool
+		err := prompt.SurveyAskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Import the OAuth token for %s?", hostname),
+		}, &confirmed)
+		if err != nil {
+			return imported, err
+		}
+		if !confirmed {
+			fmt.Fprintf(opts.IO.ErrOut, "%s skipped token for %s\n", cs.WarningIcon(), hostname)
+			continue
+		}
+		if err := opts.Config.Set(hostname, "access_token", token); err != nil {
+			return imported, fmt.Errorf("failed to import token for %s: %w", hostname, err)
+		}
+		fmt.Fprintf(opts.IO.Out, "%s imported token for %s\n", cs.SuccessIcon(), hostname)
+		imported++
+	}
+	return imported, nil
+}
+
+// backupConfigFiles copies the current config files aside so a bad migration
+// can be undone by hand.
+func backupConfigFiles() error {
+	for _, fn := range []string{config.ConfigFile(), config.HostsConfigFile()} {
+		data, err := os.ReadFile(fn)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := os.WriteFile(fn+".bak", data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ghConfigDir() string {
+	if d := os.Getenv("GH_CONFIG_DIR"); d != "" {
+		return d
+	}
+	if d := os.Getenv("XDG_CONFIG_HOME"); d != "" {
+		return filepath.Join(d, "gh")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gh")
+}
+
+func defaultValueFor(key string) string {
+	for _, co := range config.ConfigOptions() {
+		if co.Key == key {
+			return co.DefaultValue
+		}
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+func writeGhConfig(t *testing.T, configYML, hostsYML string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if configYML != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yml"), []byte(configYML), 0600))
+	}
+	if hostsYML != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hostsYML), 0600))
+	}
+	return dir
+}
+
+func Test_migrateRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		ghConfig   string
+		ghHosts    string
+		configYML  string
+		overwrite  bool
+		wantMain   string
+		wantStdout string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name: "imports mapped keys",
+			ghConfig: heredoc.Doc(`
+				git_protocol: ssh
+				editor: vim
+				prompt: disabled
+			`),
+			configYML: "pager: less\n",
+			wantMain:  "pager: less\nprotocol: ssh\neditor: vim\nprompt: disabled\n",
+			wantStdout: heredoc.Doc(`
+				✓ imported protocol: ssh
+				✓ imported editor: vim
+				✓ imported prompt: disabled
+			`),
+		},
+		{
+			name:       "keeps existing values without overwrite",
+			ghConfig:   "editor: vim\n",
+			configYML:  "editor: emacs\n",
+			wantStdout: "nothing to import\n",
+			wantStderr: "! skipped editor: already set to \"emacs\" (use --overwrite to replace)\n",
+		},
+		{
+			name:       "overwrite replaces existing values",
+			ghConfig:   "editor: vim\n",
+			configYML:  "editor: emacs\n",
+			overwrite:  true,
+			wantMain:   "editor: vim\n",
+			wantStdout: "✓ imported editor: vim\n",
+		},
+		{
+			name:       "skips invalid values",
+			ghConfig:   "git_protocol: gopher\n",
+			configYML:  "pager: less\n",
+			wantStdout: "nothing to import\n",
+			wantStderr: "! skipped protocol: invalid value \"gopher\"\n",
+		},
+		{
+			name:       "tokens are not imported without confirmation",
+			ghConfig:   "editor: vim\n",
+			ghHosts:    "github.com:\n    oauth_token: GHTOKEN\n",
+			configYML:  "pager: less\n",
+			wantMain:   "pager: less\neditor: vim\n",
+			wantStdout: "✓ imported editor: vim\n",
+			wantStderr: "! skipped token for github.com: confirmation required (re-run interactively to import tokens)\n",
+		},
+		{
+			name:      "missing gh config",
+			configYML: "pager: less\n",
+			wantErr:   "could not read gh config:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INSTILL_CONFIG_DIR", t.TempDir())
+
+			mainBuf := bytes.Buffer{}
+			hostsBuf := bytes.Buffer{}
+			defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+			io, _, stdout, stderr := iostreams.Test()
+			opts := &MigrateOptions{
+				IO:        io,
+				Config:    config.MustNewFromString(tt.configYML),
+				From:      "gh",
+				Overwrite: tt.overwrite,
+				SourceDir: writeGhConfig(t, tt.ghConfig, tt.ghHosts),
+			}
+
+			err := migrateRun(opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMain, mainBuf.String())
+			assert.Equal(t, tt.wantStdout, stdout.String())
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
+
+func Test_migrateRun_importsTokenWithConfirmation(t *testing.T) {
+	t.Setenv("INSTILL_CONFIG_DIR", t.TempDir())
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	io, _, stdout, _ := iostreams.Test()
+	io.SetStdinTTY(true)
+	io.SetStdoutTTY(true)
+
+	as, teardown := prompt.InitAskStubber()
+	defer teardown()
+	as.StubOne(true)
+
+	opts := &MigrateOptions{
+		IO:        io,
+		Config:    config.MustNewFromString("pager: less\n"),
+		From:      "gh",
+		SourceDir: writeGhConfig(t, "editor:\n", "github.com:\n    oauth_token: GHTOKEN\n"),
+	}
+
+	require.NoError(t, migrateRun(opts))
+	assert.Contains(t, stdout.String(), "✓ imported token for github.com")
+	assert.Equal(t, "github.com:\n    access_token: GHTOKEN\n", hostsBuf.String())
+}
+
+func Test_migrateRun_unsupportedSource(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	opts := &MigrateOptions{
+		IO:     io,
+		Config: config.ConfigStub{},
+		From:   "hub",
+	}
+
+	err := migrateRun(opts)
+	assert.EqualError(t, err, `unsupported migration source "hub": only "gh" is supported`)
+}
+
+func Test_migrateRun_backsUpExistingConfig(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("INSTILL_CONFIG_DIR", cfgDir)
+	require.NoError(t, os.WriteFile(filepath.Join(cfgDir, "config.yml"), []byte("editor: emacs\n"), 0600))
+
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	io, _, _, _ := iostreams.Test()
+	opts := &MigrateOptions{
+		IO:        io,
+		Config:    config.MustNewFromString("pager: less\n"),
+		From:      "gh",
+		SourceDir: writeGhConfig(t, "editor: vim\n", ""),
+	}
+
+	require.NoError(t, migrateRun(opts))
+
+	backup, err := os.ReadFile(filepath.Join(cfgDir, "config.yml.bak"))
+	require.NoError(t, err)
+	assert.Equal(t, "editor: emacs\n", string(backup))
+}
@@ -0,0 +1,73 @@
+package path
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type PathOptions struct {
+	IO     *iostreams.IOStreams
+	Output string
+}
+
+// configPaths is the machine-readable shape of the resolved file locations.
+type configPaths struct {
+	ConfigFile string `json:"configFile" yaml:"configFile"`
+	HostsFile  string `json:"hostsFile" yaml:"hostsFile"`
+	StateDir   string `json:"stateDir" yaml:"stateDir"`
+}
+
+func NewCmdConfigPath(f *cmdutil.Factory, runF func(*PathOptions) error) *cobra.Command {
+	opts := &PathOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the resolved configuration file locations",
+		Long: heredoc.Doc(`
+			Print the paths of the configuration file, the hosts file and the
+			state directory, one per line, after applying any environment or
+			flag overrides.
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.ValidateFormat(opts.Output); err != nil {
+				return err
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return pathRun(opts)
+		},
+	}
+
+	cmdutil.AddFormatFlag(cmd, &opts.Output)
+
+	return cmd
+}
+
+func pathRun(opts *PathOptions) error {
+	paths := configPaths{
+		ConfigFile: config.ConfigFile(),
+		HostsFile:  config.HostsConfigFile(),
+		StateDir:   config.StateDir(),
+	}
+
+	if opts.Output == "json" || opts.Output == "yaml" {
+		return cmdutil.RenderOutput(opts.IO, opts.Output, nil, nil, paths)
+	}
+
+	fmt.Fprintln(opts.IO.Out, paths.ConfigFile)
+	fmt.Fprintln(opts.IO.Out, paths.HostsFile)
+	fmt.Fprintln(opts.IO.Out, paths.StateDir)
+	return nil
+}
@@ -0,0 +1,58 @@
+package path
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_pathRun(t *testing.T) {
+	configDir := t.TempDir()
+	os.Setenv(config.INSTILL_CONFIG_DIR, configDir)
+	defer os.Unsetenv(config.INSTILL_CONFIG_DIR)
+
+	t.Run("plain output", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &PathOptions{IO: io}
+
+		require.NoError(t, pathRun(opts))
+
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, filepath.Join(configDir, "config.yml"), lines[0])
+		assert.Equal(t, filepath.Join(configDir, "hosts.yml"), lines[1])
+		assert.Equal(t, config.StateDir(), lines[2])
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		io, _, stdout, _ := iostreams.Test()
+		opts := &PathOptions{IO: io, Output: "json"}
+
+		require.NoError(t, pathRun(opts))
+
+		var paths map[string]string
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &paths))
+		assert.Equal(t, filepath.Join(configDir, "config.yml"), paths["configFile"])
+		assert.Equal(t, filepath.Join(configDir, "hosts.yml"), paths["hostsFile"])
+		assert.Equal(t, config.StateDir(), paths["stateDir"])
+	})
+
+	t.Run("reflects a TOML config file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("editor = \"vim\"\n"), 0600))
+		defer os.Remove(filepath.Join(configDir, "config.toml"))
+
+		io, _, stdout, _ := iostreams.Test()
+		opts := &PathOptions{IO: io}
+
+		require.NoError(t, pathRun(opts))
+		assert.Contains(t, stdout.String(), filepath.Join(configDir, "config.toml"))
+	})
+}
@@ -0,0 +1,82 @@
+package prompt
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type PromptOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	// Value is what the prompt option is set to: "enabled" or "disabled".
+	Value string
+}
+
+// NewCmdConfigEnablePrompt creates the `config enable-prompt` command.
+func NewCmdConfigEnablePrompt(f *cmdutil.Factory, runF func(*PromptOptions) error) *cobra.Command {
+	return newPromptCmd(f, runF, "enable-prompt", "enabled", heredoc.Doc(`
+		Enable interactive prompting in the terminal.
+
+		This is a shorthand for setting the prompt option:
+		$ inst config set prompt enabled
+	`))
+}
+
+// NewCmdConfigDisablePrompt creates the `config disable-prompt` command.
+func NewCmdConfigDisablePrompt(f *cmdutil.Factory, runF func(*PromptOptions) error) *cobra.Command {
+	return newPromptCmd(f, runF, "disable-prompt", "disabled", heredoc.Doc(`
+		Disable interactive prompting in the terminal, e.g. for scripts.
+
+		This is a shorthand for setting the prompt option:
+		$ inst config set prompt disabled
+	`))
+}
+
+func newPromptCmd(f *cmdutil.Factory, runF func(*PromptOptions) error, use, value, long string) *cobra.Command {
+	opts := &PromptOptions{
+		IO:    f.IOStreams,
+		Value: value,
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("Set the prompt option to %q", value),
+		Long:  long,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return promptRun(opts)
+		},
+	}
+}
+
+func promptRun(opts *PromptOptions) error {
+	if err := config.ValidateValue("prompt", opts.Value); err != nil {
+		return err
+	}
+
+	if err := opts.Config.Set("", "prompt", opts.Value); err != nil {
+		return fmt.Errorf("failed to set %q to %q: %w", "prompt", opts.Value, err)
+	}
+	if err := opts.Config.Write(); err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s prompting %s\n", opts.IO.ColorScheme().SuccessIcon(), opts.Value)
+	return nil
+}
@@ -0,0 +1,105 @@
+package prompt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_NewCmdConfigEnablePrompt(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{}, nil
+		},
+	}
+
+	var gotOpts *PromptOptions
+	cmd := NewCmdConfigEnablePrompt(f, func(opts *PromptOptions) error {
+		gotOpts = opts
+		return nil
+	})
+
+	cmd.SetArgs([]string{})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	_, err := cmd.ExecuteC()
+	require.NoError(t, err)
+	assert.Equal(t, "enabled", gotOpts.Value)
+}
+
+func Test_NewCmdConfigDisablePrompt(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{}, nil
+		},
+	}
+
+	var gotOpts *PromptOptions
+	cmd := NewCmdConfigDisablePrompt(f, func(opts *PromptOptions) error {
+		gotOpts = opts
+		return nil
+	})
+
+	cmd.SetArgs([]string{})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	_, err := cmd.ExecuteC()
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", gotOpts.Value)
+}
+
+func Test_promptRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		stdout string
+	}{
+		{
+			name:   "enable",
+			value:  "enabled",
+			stdout: "✓ prompting enabled\n",
+		},
+		{
+			name:   "disable",
+			value:  "disabled",
+			stdout: "✓ prompting disabled\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			cfg := config.ConfigStub{}
+
+			err := promptRun(&PromptOptions{
+				IO:     io,
+				Config: cfg,
+				Value:  tt.value,
+			})
+			require.NoError(t, err)
+
+			value, err := cfg.Get("", "prompt")
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.stdout, stdout.String())
+
+			// the change was persisted
+			_, err = cfg.Get("", "_written")
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package renamehost
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type RenameHostOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+
+	OldHostname string
+	NewHostname string
+	Overwrite   bool
+}
+
+func NewCmdConfigRenameHost(f *cmdutil.Factory, runF func(*RenameHostOptions) error) *cobra.Command {
+	opts := &RenameHostOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rename-host <old-hostname> <new-hostname>",
+		Short: "Rename a configured host",
+		Long: heredoc.Doc(`
+			Move the entire config block for a host to a new hostname, keeping
+			credentials and per-host settings intact. If the old hostname was the
+			default, the default is updated to the new name.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config rename-host api.old.example.com api.new.example.com
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+			opts.OldHostname = args[0]
+			opts.NewHostname = args[1]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return renameHostRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Overwrite, "overwrite", false, "Replace the config block for the new hostname if one already exists")
+
+	return cmd
+}
+
+func renameHostRun(opts *RenameHostOptions) error {
+	if opts.Overwrite {
+		// discard any existing block under the new name so the rename can proceed
+		opts.Config.UnsetHost(opts.NewHostname)
+	}
+
+	err := opts.Config.RenameHost(opts.OldHostname, opts.NewHostname)
+	if err != nil {
+		return fmt.Errorf("failed to rename host %q: %w", opts.OldHostname, err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.Out, "%s Renamed host %q to %q\n", cs.SuccessIcon(), opts.OldHostname, opts.NewHostname)
+	}
+	return nil
+}
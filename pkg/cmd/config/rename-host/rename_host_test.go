@@ -0,0 +1,167 @@
+package renamehost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdConfigRenameHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		output   RenameHostOptions
+		wantsErr bool
+	}{
+		{
+			name:     "no arguments",
+			input:    "",
+			wantsErr: true,
+		},
+		{
+			name:     "only old hostname",
+			input:    "old.instance",
+			wantsErr: true,
+		},
+		{
+			name:   "old and new hostname",
+			input:  "old.instance new.instance",
+			output: RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance"},
+		},
+		{
+			name:   "with overwrite",
+			input:  "old.instance new.instance --overwrite",
+			output: RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance", Overwrite: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &cmdutil.Factory{
+				Config: config.ConfigStubFactory,
+			}
+
+			argv, err := shlex.Split(tt.input)
+			assert.NoError(t, err)
+
+			var gotOpts *RenameHostOptions
+			cmd := NewCmdConfigRenameHost(f, func(opts *RenameHostOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output.OldHostname, gotOpts.OldHostname)
+			assert.Equal(t, tt.output.NewHostname, gotOpts.NewHostname)
+			assert.Equal(t, tt.output.Overwrite, gotOpts.Overwrite)
+		})
+	}
+}
+
+func Test_renameHostRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		configYML string
+		opts      *RenameHostOptions
+		wantHosts string
+		wantMain  string
+		wantErr   string
+	}{
+		{
+			name: "renames a host block",
+			configYML: heredoc.Doc(`
+				hosts:
+				    old.instance:
+				        protocol: ssh
+				    other.instance:
+				        protocol: https
+			`),
+			opts:      &RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance"},
+			wantHosts: "new.instance:\n    protocol: ssh\nother.instance:\n    protocol: https\n",
+		},
+		{
+			name: "updates the default hostname",
+			configYML: heredoc.Doc(`
+				default_hostname: old.instance
+				hosts:
+				    old.instance:
+				        protocol: ssh
+			`),
+			opts:      &RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance"},
+			wantHosts: "new.instance:\n    protocol: ssh\n",
+			wantMain:  "default_hostname: new.instance\n",
+		},
+		{
+			name: "errors when the new host already exists",
+			configYML: heredoc.Doc(`
+				hosts:
+				    old.instance:
+				        protocol: ssh
+				    new.instance:
+				        protocol: https
+			`),
+			opts:    &RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance"},
+			wantErr: `failed to rename host "old.instance": host "new.instance" already exists`,
+		},
+		{
+			name: "overwrite replaces the existing host",
+			configYML: heredoc.Doc(`
+				hosts:
+				    old.instance:
+				        protocol: ssh
+				    new.instance:
+				        protocol: https
+			`),
+			opts:      &RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance", Overwrite: true},
+			wantHosts: "new.instance:\n    protocol: ssh\n",
+		},
+		{
+			name:      "errors when the old host is not configured",
+			configYML: "hosts:\n    other.instance:\n        protocol: https\n",
+			opts:      &RenameHostOptions{OldHostname: "old.instance", NewHostname: "new.instance"},
+			wantErr:   `failed to rename host "old.instance": could not find config entry for "old.instance"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainBuf := bytes.Buffer{}
+			hostsBuf := bytes.Buffer{}
+			defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+			io, _, _, _ := iostreams.Test()
+			tt.opts.IO = io
+			tt.opts.Config = config.MustNewFromString(tt.configYML)
+
+			err := renameHostRun(tt.opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantHosts, hostsBuf.String())
+			if tt.wantMain != "" {
+				assert.Equal(t, tt.wantMain, mainBuf.String())
+			}
+		})
+	}
+}
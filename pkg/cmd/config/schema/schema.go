@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type SchemaOptions struct {
+	IO *iostreams.IOStreams
+}
+
+func NewCmdConfigSchema(f *cmdutil.Factory, runF func(*SchemaOptions) error) *cobra.Command {
+	opts := &SchemaOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema for the configuration file",
+		Long: heredoc.Doc(`
+			Print a JSON Schema describing the configuration file: every known key
+			with its description, default value, and allowed values, plus the shape
+			of the hosts and aliases sections.
+
+			The schema is generated from the same key definitions that config
+			validation uses, so it always matches what this version of inst accepts.
+			Point an editor's YAML language server at the output to get completion
+			and validation while editing the config file by hand.
+		`),
+		Example: heredoc.Doc(`
+			$ inst config schema > config-schema.json
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(opts)
+			}
+			return schemaRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func schemaRun(opts *SchemaOptions) error {
+	schema, err := json.MarshalIndent(buildSchema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(opts.IO.Out, "%s\n", schema)
+	return nil
+}
+
+// buildSchema assembles a JSON Schema document from the config key
+// definitions in the internal config package.
+func buildSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	hostProperties := map[string]interface{}{}
+	profileProperties := map[string]interface{}{}
+
+	for _, co := range config.ConfigOptions() {
+		properties[co.Key] = optionSchema(co)
+		// every option can also be set per host or per profile, where it
+		// overrides the top-level value
+		hostProperties[co.Key] = optionSchema(co)
+		profileProperties[co.Key] = optionSchema(co)
+	}
+
+	for _, key := range config.HostConfigKeys() {
+		hostProperties[key] = map[string]interface{}{"type": "string"}
+	}
+
+	properties["hosts"] = map[string]interface{}{
+		"type":        "object",
+		"description": "per-host settings, keyed by hostname",
+		"additionalProperties": map[string]interface{}{
+			"type":       "object",
+			"properties": hostProperties,
+		},
+	}
+	properties["profiles"] = map[string]interface{}{
+		"type":        "object",
+		"description": "named profiles overriding top-level settings, keyed by profile name",
+		"additionalProperties": map[string]interface{}{
+			"type":       "object",
+			"properties": profileProperties,
+		},
+	}
+	properties["aliases"] = map[string]interface{}{
+		"type":        "object",
+		"description": "command shorthands, keyed by alias name",
+		"additionalProperties": map[string]interface{}{
+			"type": "string",
+		},
+	}
+
+	return map[string]interface{}{
+		"$schema":    "https://json-schema.org/draft-07/schema#",
+		"title":      "inst configuration",
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func optionSchema(co config.ConfigOption) map[string]interface{} {
+	prop := map[string]interface{}{
+		"type":        "string",
+		"description": co.Description,
+	}
+	if co.DefaultValue != "" {
+		prop["default"] = co.DefaultValue
+	}
+	if len(co.AllowedValues) > 0 {
+		prop["enum"] = co.AllowedValues
+	}
+	return prop
+}
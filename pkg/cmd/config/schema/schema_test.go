@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdConfigSchema(t *testing.T) {
+	f := &cmdutil.Factory{}
+
+	var gotOpts *SchemaOptions
+	cmd := NewCmdConfigSchema(f, func(opts *SchemaOptions) error {
+		gotOpts = opts
+		return nil
+	})
+
+	cmd.SetArgs([]string{})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	_, err := cmd.ExecuteC()
+	assert.NoError(t, err)
+	assert.NotNil(t, gotOpts)
+}
+
+func Test_schemaRun(t *testing.T) {
+	io, _, stdout, stderr := iostreams.Test()
+
+	err := schemaRun(&SchemaOptions{IO: io})
+	require.NoError(t, err)
+	assert.Equal(t, "", stderr.String())
+
+	var schema struct {
+		Schema     string `json:"$schema"`
+		Type       string `json:"type"`
+		Properties map[string]struct {
+			Type                 string                     `json:"type"`
+			Description          string                     `json:"description"`
+			Default              string                     `json:"default"`
+			Enum                 []string                   `json:"enum"`
+			AdditionalProperties map[string]json.RawMessage `json:"additionalProperties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &schema))
+
+	assert.Equal(t, "https://json-schema.org/draft-07/schema#", schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+
+	protocol := schema.Properties["protocol"]
+	assert.Equal(t, "string", protocol.Type)
+	assert.Equal(t, "https", protocol.Default)
+	assert.Equal(t, []string{"https", "ssh"}, protocol.Enum)
+	assert.NotEmpty(t, protocol.Description)
+
+	hosts := schema.Properties["hosts"]
+	assert.Equal(t, "object", hosts.Type)
+	var hostShape struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(hosts.AdditionalProperties["properties"], &hostShape.Properties))
+	assert.Contains(t, hostShape.Properties, "access_token")
+	assert.Contains(t, hostShape.Properties, "oauth2_client_id")
+	assert.Contains(t, hostShape.Properties, "editor")
+
+	aliases := schema.Properties["aliases"]
+	assert.Equal(t, "object", aliases.Type)
+}
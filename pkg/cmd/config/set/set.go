@@ -3,14 +3,17 @@ package set
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
 )
 
 type SetOptions struct {
@@ -20,6 +23,9 @@ type SetOptions struct {
 	Key      string
 	Value    string
 	Hostname string
+	Force    bool
+	Append   bool
+	Remove   bool
 }
 
 func NewCmdConfigSet(f *cmdutil.Factory, runF func(*SetOptions) error) *cobra.Command {
@@ -35,17 +41,60 @@ func NewCmdConfigSet(f *cmdutil.Factory, runF func(*SetOptions) error) *cobra.Co
 			$ inst config set editor "code --wait"
 			$ inst config set protocol ssh --host github.com
 			$ inst config set prompt disabled
+
+			# add an item to a comma-separated list value
+			$ inst config set trusted_hosts my.instance --append
+
+			# attach a header to every API request
+			$ inst config set default_headers.X-Team platform
+
+			# read the value from standard input, keeping it out of shell history
+			$ inst config set http_proxy - < proxy.txt
 		`),
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: cmdutil.ConfigKeyValueCompletion,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			config, err := f.Config()
+			cfg, err := f.Config()
 			if err != nil {
 				return err
 			}
-			opts.Config = config
+			opts.Config = cfg
 			opts.Key = args[0]
 			opts.Value = args[1]
 
+			// a value of "-" is read from standard input, so secrets never
+			// appear in shell history
+			if opts.Value == "-" {
+				stdin, err := io.ReadAll(opts.IO.In)
+				if err != nil {
+					return fmt.Errorf("failed to read from standard input: %w", err)
+				}
+				opts.Value = strings.TrimSuffix(string(stdin), "\n")
+				opts.Value = strings.TrimSuffix(opts.Value, "\r")
+			}
+
+			if err := cmdutil.MutuallyExclusive(
+				"only one of `--append` or `--remove` may be used",
+				opts.Append,
+				opts.Remove,
+			); err != nil {
+				return err
+			}
+
+			if opts.Hostname != "" {
+				hostname, err := cmdutil.NormalizeHostname(opts.Hostname)
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid value %q for `--host`: %v", opts.Hostname, err)
+				}
+				opts.Hostname = hostname
+
+				// a host-scoped value for a global-only key would be written
+				// but never read back, so reject it outright
+				if config.IsGlobalOnly(opts.Key) {
+					return cmdutil.FlagErrorf("the %q key only takes effect globally; set it without `--host`", opts.Key)
+				}
+			}
+
 			if runF != nil {
 				return runF(opts)
 			}
@@ -55,6 +104,10 @@ func NewCmdConfigSet(f *cmdutil.Factory, runF func(*SetOptions) error) *cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&opts.Hostname, "host", "h", "", "Set per-host setting")
+	_ = cmd.RegisterFlagCompletionFunc("host", cmdutil.HostnameCompletion(f))
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite an existing value without confirmation")
+	cmd.Flags().BoolVar(&opts.Append, "append", false, "Append the value to an existing comma-separated list instead of replacing it")
+	cmd.Flags().BoolVar(&opts.Remove, "remove", false, "Remove the value from an existing comma-separated list")
 
 	return cmd
 }
@@ -70,6 +123,9 @@ func setRun(opts *SetOptions) error {
 	if err != nil {
 		var invalidValue *config.InvalidValueError
 		if errors.As(err, &invalidValue) {
+			if invalidValue.ExpectedType != "" {
+				return fmt.Errorf("failed to set %q to %q: expected %s", opts.Key, opts.Value, invalidValue.ExpectedType.Description())
+			}
 			var values []string
 			for _, v := range invalidValue.ValidValues {
 				values = append(values, fmt.Sprintf("'%s'", v))
@@ -78,7 +134,43 @@ func setRun(opts *SetOptions) error {
 		}
 	}
 
-	err = opts.Config.Set(opts.Hostname, opts.Key, opts.Value)
+	value := config.NormalizeValue(opts.Key, opts.Value)
+
+	// list operations build on the current value, so the overwrite guard below
+	// does not apply to them
+	if opts.Append || opts.Remove {
+		current, _ := opts.Config.Get(opts.Hostname, opts.Key)
+		if opts.Append {
+			value = appendListItem(current, value)
+		} else {
+			value = removeListItem(current, value)
+		}
+	}
+
+	// a set that changes nothing skips the write entirely, so the file's
+	// mtime and any hand-written comments survive
+	if current, err := opts.Config.Get(opts.Hostname, opts.Key); err == nil && current == value {
+		fmt.Fprintf(opts.IO.ErrOut, "%s no change: %q is already %q\n", opts.IO.ColorScheme().SuccessIcon(), opts.Key, value)
+		return nil
+	}
+
+	// guard against clobbering an existing value; first-time sets pass through
+	if current, err := opts.Config.Get(opts.Hostname, opts.Key); err == nil &&
+		current != "" && current != value && !opts.Force && !opts.Append && !opts.Remove && opts.IO.CanPrompt() {
+		var confirmed bool
+		err := prompt.SurveyAskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Overwrite %q (currently %q) with %q?", opts.Key, current, value),
+		}, &confirmed)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintf(opts.IO.ErrOut, "%s %q was left unchanged\n", opts.IO.ColorScheme().WarningIcon(), opts.Key)
+			return cmdutil.SilentError
+		}
+	}
+
+	err = opts.Config.Set(opts.Hostname, opts.Key, value)
 	if err != nil {
 		return fmt.Errorf("failed to set %q to %q: %w", opts.Key, opts.Value, err)
 	}
@@ -89,3 +181,39 @@ func setRun(opts *SetOptions) error {
 	}
 	return nil
 }
+
+// splitList parses a comma-separated config value, trimming whitespace and
+// dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// appendListItem adds the item to a comma-separated list unless it is already
+// present.
+func appendListItem(list, item string) string {
+	items := splitList(list)
+	for _, existing := range items {
+		if existing == item {
+			return strings.Join(items, ",")
+		}
+	}
+	return strings.Join(append(items, item), ",")
+}
+
+// removeListItem deletes every occurrence of the item from a comma-separated
+// list.
+func removeListItem(list, item string) string {
+	var items []string
+	for _, existing := range splitList(list) {
+		if existing != item {
+			items = append(items, existing)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -10,12 +10,14 @@ import (
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
 )
 
 func TestNewCmdConfigSet(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
+		stdin    string
 		output   SetOptions
 		wantsErr bool
 	}{
@@ -43,11 +45,31 @@ func TestNewCmdConfigSet(t *testing.T) {
 			output:   SetOptions{Hostname: "test.com", Key: "key", Value: "value"},
 			wantsErr: false,
 		},
+		{
+			name:     "--append conflicts with --remove",
+			input:    "key value --append --remove",
+			wantsErr: true,
+		},
+		{
+			name:     "global-only key with --host",
+			input:    "prompt disabled --host test.com",
+			wantsErr: true,
+		},
+		{
+			name:     "set key value from stdin",
+			input:    "key -",
+			stdin:    "s3cret\n",
+			output:   SetOptions{Key: "key", Value: "s3cret"},
+			wantsErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, _, _ := iostreams.Test()
+			stdin.WriteString(tt.stdin)
 			f := &cmdutil.Factory{
+				IOStreams: io,
 				Config: func() (config.Config, error) {
 					return config.ConfigStub{}, nil
 				},
@@ -89,6 +111,7 @@ func Test_setRun(t *testing.T) {
 		expectedValue string
 		stdout        string
 		stderr        string
+		noChange      bool
 		wantsErr      bool
 		errMsg        string
 	}{
@@ -121,6 +144,80 @@ func Test_setRun(t *testing.T) {
 			expectedValue: "someValue",
 			stderr:        "! warning: 'unknownKey' is not a known configuration key\n",
 		},
+		{
+			name: "set mixed-case value stores canonical form",
+			input: &SetOptions{
+				Config: config.ConfigStub{},
+				Key:    "protocol",
+				Value:  "HTTPS",
+			},
+			expectedValue: "https",
+		},
+		{
+			name: "set value with surrounding whitespace",
+			input: &SetOptions{
+				Config: config.ConfigStub{},
+				Key:    "prompt",
+				Value:  " Disabled ",
+			},
+			expectedValue: "disabled",
+		},
+		{
+			name: "append to an empty list",
+			input: &SetOptions{
+				Config: config.ConfigStub{},
+				Key:    "trusted_hosts",
+				Value:  "a.com",
+				Append: true,
+			},
+			expectedValue: "a.com",
+			stderr:        "! warning: 'trusted_hosts' is not a known configuration key\n",
+		},
+		{
+			name: "append to an existing list",
+			input: &SetOptions{
+				Config: config.ConfigStub{"trusted_hosts": "a.com"},
+				Key:    "trusted_hosts",
+				Value:  "b.com",
+				Append: true,
+			},
+			expectedValue: "a.com,b.com",
+			stderr:        "! warning: 'trusted_hosts' is not a known configuration key\n",
+		},
+		{
+			name: "append de-duplicates",
+			input: &SetOptions{
+				Config: config.ConfigStub{"trusted_hosts": "a.com,b.com"},
+				Key:    "trusted_hosts",
+				Value:  "a.com",
+				Append: true,
+			},
+			expectedValue: "a.com,b.com",
+			stderr:        "! warning: 'trusted_hosts' is not a known configuration key\n✓ no change: \"trusted_hosts\" is already \"a.com,b.com\"\n",
+			noChange:      true,
+		},
+		{
+			name: "no-op set skips the write",
+			input: &SetOptions{
+				Config: config.ConfigStub{"editor": "vim"},
+				Key:    "editor",
+				Value:  "vim",
+			},
+			expectedValue: "vim",
+			stderr:        "✓ no change: \"editor\" is already \"vim\"\n",
+			noChange:      true,
+		},
+		{
+			name: "remove an item",
+			input: &SetOptions{
+				Config: config.ConfigStub{"trusted_hosts": "a.com,b.com"},
+				Key:    "trusted_hosts",
+				Value:  "a.com",
+				Remove: true,
+			},
+			expectedValue: "b.com",
+			stderr:        "! warning: 'trusted_hosts' is not a known configuration key\n",
+		},
 		{
 			name: "set invalid value",
 			input: &SetOptions{
@@ -131,6 +228,25 @@ func Test_setRun(t *testing.T) {
 			wantsErr: true,
 			errMsg:   "failed to set \"protocol\" to \"invalid\": valid values are 'https', 'ssh'",
 		},
+		{
+			name: "set non-integer value for an int key",
+			input: &SetOptions{
+				Config: config.ConfigStub{},
+				Key:    "http_retry_base_ms",
+				Value:  "soon",
+			},
+			wantsErr: true,
+			errMsg:   "failed to set \"http_retry_base_ms\" to \"soon\": expected an integer value",
+		},
+		{
+			name: "set bool value stores canonical form",
+			input: &SetOptions{
+				Config: config.ConfigStub{},
+				Key:    "http_retry_jitter",
+				Value:  "False",
+			},
+			expectedValue: "false",
+		},
 	}
 	for _, tt := range tests {
 		io, _, stdout, stderr := iostreams.Test()
@@ -151,8 +267,100 @@ func Test_setRun(t *testing.T) {
 			assert.Equal(t, tt.expectedValue, val)
 
 			val, err = tt.input.Config.Get("", "_written")
+			if tt.noChange {
+				assert.Error(t, err, "expected no write to have happened")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "true", val)
+			}
+		})
+	}
+}
+
+func Test_setRun_noopLeavesFileUntouched(t *testing.T) {
+	mainBuf := bytes.Buffer{}
+	hostsBuf := bytes.Buffer{}
+	defer config.StubWriteConfig(&mainBuf, &hostsBuf)()
+
+	io, _, _, stderr := iostreams.Test()
+	opts := &SetOptions{
+		IO:     io,
+		Config: config.MustNewFromString("editor: vim\n"),
+		Key:    "editor",
+		Value:  "vim",
+	}
+
+	assert.NoError(t, setRun(opts))
+	assert.Contains(t, stderr.String(), `no change: "editor" is already "vim"`)
+	assert.Empty(t, mainBuf.String())
+	assert.Empty(t, hostsBuf.String())
+}
+
+func Test_setRun_overwriteGuard(t *testing.T) {
+	tests := []struct {
+		name          string
+		force         bool
+		canPrompt     bool
+		confirm       bool
+		expectedValue string
+		wantsErr      bool
+	}{
+		{
+			name:          "confirmed overwrite",
+			canPrompt:     true,
+			confirm:       true,
+			expectedValue: "emacs",
+		},
+		{
+			name:          "declined overwrite keeps the old value",
+			canPrompt:     true,
+			confirm:       false,
+			expectedValue: "vim",
+			wantsErr:      true,
+		},
+		{
+			name:          "force skips the prompt",
+			force:         true,
+			canPrompt:     true,
+			expectedValue: "emacs",
+		},
+		{
+			name:          "prompting disabled skips the guard",
+			canPrompt:     false,
+			expectedValue: "emacs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, _ := iostreams.Test()
+			io.SetStdinTTY(tt.canPrompt)
+			io.SetStdoutTTY(tt.canPrompt)
+
+			as, teardown := prompt.InitAskStubber()
+			defer teardown()
+			if tt.canPrompt && !tt.force {
+				as.StubOne(tt.confirm)
+			}
+
+			opts := &SetOptions{
+				IO:     io,
+				Config: config.ConfigStub{"editor": "vim"},
+				Key:    "editor",
+				Value:  "emacs",
+				Force:  tt.force,
+			}
+
+			err := setRun(opts)
+			if tt.wantsErr {
+				assert.Equal(t, cmdutil.SilentError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			val, err := opts.Config.Get("", "editor")
 			assert.NoError(t, err)
-			assert.Equal(t, "true", val)
+			assert.Equal(t, tt.expectedValue, val)
 		})
 	}
 }
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+type ValidateOptions struct {
+	IO     *iostreams.IOStreams
+	Config config.Config
+}
+
+func NewCmdConfigValidate(f *cmdutil.Factory, runF func(*ValidateOptions) error) *cobra.Command {
+	opts := &ValidateOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for unknown keys and invalid values",
+		Long: heredoc.Doc(`
+			Check the configuration file for unrecognized keys and invalid option values.
+
+			Exits non-zero when any problem is found, so it can run in CI.
+		`),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.Config()
+			if err != nil {
+				return err
+			}
+			opts.Config = config
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return validateRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func validateRun(opts *ValidateOptions) error {
+	cs := opts.IO.ColorScheme()
+	problems := 0
+
+	for _, key := range opts.Config.UnknownKeys() {
+		fmt.Fprintf(opts.IO.ErrOut, "%s unknown config key %q\n", cs.WarningIcon(), key)
+		problems++
+	}
+
+	for _, co := range config.ConfigOptions() {
+		val, err := opts.Config.Get("", co.Key)
+		if err != nil {
+			continue
+		}
+		if err := config.ValidateValue(co.Key, val); err != nil {
+			var invalidValue *config.InvalidValueError
+			if errors.As(err, &invalidValue) {
+				if invalidValue.ExpectedType != "" {
+					fmt.Fprintf(opts.IO.ErrOut, "%s invalid value %q for %q: expected %s\n",
+						cs.WarningIcon(), val, co.Key, invalidValue.ExpectedType.Description())
+				} else {
+					fmt.Fprintf(opts.IO.ErrOut, "%s invalid value %q for %q: valid values are %s\n",
+						cs.WarningIcon(), val, co.Key, strings.Join(invalidValue.ValidValues, ", "))
+				}
+				problems++
+			}
+		}
+	}
+
+	if problems > 0 {
+		return cmdutil.SilentError
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s configuration is valid\n", cs.SuccessIcon())
+	return nil
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func Test_validateRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.Config
+		stdout  string
+		stderr  string
+		wantErr error
+	}{
+		{
+			name:   "valid config",
+			config: config.MustNewFromString("editor: vim\n"),
+			stdout: "✓ configuration is valid\n",
+		},
+		{
+			name:    "unknown key",
+			config:  config.MustNewFromString("protcol: https\n"),
+			stderr:  "! unknown config key \"protcol\"\n",
+			wantErr: cmdutil.SilentError,
+		},
+		{
+			name:    "invalid value",
+			config:  config.MustNewFromString("prompt: sometimes\n"),
+			stderr:  "! invalid value \"sometimes\" for \"prompt\": valid values are enabled, disabled\n",
+			wantErr: cmdutil.SilentError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, stderr := iostreams.Test()
+			opts := &ValidateOptions{
+				IO:     io,
+				Config: tt.config,
+			}
+
+			err := validateRun(opts)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.stdout, stdout.String())
+			assert.Equal(t, tt.stderr, stderr.String())
+		})
+	}
+}
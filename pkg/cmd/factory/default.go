@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmdutil"
@@ -26,21 +27,67 @@ func New(appVersion string) *cmdutil.Factory {
 		},
 	}
 
-	f.IOStreams = ioStreams(f)                   // Depends on Config
-	f.HTTPClient = httpClientFunc(f, appVersion) // Depends on Config, IOStreams, and appVersion
-	f.Browser = browser(f)                       // Depends on Config, and IOStreams
+	f.IOStreams = ioStreams(f)                                         // Depends on Config
+	f.HTTPClient = httpClientFunc(f, appVersion, true)                 // Depends on Config, IOStreams, and appVersion
+	f.UnauthenticatedHTTPClient = httpClientFunc(f, appVersion, false) // HTTPClient without the Authorization middleware
+	f.Browser = browser(f)                                             // Depends on Config, and IOStreams
 
 	return f
 }
 
-func httpClientFunc(f *cmdutil.Factory, appVersion string) func() (*http.Client, error) {
+func httpClientFunc(f *cmdutil.Factory, appVersion string, setAuth bool) func() (*http.Client, error) {
 	return func() (*http.Client, error) {
 		io := f.IOStreams
 		cfg, err := f.Config()
 		if err != nil {
 			return nil, err
 		}
-		return NewHTTPClient(io, cfg, appVersion, true)
+		if setAuth {
+			warnTokenExpiry(io, cfg)
+		}
+		return NewHTTPClient(io, cfg, appVersion, true, setAuth, f.HTTPMiddleware...)
+	}
+}
+
+// swappable for tests
+var expiryWarnNow = time.Now
+
+// warnTokenExpiry reports hosts whose access token has expired or will expire
+// within the token_expiry_warn window, so tokens can be refreshed before
+// requests start failing. Setting the window to "0" disables the warning.
+func warnTokenExpiry(io *iostreams.IOStreams, cfg config.Config) {
+	window := 24 * time.Hour
+	if raw, _ := cfg.Get("", "token_expiry_warn"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d >= 0 {
+			window = d
+		} else {
+			io.LogWarningf("invalid value %q for token_expiry_warn, using the default", raw)
+		}
+	}
+	if window == 0 {
+		return
+	}
+
+	hostnames, err := cfg.Hosts()
+	if err != nil {
+		return
+	}
+	now := expiryWarnNow()
+	for _, hostname := range hostnames {
+		token, _ := cfg.Get(hostname, "access_token")
+		expiry, _ := cfg.Get(hostname, "expiry")
+		if token == "" || expiry == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC1123, expiry)
+		if err != nil {
+			continue
+		}
+		if remaining := t.Sub(now); remaining <= 0 {
+			io.LogWarningf("the access token for %s has expired; run `inst auth refresh --hostname %s` to renew it", hostname, hostname)
+		} else if remaining <= window {
+			io.LogWarningf("the access token for %s expires in %s; run `inst auth refresh --hostname %s` to renew it", hostname, remaining.Round(time.Minute), hostname)
+		}
 	}
 }
 
@@ -144,6 +191,14 @@ func ioStreams(f *cmdutil.Factory) *iostreams.IOStreams {
 		io.SetNeverPrompt(true)
 	}
 
+	if spinner, _ := cfg.Get("", "spinner"); spinner == "disabled" {
+		io.SetProgressIndicatorEnabled(false)
+	}
+
+	if colorPref, _ := cfg.Get("", "color"); colorPref != "" {
+		io.SetColorFromConfig(colorPref)
+	}
+
 	// Pager precedence
 	// 1. INSTILL_PAGER
 	// 2. pager from config
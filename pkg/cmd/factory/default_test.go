@@ -3,10 +3,13 @@ package factory
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
 func Test_ioStreams_pager(t *testing.T) {
@@ -131,7 +134,7 @@ func Test_browserLauncher(t *testing.T) {
 		},
 		{
 			name:        "config browser set",
-			config:      config.NewFromString("browser: CONFIG_BROWSER"),
+			config:      config.MustNewFromString("browser: CONFIG_BROWSER"),
 			wantBrowser: "CONFIG_BROWSER",
 		},
 		{
@@ -146,7 +149,7 @@ func Test_browserLauncher(t *testing.T) {
 			env: map[string]string{
 				"INSTILL_BROWSER": "INSTILL_BROWSER",
 			},
-			config:      config.NewFromString("browser: CONFIG_BROWSER"),
+			config:      config.MustNewFromString("browser: CONFIG_BROWSER"),
 			wantBrowser: "INSTILL_BROWSER",
 		},
 		{
@@ -154,7 +157,7 @@ func Test_browserLauncher(t *testing.T) {
 			env: map[string]string{
 				"BROWSER": "BROWSER",
 			},
-			config:      config.NewFromString("browser: CONFIG_BROWSER"),
+			config:      config.MustNewFromString("browser: CONFIG_BROWSER"),
 			wantBrowser: "CONFIG_BROWSER",
 		},
 		{
@@ -190,9 +193,57 @@ func Test_browserLauncher(t *testing.T) {
 }
 
 func pagerConfig() config.Config {
-	return config.NewFromString("pager: CONFIG_PAGER")
+	return config.MustNewFromString("pager: CONFIG_PAGER")
 }
 
 func disablePromptConfig() config.Config {
-	return config.NewFromString("prompt: disabled")
+	return config.MustNewFromString("prompt: disabled")
+}
+
+func Test_warnTokenExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	expiryWarnNow = func() time.Time { return now }
+	defer func() { expiryWarnNow = time.Now }()
+
+	hostConfig := func(window string, expiry time.Time) config.Config {
+		return config.MustNewFromString(heredoc.Docf(`
+			token_expiry_warn: %s
+			hosts:
+			    instill.tech:
+			        access_token: TOKEN
+			        expiry: %s
+		`, window, expiry.Format(time.RFC1123)))
+	}
+
+	t.Run("token expiring within the window", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		warnTokenExpiry(io, hostConfig("24h", now.Add(2*time.Hour)))
+		assert.Contains(t, stderr.String(), "the access token for instill.tech expires in 2h0m0s")
+		assert.Contains(t, stderr.String(), "inst auth refresh --hostname instill.tech")
+	})
+
+	t.Run("token with plenty of lifetime", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		warnTokenExpiry(io, hostConfig("24h", now.Add(48*time.Hour)))
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		warnTokenExpiry(io, hostConfig("24h", now.Add(-time.Hour)))
+		assert.Contains(t, stderr.String(), "the access token for instill.tech has expired")
+	})
+
+	t.Run("warning disabled", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		warnTokenExpiry(io, hostConfig("0", now.Add(time.Hour)))
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("invalid window falls back to the default", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		warnTokenExpiry(io, hostConfig("soon", now.Add(2*time.Hour)))
+		assert.Contains(t, stderr.String(), `invalid value "soon" for token_expiry_warn`)
+		assert.Contains(t, stderr.String(), "expires in 2h0m0s")
+	})
 }
@@ -1,9 +1,12 @@
 package factory
 
 import (
+	"crypto/tls"
 	"fmt"
+	stdio "io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,19 +60,57 @@ var timezoneNames = map[int]string{
 // TODO wrong name
 type configHTTPClient interface {
 	Get(string, string) (string, error)
+	GetInt(string, string) (int, error)
+	GetBool(string, string) (bool, error)
 	Set(string, string, string) error
 	Write() error
 	SaveTyped(*config.HostConfigTyped) error
+	DefaultHeaders() (map[string]string, error)
 }
 
-// NewHTTPClient is a generic authenticated HTTP client for commands
-func NewHTTPClient(io *iostreams.IOStreams, cfg configHTTPClient, appVersion string, setAccept bool) (*http.Client, error) {
+// NewHTTPClient is a generic authenticated HTTP client for commands. The
+// transport is built from an ordered chain of middleware wrappers: the tuned
+// base transport and unix socket support first, then any externally registered
+// middleware, and the built-in header middleware (ending with Authorization)
+// last, so registered middleware observes fully authenticated requests. With
+// setAuth false the Authorization middleware is left out entirely, for
+// endpoints that reject or do not need a token.
+func NewHTTPClient(io *iostreams.IOStreams, cfg configHTTPClient, appVersion string, setAccept, setAuth bool, middleware ...api.ClientOption) (*http.Client, error) {
 	var opts []api.ClientOption
 
-	// We need to check and potentially add the unix socket roundtripper option
-	// before adding any other options, since if we are going to use the unix
-	// socket transport, it needs to form the base of the transport chain
-	// represented by invocations of opts...
+	// a customized transport replaces the default base of the chain, so it has
+	// to be the very first option
+	base := tunedTransport(io, cfg)
+
+	cert, err := clientCertificate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cert != nil {
+		if base == nil {
+			base = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		if base.TLSClientConfig == nil {
+			base.TLSClientConfig = &tls.Config{}
+		}
+		base.TLSClientConfig.Certificates = append(base.TLSClientConfig.Certificates, *cert)
+	}
+
+	// dial overrides (`inst api --resolve`) ride on the request context, so
+	// they apply for a single invocation; hook them into whichever base
+	// transport ends up carrying the connection
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	base.DialContext = api.ResolveDialContext(base.DialContext)
+
+	opts = append(opts, api.ClientOption(func(http.RoundTripper) http.RoundTripper {
+		return base
+	}))
+
+	// We need to add the unix socket roundtripper option before adding any
+	// other options, since the unix socket transport needs to form the base of
+	// the transport chain represented by invocations of opts...
 	//
 	// Another approach might be to change the signature of api.NewHTTPClient to
 	// take an explicit base http.RoundTripper as its first parameter (it
@@ -78,32 +119,57 @@ func NewHTTPClient(io *iostreams.IOStreams, cfg configHTTPClient, appVersion str
 	// which would use that non-default behavior is right here, and it doesn't
 	// seem worth the cognitive overhead everywhere else just to serve this one
 	// use case.
-	unixSocket, err := cfg.Get("", "http_unix_socket")
-	if err != nil {
-		return nil, err
+	// `http_unix_socket` is resolved with host scoping, so one host can be
+	// reached over a socket while others use the normal TCP transport.
+	opts = append(opts, api.ClientOption(func(tr http.RoundTripper) http.RoundTripper {
+		return httpunix.NewHostRoundTripper(func(host string) string {
+			socketPath, _ := cfg.Get(host, "http_unix_socket")
+			return socketPath
+		}, tr)
+	}))
+
+	if opt, ok := VerboseLogOption(io, false); ok {
+		opts = append(opts, opt)
 	}
-	if unixSocket != "" {
-		opts = append(opts, api.ClientOption(func(http.RoundTripper) http.RoundTripper {
-			return httpunix.NewRoundTripper(unixSocket)
+
+	var validationCache *oauth2.ValidationCache
+	if setAuth {
+		validationCache = oauth2.NewValidationCache(authCacheTTL(io, cfg))
+		opts = append(opts, api.ClientOption(func(tr http.RoundTripper) http.RoundTripper {
+			return &authCacheInvalidator{rt: tr, cache: validationCache, cfg: cfg}
 		}))
 	}
 
-	if verbose := os.Getenv("DEBUG"); verbose != "" {
-		logTraffic := strings.Contains(verbose, "api")
-		opts = append(opts, api.VerboseLog(io.ErrOut, logTraffic, io.IsStderrTTY()))
+	// externally registered middleware wraps the transport built so far; the
+	// fold is reversed so that middleware runs in registration order on each
+	// request
+	for i := len(middleware) - 1; i >= 0; i-- {
+		opts = append(opts, middleware[i])
 	}
 
 	opts = append(opts,
+		// headers from the default_headers config mapping ride on every
+		// request; headers set explicitly by the caller (e.g. `inst api -H`)
+		// win because existing values are never overwritten
+		api.AddDefaultHeaders(func(*http.Request) (map[string]string, error) {
+			headers, err := cfg.DefaultHeaders()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read default_headers from config: %w", err)
+			}
+			return headers, nil
+		}),
 		api.AddHeader("User-Agent", fmt.Sprintf("Instill CLI %s", appVersion)),
-		api.AddHeaderFunc("Authorization", func(req *http.Request) (string, error) {
+		// a fresh ID per request correlates client logs with server logs; an
+		// explicit -H X-Request-Id wins over the generated one
+		api.AddRequestID(),
+		api.AddHeaderFunc("Accept-Language", func(req *http.Request) (string, error) {
+			// per-host preferred language for localized server messages
 			hostname := getHost(req)
-			if accessToken, err := cfg.Get(hostname, "access_token"); err == nil && accessToken != "" {
-				// Refresh access token every time
-				if accessToken, err = oauth2.RefreshToken(cfg, hostname); err == nil && accessToken != "" {
-					return fmt.Sprintf("bearer %s", accessToken), nil
-				}
+			lang, err := cfg.Get(hostname, "accept_language")
+			if err != nil {
+				return "", nil
 			}
-			return "", err
+			return lang, nil
 		}),
 		api.AddHeaderFunc("Time-Zone", func(req *http.Request) (string, error) {
 			if req.Method != "GET" && req.Method != "HEAD" {
@@ -127,9 +193,270 @@ func NewHTTPClient(io *iostreams.IOStreams, cfg configHTTPClient, appVersion str
 		)
 	}
 
+	// the Authorization header is applied last among the middleware so that
+	// everything registered above sees the final, authenticated request
+	if setAuth {
+		opts = append(opts,
+			api.AddHeaderFunc("Authorization", func(req *http.Request) (string, error) {
+				hostname := getHost(req)
+				if accessToken, err := cfg.Get(hostname, "access_token"); err == nil && accessToken != "" {
+					// a recently validated token is reused as-is, skipping the
+					// refresh round trip
+					if validationCache.IsValid(hostname, accessToken) {
+						return fmt.Sprintf("bearer %s", accessToken), nil
+					}
+					if accessToken, err = oauth2.RefreshToken(cfg, hostname); err == nil && accessToken != "" {
+						_ = validationCache.MarkValidated(hostname, accessToken)
+						return fmt.Sprintf("bearer %s", accessToken), nil
+					}
+				}
+				return "", nil
+			}),
+		)
+	}
+
+	// added last so that retried attempts re-run the whole chain, including
+	// refreshing the Authorization header
+	opts = append(opts, api.Retry(retryPolicy(io, cfg)))
+
+	// outermost of all, so every poll re-runs the full chain and picks up a
+	// token that was provisioned after the CLI started
+	if setAuth {
+		if wait := authWait(io, cfg); wait > 0 {
+			opts = append(opts, api.ClientOption(func(tr http.RoundTripper) http.RoundTripper {
+				return &authWaitRoundTripper{rt: tr, io: io, wait: wait}
+			}))
+		}
+	}
+
 	return api.NewHTTPClient(opts...), nil
 }
 
+// authCacheTTL resolves the auth_cache_ttl config key, which bounds how long a
+// validated access token is reused without re-validating. A value that does
+// not parse produces a warning and falls back to the default.
+func authCacheTTL(io *iostreams.IOStreams, cfg configHTTPClient) time.Duration {
+	ttl := 5 * time.Minute
+	if raw, _ := cfg.Get("", "auth_cache_ttl"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d >= 0 {
+			ttl = d
+		} else {
+			io.LogWarningf("invalid value %q for auth_cache_ttl, using the default", raw)
+		}
+	}
+	return ttl
+}
+
+// authCacheInvalidator drops the recorded validation of a host's token
+// whenever the server answers 401, so the next request re-validates instead of
+// trusting the cache.
+type authCacheInvalidator struct {
+	rt    http.RoundTripper
+	cache *oauth2.ValidationCache
+	cfg   configHTTPClient
+}
+
+func (t *authCacheInvalidator) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		hostname := getHost(req)
+		if token, tokenErr := t.cfg.Get(hostname, "access_token"); tokenErr == nil && token != "" {
+			t.cache.Invalidate(hostname, token)
+		}
+	}
+	return res, err
+}
+
+// authWait resolves the auth_wait config key, which bounds how long a request
+// answered with 401 is retried while a token may still be getting provisioned.
+// Waiting is strictly opt-in: an unset or zero value, or one that does not
+// parse, disables it.
+func authWait(io *iostreams.IOStreams, cfg configHTTPClient) time.Duration {
+	if raw, _ := cfg.Get("", "auth_wait"); raw != "" && raw != "0" {
+		if d, err := time.ParseDuration(raw); err == nil && d >= 0 {
+			return d
+		}
+		io.LogWarningf("invalid value %q for auth_wait, ignoring it", raw)
+	}
+	return 0
+}
+
+// authWaitInterval is how long authWaitRoundTripper pauses between polls.
+var authWaitInterval = 2 * time.Second // swappable for tests
+
+// authWaitRoundTripper keeps retrying a request that came back 401, for up to
+// the auth_wait duration, to cover orchestrated environments where the token
+// is provisioned slightly after the CLI starts. Each retry re-runs the whole
+// middleware chain, so the Authorization header is rebuilt from the current
+// config and environment.
+type authWaitRoundTripper struct {
+	rt   http.RoundTripper
+	io   *iostreams.IOStreams
+	wait time.Duration
+}
+
+func (t *authWaitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	// a one-shot request body cannot be replayed
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+
+	t.io.LogWarningf("got HTTP 401 from %s, retrying for up to %s in case the token is still being provisioned (auth_wait)", getHost(req), t.wait)
+
+	deadline := time.Now().Add(t.wait)
+	for time.Now().Before(deadline) {
+		interval := authWaitInterval
+		if remaining := time.Until(deadline); remaining < interval {
+			interval = remaining
+		}
+		select {
+		case <-req.Context().Done():
+			return res, nil
+		case <-time.After(interval):
+		}
+
+		// drain the failed response so its connection can be reused
+		_, _ = stdio.Copy(stdio.Discard, res.Body)
+		res.Body.Close()
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			retry.Body = body
+		}
+
+		res, err = t.rt.RoundTrip(retry)
+		if err != nil || res.StatusCode != http.StatusUnauthorized {
+			return res, err
+		}
+	}
+	t.io.LogWarningf("still getting HTTP 401 from %s after waiting %s, giving up", getHost(req), t.wait)
+	return res, err
+}
+
+// retryPolicy builds the retry/backoff settings for the HTTP client from the
+// http_retry_base_ms, http_retry_max_ms and http_retry_jitter config keys.
+// Values that do not parse produce a warning and fall back to the defaults.
+func retryPolicy(io *iostreams.IOStreams, cfg configHTTPClient) api.RetryPolicy {
+	policy := api.DefaultRetryPolicy()
+
+	if n, err := cfg.GetInt("", "http_retry_base_ms"); err != nil {
+		io.LogWarningf("%v, using the default", err)
+	} else if n > 0 {
+		policy.Base = time.Duration(n) * time.Millisecond
+	} else {
+		io.LogWarningf("invalid value %q for http_retry_base_ms, using the default", strconv.Itoa(n))
+	}
+
+	if n, err := cfg.GetInt("", "http_retry_max_ms"); err != nil {
+		io.LogWarningf("%v, using the default", err)
+	} else if n > 0 {
+		policy.Cap = time.Duration(n) * time.Millisecond
+	} else {
+		io.LogWarningf("invalid value %q for http_retry_max_ms, using the default", strconv.Itoa(n))
+	}
+
+	if jitter, err := cfg.GetBool("", "http_retry_jitter"); err != nil {
+		io.LogWarningf("%v, using the default", err)
+	} else {
+		policy.Jitter = jitter
+	}
+
+	return policy
+}
+
+// tunedTransport applies the http_max_idle_conns, http_idle_timeout and
+// http_force_http1 config keys to a clone of http.DefaultTransport. It returns
+// nil when none of the keys are set, so callers keep the default transport;
+// values that do not parse produce a warning and fall back to Go's defaults.
+func tunedTransport(io *iostreams.IOStreams, cfg configHTTPClient) *http.Transport {
+	maxIdleConns, _ := cfg.Get("", "http_max_idle_conns")
+	idleTimeout, _ := cfg.Get("", "http_idle_timeout")
+	forceHTTP1, forceErr := cfg.GetBool("", "http_force_http1")
+	if forceErr != nil {
+		io.LogWarningf("%v, using the default", forceErr)
+		forceHTTP1 = false
+	}
+	if maxIdleConns == "" && idleTimeout == "" && !forceHTTP1 {
+		return nil
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+
+	if maxIdleConns != "" {
+		if n, err := cfg.GetInt("", "http_max_idle_conns"); err != nil {
+			io.LogWarningf("%v, using the default", err)
+		} else if n > 0 {
+			tr.MaxIdleConns = n
+		} else {
+			io.LogWarningf("invalid value %q for http_max_idle_conns, using the default", maxIdleConns)
+		}
+	}
+
+	if idleTimeout != "" {
+		if d, err := time.ParseDuration(idleTimeout); err == nil && d > 0 {
+			tr.IdleConnTimeout = d
+		} else {
+			io.LogWarningf("invalid value %q for http_idle_timeout, using the default", idleTimeout)
+		}
+	}
+
+	if forceHTTP1 {
+		tr.ForceAttemptHTTP2 = false
+		// an empty, non-nil map keeps the TLS handshake from negotiating h2
+		tr.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return tr
+}
+
+// clientCertificate loads the mutual-TLS client certificate configured via the
+// tls_client_cert and tls_client_key keys. It returns nil when neither key is
+// set; setting only one half of the pair is an error, and an invalid pair
+// fails here, at client construction, rather than on the first request.
+func clientCertificate(cfg configHTTPClient) (*tls.Certificate, error) {
+	certFile, _ := cfg.Get("", "tls_client_cert")
+	keyFile, _ := cfg.Get("", "tls_client_key")
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("mutual TLS requires both tls_client_cert and tls_client_key to be set")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// VerboseLogOption resolves wire-traffic logging from the INSTILL_DEBUG and
+// DEBUG environment variables, or forces it on for commands with a --verbose
+// flag. Headers are logged with sensitive values redacted; bodies are included
+// only with INSTILL_DEBUG=api:body (or DEBUG=api:body). The second return
+// value reports whether logging is enabled at all.
+func VerboseLogOption(io *iostreams.IOStreams, force bool) (api.ClientOption, bool) {
+	debug := os.Getenv("INSTILL_DEBUG")
+	if debug == "" {
+		debug = os.Getenv("DEBUG")
+	}
+	if debug == "" && !force {
+		return nil, false
+	}
+
+	logHeaders := force || strings.Contains(debug, "api")
+	logBodies := strings.Contains(debug, "api:body")
+	return api.VerboseLogWithBodies(io.ErrOut, logHeaders, logBodies, io.IsStderrTTY()), true
+}
+
 func getHost(r *http.Request) string {
 	if r.Host != "" {
 		return r.Host
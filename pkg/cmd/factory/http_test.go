@@ -1,17 +1,34 @@
 package factory
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/instill-ai/cli/api"
 	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/iostreams"
 )
@@ -74,6 +91,38 @@ func TestNewHTTPClient(t *testing.T) {
 			},
 			wantStderr: "",
 		},
+		{
+			name: "instill.tech with configured accept_language",
+			args: args{
+				config: tinyConfig{
+					"instill.tech:access_token":    "MYTOKEN",
+					"instill.tech:accept_language": "zh-TW",
+				},
+				appVersion: "v1.2.3",
+				setAccept:  true,
+			},
+			host: "instill.tech",
+			wantHeader: map[string]string{
+				"authorization":   "bearer MYTOKEN",
+				"user-agent":      "Instill CLI v1.2.3",
+				"accept-language": "zh-TW",
+			},
+			wantStderr: "",
+		},
+		{
+			name: "instill.tech without accept_language",
+			args: args{
+				config:     tinyConfig{"instill.tech:access_token": "MYTOKEN"},
+				appVersion: "v1.2.3",
+				setAccept:  true,
+			},
+			host: "instill.tech",
+			wantHeader: map[string]string{
+				"authorization":   "bearer MYTOKEN",
+				"accept-language": "",
+			},
+			wantStderr: "",
+		},
 		{
 			name: "instill.tech in verbose mode",
 			args: args{
@@ -94,6 +143,7 @@ func TestNewHTTPClient(t *testing.T) {
 				> Host: instill.tech
 				> Authorization: bearer ████████████████████
 				> User-Agent: Instill CLI v1.2.3
+				> X-Request-Id: <request-id>
 
 				< HTTP/1.1 204 No Content
 				< Date: <time>
@@ -119,7 +169,7 @@ func TestNewHTTPClient(t *testing.T) {
 			})
 
 			io, _, _, stderr := iostreams.Test()
-			client, err := NewHTTPClient(io, tt.args.config, tt.args.appVersion, tt.args.setAccept)
+			client, err := NewHTTPClient(io, tt.args.config, tt.args.appVersion, tt.args.setAccept, true)
 			require.NoError(t, err)
 
 			req, err := http.NewRequest("GET", ts.URL, nil)
@@ -149,6 +199,36 @@ func (c tinyConfig) Get(host, key string) (string, error) {
 	return c[fmt.Sprintf("%s:%s", host, key)], nil
 }
 
+func (c tinyConfig) GetInt(host, key string) (int, error) {
+	raw := c.rawOrDefault(host, key)
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for %s", raw, key)
+	}
+	return n, nil
+}
+
+func (c tinyConfig) GetBool(host, key string) (bool, error) {
+	raw := c.rawOrDefault(host, key)
+	b, err := strconv.ParseBool(strings.ToLower(raw))
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s", raw, key)
+	}
+	return b, nil
+}
+
+func (c tinyConfig) rawOrDefault(host, key string) string {
+	raw, _ := c.Get(host, key)
+	if raw == "" {
+		for _, opt := range config.ConfigOptions() {
+			if opt.Key == key {
+				return opt.DefaultValue
+			}
+		}
+	}
+	return raw
+}
+
 func (c tinyConfig) Set(host, key, value string) error {
 	return nil
 }
@@ -157,15 +237,630 @@ func (c tinyConfig) Write() error {
 	return nil
 }
 
+// default headers are stored under "default_headers:<name>" keys
+func (c tinyConfig) DefaultHeaders() (map[string]string, error) {
+	headers := map[string]string{}
+	for key, value := range c {
+		if name, found := strings.CutPrefix(key, "default_headers:"); found {
+			headers[name] = value
+		}
+	}
+	return headers, nil
+}
+
 var requestAtRE = regexp.MustCompile(`(?m)^\* Request at .+`)
 var dateRE = regexp.MustCompile(`(?m)^< Date: .+`)
 var hostWithPortRE = regexp.MustCompile(`127\.0\.0\.1:\d+`)
 var durationRE = regexp.MustCompile(`(?m)^\* Request took .+`)
+var requestIDRE = regexp.MustCompile(`(?m)^> X-Request-Id: .+`)
 
 func normalizeVerboseLog(t string) string {
 	t = requestAtRE.ReplaceAllString(t, "* Request at <time>")
 	t = hostWithPortRE.ReplaceAllString(t, "<host>:<port>")
 	t = dateRE.ReplaceAllString(t, "< Date: <time>")
 	t = durationRE.ReplaceAllString(t, "* Request took <duration>")
+	t = requestIDRE.ReplaceAllString(t, "> X-Request-Id: <request-id>")
 	return t
 }
+
+func TestNewHTTPClient_middleware(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var order []string
+	mark := func(name string) api.ClientOption {
+		return func(tr http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, fmt.Sprintf("%s auth=%v", name, req.Header.Get("Authorization") != ""))
+				return tr.RoundTrip(req)
+			})
+		}
+	}
+
+	cfg := tinyConfig{"instill.tech:access_token": "MYTOKEN"}
+	io, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(io, cfg, "v1.2.3", false, true, mark("first"), mark("second"))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	require.NoError(t, err)
+	req.Host = "instill.tech"
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, 204, res.StatusCode)
+
+	// middleware runs in registration order and sees the Authorization header,
+	// since auth is applied last in the chain
+	assert.Equal(t, []string{"first auth=true", "second auth=true"}, order)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewHTTPClient_noAuth(t *testing.T) {
+	var gotAuthHeader string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	cfg := tinyConfig{"instill.tech:access_token": "MYTOKEN"}
+	io, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(io, cfg, "v1.2.3", false, false)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	require.NoError(t, err)
+	req.Host = "instill.tech"
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, 204, res.StatusCode)
+
+	// even with a token configured for the host, no Authorization header is
+	// sent when the Authorization middleware is disabled
+	assert.Equal(t, "", gotAuthHeader)
+}
+
+func TestNewHTTPClient_defaultHeaders(t *testing.T) {
+	var gotReq *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotReq = r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	cfg := tinyConfig{
+		"default_headers:X-Team": "platform",
+		"default_headers:X-Env":  "staging",
+	}
+	io, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(io, cfg, "v1.2.3", false, false)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	require.NoError(t, err)
+	// an explicitly set header wins over the configured default
+	req.Header.Set("X-Env", "production")
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, 204, res.StatusCode)
+
+	assert.Equal(t, "platform", gotReq.Header.Get("X-Team"))
+	assert.Equal(t, "production", gotReq.Header.Get("X-Env"))
+}
+
+func TestNewHTTPClient_resolveOverride(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Host", r.Host)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	_, port, err := net.SplitHostPort(ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	io, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(io, tinyConfig{}, "v1.2.3", false, false)
+	require.NoError(t, err)
+
+	// the override redirects the dial to the local server while the request
+	// itself, including its Host header, stays on the original hostname
+	ctx := api.WithResolveOverrides(context.Background(), map[string]string{
+		"resolve-me.invalid:" + port: "127.0.0.1:" + port,
+	})
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://resolve-me.invalid:"+port+"/", nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, 204, res.StatusCode)
+	assert.Equal(t, "resolve-me.invalid:"+port, res.Header.Get("X-Request-Host"))
+
+	// without the override the placeholder hostname does not resolve; a fresh
+	// client avoids reusing the pooled connection from the request above
+	client, err = NewHTTPClient(io, tinyConfig{}, "v1.2.3", false, false)
+	require.NoError(t, err)
+	req, err = http.NewRequest("GET", "http://resolve-me.invalid:"+port+"/", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClient_unixSocketPerHost(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "inst.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	socketServer := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Served-By", "socket")
+			w.WriteHeader(http.StatusNoContent)
+		})},
+	}
+	socketServer.Start()
+	defer socketServer.Close()
+
+	tcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Served-By", "tcp")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer tcpServer.Close()
+
+	cfg := tinyConfig{"socket.local:http_unix_socket": socketPath}
+	io, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(io, cfg, "v1.2.3", false, true)
+	require.NoError(t, err)
+
+	// the socket-backed host is dialed through the unix socket
+	req, err := http.NewRequest("GET", "http://socket.local/", nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "socket", res.Header.Get("X-Served-By"))
+
+	// a host without a socket configured uses the normal transport
+	req, err = http.NewRequest("GET", tcpServer.URL, nil)
+	require.NoError(t, err)
+	req.Host = "tcp.local"
+	res, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", res.Header.Get("X-Served-By"))
+}
+
+func TestVerboseLogOption(t *testing.T) {
+	tests := []struct {
+		name        string
+		instillDbg  string
+		debug       string
+		force       bool
+		wantEnabled bool
+	}{
+		{
+			name:        "no environment, no force",
+			wantEnabled: false,
+		},
+		{
+			name:        "no environment, forced",
+			force:       true,
+			wantEnabled: true,
+		},
+		{
+			name:        "INSTILL_DEBUG=api",
+			instillDbg:  "api",
+			wantEnabled: true,
+		},
+		{
+			name:        "INSTILL_DEBUG=api:body",
+			instillDbg:  "api:body",
+			wantEnabled: true,
+		},
+		{
+			name:        "DEBUG fallback",
+			debug:       "api",
+			wantEnabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INSTILL_DEBUG", tt.instillDbg)
+			t.Setenv("DEBUG", tt.debug)
+
+			io, _, _, _ := iostreams.Test()
+			opt, enabled := VerboseLogOption(io, tt.force)
+			assert.Equal(t, tt.wantEnabled, enabled)
+			if enabled {
+				assert.NotNil(t, opt)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_gzipDecompression(t *testing.T) {
+	gzipHandler := func(label string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				t.Errorf("request did not advertise gzip: Accept-Encoding: %q", r.Header.Get("Accept-Encoding"))
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Set("Content-Type", "application/json")
+			gz := gzip.NewWriter(w)
+			fmt.Fprintf(gz, `{"served_by":%q}`, label)
+			gz.Close()
+		}
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "inst.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	socketServer := &httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: gzipHandler("socket")},
+	}
+	socketServer.Start()
+	defer socketServer.Close()
+
+	tcpServer := httptest.NewServer(gzipHandler("tcp"))
+	defer tcpServer.Close()
+
+	cfg := tinyConfig{"socket.local:http_unix_socket": socketPath}
+	streams, _, _, _ := iostreams.Test()
+	client, err := NewHTTPClient(streams, cfg, "v1.2.3", false, true)
+	require.NoError(t, err)
+
+	// both the normal and the unix-socket transport must decompress
+	// transparently
+	for _, tt := range []struct {
+		url  string
+		want string
+	}{
+		{url: "http://socket.local/", want: `{"served_by":"socket"}`},
+		{url: tcpServer.URL, want: `{"served_by":"tcp"}`},
+	} {
+		req, err := http.NewRequest("GET", tt.url, nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, string(body))
+		assert.Empty(t, res.Header.Get("Content-Encoding"))
+	}
+}
+
+func Test_tunedTransport(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             tinyConfig
+		wantNil         bool
+		wantMaxIdle     int
+		wantIdleTimeout time.Duration
+		wantStderr      string
+	}{
+		{
+			name:    "no tuning keys set",
+			cfg:     tinyConfig{},
+			wantNil: true,
+		},
+		{
+			name: "custom values",
+			cfg: tinyConfig{
+				":http_max_idle_conns": "500",
+				":http_idle_timeout":   "2m",
+			},
+			wantMaxIdle:     500,
+			wantIdleTimeout: 2 * time.Minute,
+		},
+		{
+			name: "invalid values fall back to the defaults",
+			cfg: tinyConfig{
+				":http_max_idle_conns": "lots",
+				":http_idle_timeout":   "-5s",
+			},
+			wantMaxIdle:     100,
+			wantIdleTimeout: 90 * time.Second,
+			wantStderr: heredoc.Doc(`
+				! invalid value "lots" for http_max_idle_conns, using the default
+				! invalid value "-5s" for http_idle_timeout, using the default
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, _, stderr := iostreams.Test()
+
+			tr := tunedTransport(streams, tt.cfg)
+			if tt.wantNil {
+				assert.Nil(t, tr)
+				return
+			}
+
+			require.NotNil(t, tr)
+			assert.Equal(t, tt.wantMaxIdle, tr.MaxIdleConns)
+			assert.Equal(t, tt.wantIdleTimeout, tr.IdleConnTimeout)
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
+
+func Test_clientCertificate(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	t.Run("no keys set", func(t *testing.T) {
+		cert, err := clientCertificate(tinyConfig{})
+		assert.NoError(t, err)
+		assert.Nil(t, cert)
+	})
+
+	t.Run("only the certificate set", func(t *testing.T) {
+		cfg := tinyConfig{":tls_client_cert": certFile}
+		_, err := clientCertificate(cfg)
+		assert.EqualError(t, err, "mutual TLS requires both tls_client_cert and tls_client_key to be set")
+	})
+
+	t.Run("only the key set", func(t *testing.T) {
+		cfg := tinyConfig{":tls_client_key": keyFile}
+		_, err := clientCertificate(cfg)
+		assert.EqualError(t, err, "mutual TLS requires both tls_client_cert and tls_client_key to be set")
+	})
+
+	t.Run("valid pair", func(t *testing.T) {
+		cfg := tinyConfig{
+			":tls_client_cert": certFile,
+			":tls_client_key":  keyFile,
+		}
+		cert, err := clientCertificate(cfg)
+		require.NoError(t, err)
+		require.NotNil(t, cert)
+		assert.NotEmpty(t, cert.Certificate)
+	})
+
+	t.Run("invalid pair", func(t *testing.T) {
+		cfg := tinyConfig{
+			":tls_client_cert": certFile,
+			":tls_client_key":  certFile,
+		}
+		_, err := clientCertificate(cfg)
+		assert.ErrorContains(t, err, "failed to load TLS client certificate")
+	})
+}
+
+func TestNewHTTPClient_clientCertificate(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+	io, _, _, _ := iostreams.Test()
+
+	cfg := tinyConfig{
+		":tls_client_cert": certFile,
+		":tls_client_key":  keyFile,
+	}
+	_, err := NewHTTPClient(io, cfg, "v1.2.3", false, true)
+	assert.NoError(t, err)
+
+	// a broken pair must fail at construction, not on the first request
+	cfg = tinyConfig{":tls_client_cert": certFile}
+	_, err = NewHTTPClient(io, cfg, "v1.2.3", false, true)
+	assert.EqualError(t, err, "mutual TLS requires both tls_client_cert and tls_client_key to be set")
+}
+
+// writeTestCertificate generates a self-signed certificate and private key as
+// PEM files for exercising the mutual-TLS configuration.
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "inst test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return certFile, keyFile
+}
+
+func Test_retryPolicy(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		policy := retryPolicy(io, tinyConfig{})
+		assert.Equal(t, api.DefaultRetryPolicy(), policy)
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		policy := retryPolicy(io, tinyConfig{
+			":http_retry_base_ms": "500",
+			":http_retry_max_ms":  "10000",
+			":http_retry_jitter":  "false",
+		})
+		assert.Equal(t, 500*time.Millisecond, policy.Base)
+		assert.Equal(t, 10*time.Second, policy.Cap)
+		assert.False(t, policy.Jitter)
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("invalid values warn and fall back", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		policy := retryPolicy(io, tinyConfig{
+			":http_retry_base_ms": "soon",
+			":http_retry_max_ms":  "-1",
+		})
+		assert.Equal(t, api.DefaultRetryPolicy(), policy)
+		assert.Contains(t, stderr.String(), `invalid value "soon" for http_retry_base_ms`)
+		assert.Contains(t, stderr.String(), `invalid value "-1" for http_retry_max_ms`)
+	})
+}
+
+func Test_authWait(t *testing.T) {
+	t.Run("unset disables waiting", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		assert.Equal(t, time.Duration(0), authWait(io, tinyConfig{}))
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("zero disables waiting", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		assert.Equal(t, time.Duration(0), authWait(io, tinyConfig{":auth_wait": "0"}))
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("configured duration", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		assert.Equal(t, 30*time.Second, authWait(io, tinyConfig{":auth_wait": "30s"}))
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("invalid value warns and disables waiting", func(t *testing.T) {
+		io, _, _, stderr := iostreams.Test()
+		assert.Equal(t, time.Duration(0), authWait(io, tinyConfig{":auth_wait": "soon"}))
+		assert.Equal(t, "! invalid value \"soon\" for auth_wait, ignoring it\n", stderr.String())
+	})
+}
+
+func TestNewHTTPClient_authWait(t *testing.T) {
+	origInterval := authWaitInterval
+	authWaitInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		authWaitInterval = origInterval
+	})
+
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the token only becomes valid on the third attempt, as if it were
+		// provisioned after the CLI started
+		if requests >= 3 && r.Header.Get("Authorization") == "bearer MYTOKEN" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	t.Run("retries until the token is accepted", func(t *testing.T) {
+		requests = 0
+		cfg := tinyConfig{
+			"instill.tech:access_token": "MYTOKEN",
+			":auth_wait":                "2s",
+		}
+		streams, _, _, stderr := iostreams.Test()
+		client, err := NewHTTPClient(streams, cfg, "v1.2.3", false, true)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		require.NoError(t, err)
+		req.Host = "instill.tech"
+		res, err := client.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, 204, res.StatusCode)
+		assert.Equal(t, 3, requests)
+		assert.Contains(t, stderr.String(), "got HTTP 401 from instill.tech, retrying for up to 2s")
+	})
+
+	t.Run("disabled without auth_wait", func(t *testing.T) {
+		requests = 0
+		cfg := tinyConfig{"instill.tech:access_token": "MYTOKEN"}
+		streams, _, _, stderr := iostreams.Test()
+		client, err := NewHTTPClient(streams, cfg, "v1.2.3", false, true)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		require.NoError(t, err)
+		req.Host = "instill.tech"
+		res, err := client.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, 401, res.StatusCode)
+		assert.Equal(t, 1, requests)
+		assert.Equal(t, "", stderr.String())
+	})
+
+	t.Run("gives up at the deadline", func(t *testing.T) {
+		requests = 0
+		cfg := tinyConfig{
+			"instill.tech:access_token": "WRONG",
+			":auth_wait":                "30ms",
+		}
+		streams, _, _, stderr := iostreams.Test()
+		client, err := NewHTTPClient(streams, cfg, "v1.2.3", false, true)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		require.NoError(t, err)
+		req.Host = "instill.tech"
+		res, err := client.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, 401, res.StatusCode)
+		assert.GreaterOrEqual(t, requests, 2)
+		assert.Contains(t, stderr.String(), "still getting HTTP 401 from instill.tech after waiting 30ms, giving up")
+	})
+}
+
+func Test_tunedTransport_forceHTTP1(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Proto)
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	certpool := x509.NewCertPool()
+	certpool.AddCert(ts.Certificate())
+
+	tests := []struct {
+		name      string
+		config    tinyConfig
+		wantProto string
+	}{
+		{
+			name:      "http/2 negotiated by default",
+			config:    tinyConfig{":http_max_idle_conns": "10"},
+			wantProto: "HTTP/2.0",
+		},
+		{
+			name:      "http_force_http1 disables h2",
+			config:    tinyConfig{":http_force_http1": "true"},
+			wantProto: "HTTP/1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, _, _ := iostreams.Test()
+			tr := tunedTransport(ios, tt.config)
+			require.NotNil(t, tr)
+			tr.TLSClientConfig = &tls.Config{RootCAs: certpool}
+
+			client := &http.Client{Transport: tr}
+			res, err := client.Get(ts.URL)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProto, string(body))
+			assert.Equal(t, tt.wantProto, res.Proto)
+		})
+	}
+}
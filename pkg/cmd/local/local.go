@@ -55,6 +55,11 @@ func New(f *cmdutil.Factory) *cobra.Command {
 		Short: "Local Instill Core instance",
 		Long:  `Create and manage a local Instill Core instance with ease.`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// this hook shadows the root one, so honor `--no-prompt` here too
+			if noPrompt, _ := cmd.Flags().GetBool("no-prompt"); noPrompt {
+				f.IOStreams.SetNeverPrompt(true)
+			}
+
 			d, err := os.UserHomeDir()
 			if err != nil {
 				return err
@@ -0,0 +1,20 @@
+package pipeline
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/pkg/cmdutil"
+)
+
+// New creates the `pipeline` command group
+func New(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pipeline <command>",
+		Short: "Work with pipelines",
+		Long:  `Interact with the pipelines of an Instill Core/Cloud instance.`,
+	}
+
+	cmd.AddCommand(cmdutil.NewListCommand(f, "pipelines", "vdp/v1alpha/pipelines", "pipelines", nil))
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+package root
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+// requestCompletions drives cobra's hidden __complete command against the full
+// command tree and returns the offered candidates, with any "\tdescription"
+// annotations stripped.
+func requestCompletions(t *testing.T, cfg config.Config, args ...string) []string {
+	t.Helper()
+
+	io, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return cfg, nil
+		},
+	}
+
+	cmd := NewCmdRoot(f, "1.0.0", "2020-01-01")
+	cmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	_, err := cmd.ExecuteC()
+	require.NoError(t, err)
+
+	var candidates []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		// the final line reports the completion directive, e.g. ":4"
+		if line == "" || strings.HasPrefix(line, ":") {
+			continue
+		}
+		candidates = append(candidates, strings.SplitN(line, "\t", 2)[0])
+	}
+	return candidates
+}
+
+func TestCompletion_configSetValues(t *testing.T) {
+	got := requestCompletions(t, config.ConfigStub{}, "config", "set", "protocol", "")
+	assert.Equal(t, []string{"https", "ssh"}, got)
+}
+
+func TestCompletion_configGetKeys(t *testing.T) {
+	got := requestCompletions(t, config.ConfigStub{}, "config", "get", "")
+
+	var want []string
+	for _, co := range config.ConfigOptions() {
+		want = append(want, co.Key)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestCompletion_apiShorthands(t *testing.T) {
+	got := requestCompletions(t, config.ConfigStub{}, "api", "")
+	assert.Equal(t, []string{"me", "models", "pipelines"}, got)
+}
+
+func TestCompletion_apiMethods(t *testing.T) {
+	got := requestCompletions(t, config.ConfigStub{}, "api", "pipelines", "--method", "")
+	assert.Equal(t, []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}, got)
+}
+
+func TestCompletion_apiOutputFormats(t *testing.T) {
+	got := requestCompletions(t, config.ConfigStub{}, "api", "pipelines", "--output", "")
+	assert.Equal(t, []string{"json", "table", "yaml", "ndjson"}, got)
+}
+
+func TestCompletion_hostnames(t *testing.T) {
+	cfg := config.MustNewFromString(heredoc.Doc(`
+		hosts:
+		  api.instill.tech:
+		    access_token: TOKEN1
+		  my.instance:
+		    access_token: TOKEN2
+	`))
+
+	got := requestCompletions(t, cfg, "config", "get", "--host", "")
+	assert.Equal(t, []string{"api.instill.tech", "my.instance"}, got)
+}
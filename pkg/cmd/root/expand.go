@@ -0,0 +1,74 @@
+package root
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/google/shlex"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+var placeholderRE = regexp.MustCompile(`\$(\d+|@)`)
+
+// ExpandAlias rewrites the arguments of an invocation according to the user's
+// aliases. The first argument is matched against alias names; positional
+// placeholders "$1", "$2", ... and "$@" in the expansion are substituted with
+// the remaining arguments. When no placeholders are present, remaining
+// arguments are appended to the expansion. The second return value reports
+// whether an alias matched.
+func ExpandAlias(cfg config.Config, args []string) ([]string, bool, error) {
+	if len(args) < 1 {
+		return nil, false, nil
+	}
+
+	aliases, err := cfg.Aliases()
+	if err != nil {
+		return nil, false, err
+	}
+
+	expansion, found := aliases[args[0]]
+	if !found {
+		return nil, false, nil
+	}
+
+	extraArgs := args[1:]
+	expanded, err := shlex.Split(expansion)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse alias expansion %q: %w", expansion, err)
+	}
+
+	newArgs := []string{}
+	substituted := false
+	for _, a := range expanded {
+		if a == "$@" {
+			newArgs = append(newArgs, extraArgs...)
+			substituted = true
+			continue
+		}
+		var substErr error
+		a = placeholderRE.ReplaceAllStringFunc(a, func(m string) string {
+			if m == "$@" {
+				return m
+			}
+			n, _ := strconv.Atoi(m[1:])
+			if n < 1 || n > len(extraArgs) {
+				substErr = fmt.Errorf("not enough arguments for alias: %s", expansion)
+				return m
+			}
+			substituted = true
+			return extraArgs[n-1]
+		})
+		if substErr != nil {
+			return nil, false, substErr
+		}
+		newArgs = append(newArgs, a)
+	}
+
+	if !substituted {
+		newArgs = append(newArgs, extraArgs...)
+	}
+
+	return newArgs, true, nil
+}
@@ -0,0 +1,86 @@
+package root
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func TestExpandAlias(t *testing.T) {
+	cfg := config.ConfigStub{
+		"alias:pl":      "api vdp/v1alpha/pipelines",
+		"alias:trigger": "api vdp/v1alpha/pipelines/$1/trigger",
+		"alias:passall": "api $@",
+		"alias:bad":     "api 'unterminated",
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		expanded bool
+		wantErr  string
+	}{
+		{
+			name:     "no expansion",
+			args:     []string{"auth", "login"},
+			expanded: false,
+		},
+		{
+			name:     "simple expansion",
+			args:     []string{"pl"},
+			want:     []string{"api", "vdp/v1alpha/pipelines"},
+			expanded: true,
+		},
+		{
+			name:     "extra args are appended",
+			args:     []string{"pl", "--jq", ".pipelines"},
+			want:     []string{"api", "vdp/v1alpha/pipelines", "--jq", ".pipelines"},
+			expanded: true,
+		},
+		{
+			name:     "positional placeholder",
+			args:     []string{"trigger", "hello"},
+			want:     []string{"api", "vdp/v1alpha/pipelines/hello/trigger"},
+			expanded: true,
+		},
+		{
+			name:    "missing positional argument",
+			args:    []string{"trigger"},
+			wantErr: "not enough arguments for alias: api vdp/v1alpha/pipelines/$1/trigger",
+		},
+		{
+			name:     "dollar at placeholder",
+			args:     []string{"passall", "models", "-i"},
+			want:     []string{"api", "models", "-i"},
+			expanded: true,
+		},
+		{
+			name:    "unparseable expansion",
+			args:    []string{"bad"},
+			wantErr: `could not parse alias expansion "api 'unterminated": EOF found when expecting closing quote`,
+		},
+		{
+			name:     "empty args",
+			args:     []string{},
+			expanded: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, expanded, err := ExpandAlias(cfg, tt.args)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expanded, expanded)
+			if tt.expanded {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/instill-ai/cli/internal/build"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 	"github.com/instill-ai/cli/pkg/text"
 )
@@ -127,7 +128,7 @@ func rootHelpFunc(f *cmdutil.Factory, command *cobra.Command, args []string) {
 	}
 	if longText != "" && command.LocalFlags().Lookup("jq") != nil {
 		longText = strings.TrimRight(longText, "\n") +
-			"\n\nFor more information about output formatting flags, see `inst help formatting`."
+			fmt.Sprintf("\n\nFor more information about output formatting flags, see `%s help formatting`.", build.ExecutableName)
 	}
 
 	helpEntries := []helpEntry{}
@@ -162,9 +163,9 @@ func rootHelpFunc(f *cmdutil.Factory, command *cobra.Command, args []string) {
 	if _, ok := command.Annotations["help:environment"]; ok {
 		helpEntries = append(helpEntries, helpEntry{"ENVIRONMENT VARIABLES", command.Annotations["help:environment"]})
 	}
-	helpEntries = append(helpEntries, helpEntry{"LEARN MORE", `
-Use 'inst <command> <subcommand> --help' for more information about a command.
-Read the manual at https://www.instill.tech/docs`})
+	helpEntries = append(helpEntries, helpEntry{"LEARN MORE", fmt.Sprintf(`
+Use '%s <command> <subcommand> --help' for more information about a command.
+Read the manual at https://www.instill.tech/docs`, build.ExecutableName)})
 	if _, ok := command.Annotations["help:feedback"]; ok {
 		helpEntries = append(helpEntries, helpEntry{"FEEDBACK", command.Annotations["help:feedback"]})
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/instill-ai/cli/internal/build"
 	"github.com/instill-ai/cli/pkg/iostreams"
 	"github.com/instill-ai/cli/pkg/markdown"
 )
@@ -39,7 +40,7 @@ func referenceHelpFn(io *iostreams.IOStreams) func(*cobra.Command, []string) {
 }
 
 func referenceLong(cmd *cobra.Command) string {
-	buf := bytes.NewBufferString("# inst reference\n\n")
+	buf := bytes.NewBufferString(fmt.Sprintf("# %s reference\n\n", build.ExecutableName))
 	for _, c := range cmd.Commands() {
 		if c.Hidden {
 			continue
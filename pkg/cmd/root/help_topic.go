@@ -1,21 +1,26 @@
 package root
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 
+	"github.com/instill-ai/cli/internal/build"
+	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/text"
 )
 
 var HelpTopics = map[string]map[string]string{
 	"mintty": {
-		"short": "Information about using inst with MinTTY",
-		"long": heredoc.Doc(`
+		"short": fmt.Sprintf("Information about using %s with MinTTY", build.ExecutableName),
+		"long": heredoc.Docf(`
 			MinTTY is the terminal emulator that comes by default with Git
-			for Windows.  It has known issues with inst's ability to prompt a
+			for Windows.  It has known issues with %[1]s's ability to prompt a
 			user for input.
 
-			There are a few workarounds to make inst work with MinTTY:
+			There are a few workarounds to make %[1]s work with MinTTY:
 
 			- Reinstall Git for Windows, checking "Enable experimental support for pseudo consoles".
 
@@ -23,54 +28,22 @@ var HelpTopics = map[string]map[string]string{
 			  You can run "C:\Program Files\Git\bin\bash.exe" from any terminal emulator to continue
 			  using all of the tooling in Git For Windows without MinTTY.
 
-			- Prefix invocations of inst with winpty, eg: "winpty inst auth login".
+			- Prefix invocations of %[1]s with winpty, eg: "winpty %[1]s auth login".
 			  NOTE: this can lead to some UI bugs.
-		`),
+		`, build.ExecutableName),
 	},
 	"environment": {
-		"short": "Environment variables that can be used with inst",
-		"long": heredoc.Doc(`
-			INSTILL_EDITOR, GIT_EDITOR, VISUAL, EDITOR (in order of precedence): the editor tool to use
-			for authoring text.
-
-			INSTILL_BROWSER, BROWSER (in order of precedence): the web browser to use for opening links.
-
-			DEBUG: set to any value to enable verbose output to standard error. Include values "api"
-			or "oauth" to print detailed information about HTTP requests or authentication flow.
-
-			INSTILL_PAGER, PAGER (in order of precedence): a terminal paging program to send standard output
-			to, e.g. "less".
-
-			GLAMOUR_STYLE: the style to use for rendering Markdown. See
-			<https://github.com/charmbracelet/glamour#styles>
-
-			NO_COLOR: set to any value to avoid printing ANSI escape sequences for color output.
-
-			CLICOLOR: set to "0" to disable printing ANSI colors in output.
-
-			CLICOLOR_FORCE: set to a value other than "0" to keep ANSI colors in output
-			even when the output is piped.
-
-			INSTILL_FORCE_TTY: set to any value to force terminal-style output even when the output is
-			redirected. When the value is a number, it is interpreted as the number of columns
-			available in the viewport. When the value is a percentage, it will be applied against
-			the number of columns available in the current viewport.
-
-			INSTILL_NO_UPDATE_NOTIFIER: set to any value to disable update notifications. By default, inst
-			checks for new releases once every 24 hours and displays an upgrade notice on standard
-			error if a newer version was found.
-
-			INSTILL_CONFIG_DIR: the directory where inst will store configuration files. Default:
-			"$XDG_CONFIG_HOME/instill" or "$HOME/.config/instill".
-		`),
+		"short": fmt.Sprintf("Environment variables that can be used with %s", build.ExecutableName),
+		// the "long" text is generated at runtime from ConfigOptions; see
+		// environmentLong below
 	},
 	"reference": {
-		"short": "A comprehensive reference of all inst commands",
+		"short": fmt.Sprintf("A comprehensive reference of all %s commands", build.ExecutableName),
 	},
 	"formatting": {
-		"short": "Formatting options for JSON data exported from inst",
+		"short": fmt.Sprintf("Formatting options for JSON data exported from %s", build.ExecutableName),
 		"long": heredoc.Docf(`
-			Some inst commands support exporting the data as JSON as an alternative to their usual
+			Some %[2]s commands support exporting the data as JSON as an alternative to their usual
 			line-based plain text output. This is suitable for passing structured data to scripts.
 			The JSON output is enabled with the %[1]s--json%[1]s option, followed by the list of fields
 			to fetch. Use the flag without a value to get the list of available fields.
@@ -93,25 +66,86 @@ var HelpTopics = map[string]map[string]string{
 			- %[1]stimeago <time>%[1]s: renders a timestamp as relative to now
 			- %[1]stimefmt <format> <time>%[1]s: formats a timestamp using Go's Time.Format function
 			- %[1]struncate <length> <input>%[1]s: ensures input fits within length
-		`, "`"),
-		"example": heredoc.Doc(`
+		`, "`", build.ExecutableName),
+		"example": heredoc.Docf(`
 			# format pipelines as a table
-			$ inst api vdp/v1alpha/pipelines \
-			  --template '{{range .pipelines}}{{tablerow (printf "%v" .name | autocolor "green")}}{{end}}'
-		`),
+			$ %[1]s api vdp/v1alpha/pipelines \
+			  --template '{{range .pipelines}}{{tablerow (printf "%%v" .name | autocolor "green")}}{{end}}'
+		`, build.ExecutableName),
 	},
 }
 
+// environmentLong renders the environment help topic from ConfigOptions so
+// the documented variables cannot drift from the supported settings.
+func environmentLong() string {
+	sb := strings.Builder{}
+
+	for _, co := range config.ConfigOptions() {
+		names := config.EnvOverrides(co.Key)
+		sb.WriteString(strings.Join(names, ", "))
+		if len(names) > 1 {
+			sb.WriteString(" (in order of precedence)")
+		}
+		sb.WriteString(": ")
+		sb.WriteString(co.Description)
+		if co.DefaultValue != "" {
+			sb.WriteString(fmt.Sprintf(" (default: %q)", co.DefaultValue))
+		}
+		sb.WriteString(".\n\n")
+	}
+
+	sb.WriteString(heredoc.Docf(`
+		INSTILL_<HOST>_TOKEN, INSTILL_TOKEN (in order of precedence): an access token to use
+		instead of the stored one, e.g. for CI. The per-host form uppercases the hostname and
+		turns dots into underscores (INSTILL_API_INSTILL_TECH_TOKEN); the plain form applies
+		to the default host only.
+
+		INSTILL_DEBUG, DEBUG (in order of precedence): set to any value to enable verbose output to
+		standard error. Include value "api" to print detailed information about HTTP requests, and
+		"api:body" to include request and response bodies; "oauth" prints details about the
+		authentication flow.
+
+		GLAMOUR_STYLE: the style to use for rendering Markdown. See
+		<https://github.com/charmbracelet/glamour#styles>
+
+		NO_COLOR: set to any value to avoid printing ANSI escape sequences for color output.
+
+		CLICOLOR: set to "0" to disable printing ANSI colors in output.
+
+		CLICOLOR_FORCE: set to a value other than "0" to keep ANSI colors in output
+		even when the output is piped.
+
+		INSTILL_FORCE_TTY: set to any value to force terminal-style output even when the output is
+		redirected. When the value is a number, it is interpreted as the number of columns
+		available in the viewport. When the value is a percentage, it will be applied against
+		the number of columns available in the current viewport.
+
+		INSTILL_NO_UPDATE_NOTIFIER: set to any value to disable update notifications. By default, %[1]s
+		checks for new releases once every 24 hours and displays an upgrade notice on standard
+		error if a newer version was found.
+
+		INSTILL_CONFIG_DIR: the directory where %[1]s will store configuration files. Default:
+		"$XDG_CONFIG_HOME/instill" or "$HOME/.config/instill".
+	`, build.ExecutableName))
+
+	return sb.String()
+}
+
 func NewHelpTopic(topic string) *cobra.Command {
+	long := HelpTopics[topic]["long"]
+	if topic == "environment" {
+		long = environmentLong()
+	}
+
 	cmd := &cobra.Command{
 		Use:     topic,
 		Short:   HelpTopics[topic]["short"],
-		Long:    HelpTopics[topic]["long"],
+		Long:    long,
 		Example: HelpTopics[topic]["example"],
 		Hidden:  true,
 		Annotations: map[string]string{
 			"markdown:generate": "true",
-			"markdown:basename": "inst_help_" + topic,
+			"markdown:basename": build.ExecutableName + "_help_" + topic,
 		},
 	}
 
@@ -130,6 +164,6 @@ func helpTopicHelpFunc(command *cobra.Command, args []string) {
 }
 
 func helpTopicUsageFunc(command *cobra.Command) error {
-	command.Printf("Usage: inst help %s", command.Use)
+	command.Printf("Usage: %s help %s", build.ExecutableName, command.Use)
 	return nil
 }
@@ -78,3 +78,17 @@ func TestNewHelpTopic(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvironmentLong_generated(t *testing.T) {
+	long := environmentLong()
+
+	// entries generated from ConfigOptions
+	assert.Contains(t, long, "INSTILL_EDITOR, GIT_EDITOR, VISUAL, EDITOR (in order of precedence)")
+	assert.Contains(t, long, "INSTILL_BROWSER, BROWSER (in order of precedence)")
+	assert.Contains(t, long, "INSTILL_PROTOCOL")
+	assert.Contains(t, long, `(default: "https")`)
+
+	// static entries without a config counterpart
+	assert.Contains(t, long, "INSTILL_CONFIG_DIR")
+	assert.Contains(t, long, "NO_COLOR")
+}
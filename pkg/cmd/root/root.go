@@ -1,44 +1,52 @@
 package root
 
 import (
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 
+	"github.com/instill-ai/cli/internal/build"
+	"github.com/instill-ai/cli/internal/config"
 	"github.com/instill-ai/cli/pkg/cmd/factory"
 	"github.com/instill-ai/cli/pkg/cmdutil"
 
+	aliasCmd "github.com/instill-ai/cli/pkg/cmd/alias"
 	apiCmd "github.com/instill-ai/cli/pkg/cmd/api"
 	authCmd "github.com/instill-ai/cli/pkg/cmd/auth"
 	completionCmd "github.com/instill-ai/cli/pkg/cmd/completion"
 	configCmd "github.com/instill-ai/cli/pkg/cmd/config"
 	instanceCmd "github.com/instill-ai/cli/pkg/cmd/instance"
 	localCmd "github.com/instill-ai/cli/pkg/cmd/local"
+	pipelineCmd "github.com/instill-ai/cli/pkg/cmd/pipeline"
 	versionCmd "github.com/instill-ai/cli/pkg/cmd/version"
 )
 
 // NewCmdRoot initiates the Cobra command root
 func NewCmdRoot(f *cmdutil.Factory, version, buildDate string) *cobra.Command {
+	exe := build.ExecutableName
 	cmd := &cobra.Command{
-		Use:   "inst <command> <subcommand> [flags]",
+		Use:   fmt.Sprintf("%s <command> <subcommand> [flags]", exe),
 		Short: "Instill CLI",
 		Long:  `Access Instill Core/Cloud from the command line.`,
 
 		SilenceErrors: true,
 		SilenceUsage:  true,
-		Example: heredoc.Doc(`
-			$ inst api pipelines
-			$ inst config get editor
-			$ inst auth login
-		`),
+		Example: heredoc.Docf(`
+			$ %[1]s api pipelines
+			$ %[1]s config get editor
+			$ %[1]s auth login
+		`, exe),
 		Annotations: map[string]string{
 			"help:feedback": heredoc.Doc(`
 				Please open an issue on https://github.com/instill-ai/community.
 			`),
-			"help:environment": heredoc.Doc(`
-				See 'inst help environment' for the list of supported environment variables.
-			`),
+			"help:environment": heredoc.Docf(`
+				See '%[1]s help environment' for the list of supported environment variables.
+			`, exe),
 		},
 	}
 
@@ -46,6 +54,65 @@ func NewCmdRoot(f *cmdutil.Factory, version, buildDate string) *cobra.Command {
 	cmd.SetErr(f.IOStreams.ErrOut)
 
 	cmd.PersistentFlags().Bool("help", false, "Show help for command")
+
+	// the value is consumed before dispatch (see cmd/inst/main.go); the flag is
+	// declared here so cobra accepts it and documents it
+	cmd.PersistentFlags().String("config-dir", "", "Directory to read configuration and credentials from")
+	cmd.PersistentFlags().String("config", "", "Read configuration from an exact `file`, overriding --config-dir")
+	cmd.PersistentFlags().String("profile", "", "Use the named `profile` from the config file, overriding INSTILL_PROFILE")
+
+	// equivalent to `prompt=disabled`, but scoped to a single invocation; the
+	// value is consumed before dispatch (see cmd/inst/main.go)
+	cmd.PersistentFlags().Bool("no-prompt", false, "Disable interactive prompts for this invocation")
+
+	// suppresses spinners, warnings, and other status output on stderr; errors
+	// are still printed. The value is consumed before dispatch (see
+	// cmd/inst/main.go)
+	cmd.PersistentFlags().Bool("quiet", false, "Suppress informational output; errors are still printed")
+
+	// emits warnings and other diagnostics as JSON lines for log collectors;
+	// the value is consumed before dispatch (see cmd/inst/main.go)
+	cmd.PersistentFlags().String("log-format", "", "Format for diagnostic output on stderr: {text|json}")
+
+	// explicit interactivity overrides beat terminal auto-detection, for
+	// environments where detection is wrong; unlike the `prompt` config they
+	// also affect color and progress rendering. The values are consumed before
+	// dispatch (see cmd/inst/main.go)
+	cmd.PersistentFlags().Bool("interactive", false, "Treat input and output as a terminal, overriding auto-detection")
+	cmd.PersistentFlags().Bool("no-interactive", false, "Treat input and output as non-terminal, disabling prompts, color, and spinners")
+
+	// allow a one-shot configuration piped in via stdin, e.g. in CI
+	var configStdin bool
+	cmd.PersistentFlags().BoolVar(&configStdin, "config-stdin", false, "Read configuration YAML from standard input for this invocation only")
+	fileConfig := f.Config
+	stdinConfig := configFromStdin(f)
+	f.Config = func() (config.Config, error) {
+		if configStdin {
+			return stdinConfig()
+		}
+		return fileConfig()
+	}
+	// one-off key overrides, layered above whichever config was loaded; they
+	// live only in memory and are never written back to disk
+	var configOverrides []string
+	cmd.PersistentFlags().StringArrayVar(&configOverrides, "config-key", nil, "Override a config `key=value` in memory for this invocation only")
+	baseConfig := f.Config
+	f.Config = func() (config.Config, error) {
+		cfg, err := baseConfig()
+		if err != nil || len(configOverrides) == 0 {
+			return cfg, err
+		}
+		overrides := map[string]string{}
+		for _, pair := range configOverrides {
+			key, value, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("expected the \"key=value\" format for `--config-key`, got %q", pair)
+			}
+			overrides[key] = config.NormalizeValue(key, value)
+		}
+		return config.NewWithOverrides(cfg, overrides)
+	}
+
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		rootHelpFunc(f, cmd, args)
 	})
@@ -55,14 +122,16 @@ func NewCmdRoot(f *cmdutil.Factory, version, buildDate string) *cobra.Command {
 	formattedVersion := versionCmd.Format(version, buildDate)
 	cmd.SetVersionTemplate(formattedVersion)
 	cmd.Version = formattedVersion
-	cmd.Flags().Bool("version", false, "Show inst version")
+	cmd.Flags().Bool("version", false, fmt.Sprintf("Show %s version", exe))
 
 	// Child commands
 	cmd.AddCommand(versionCmd.NewCmdVersion(f, version, buildDate))
 	cmd.AddCommand(authCmd.NewCmdAuth(f))
 	cmd.AddCommand(instanceCmd.New(f))
 	cmd.AddCommand(configCmd.NewCmdConfig(f))
+	cmd.AddCommand(aliasCmd.NewCmdAlias(f))
 	cmd.AddCommand(localCmd.New(f))
+	cmd.AddCommand(pipelineCmd.New(f))
 	cmd.AddCommand(completionCmd.NewCmdCompletion(f.IOStreams))
 
 	// the `api` command should not inherit any extra HTTP headers
@@ -86,12 +155,35 @@ func NewCmdRoot(f *cmdutil.Factory, version, buildDate string) *cobra.Command {
 	return cmd
 }
 
+// configFromStdin reads a read-only configuration from standard input once
+// and reuses it for the remainder of the invocation.
+func configFromStdin(f *cmdutil.Factory) func() (config.Config, error) {
+	var cached config.Config
+	var err error
+	return func() (config.Config, error) {
+		if cached != nil || err != nil {
+			return cached, err
+		}
+		var data []byte
+		data, err = io.ReadAll(f.IOStreams.In)
+		if err != nil {
+			return nil, err
+		}
+		cached, err = config.NewFromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing config from stdin: %w", err)
+		}
+		cached = config.NewReadOnly(cached)
+		return cached, nil
+	}
+}
+
 func bareHTTPClient(f *cmdutil.Factory, version string) func() (*http.Client, error) {
 	return func() (*http.Client, error) {
 		cfg, err := f.Config()
 		if err != nil {
 			return nil, err
 		}
-		return factory.NewHTTPClient(f.IOStreams, cfg, version, false)
+		return factory.NewHTTPClient(f.IOStreams, cfg, version, false, true)
 	}
 }
@@ -0,0 +1,85 @@
+package root
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/build"
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestNewCmdRoot_noPromptFlag(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{}, nil
+		},
+	}
+
+	cmd := NewCmdRoot(f, "1.0.0", "2020-01-01")
+
+	flag := cmd.PersistentFlags().Lookup("no-prompt")
+	if assert.NotNil(t, flag) {
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
+
+func TestNewCmdRoot_executableName(t *testing.T) {
+	orig := build.ExecutableName
+	build.ExecutableName = "mycli"
+	t.Cleanup(func() {
+		build.ExecutableName = orig
+	})
+
+	io, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{}, nil
+		},
+	}
+
+	cmd := NewCmdRoot(f, "1.0.0", "2020-01-01")
+
+	assert.Equal(t, "mycli <command> <subcommand> [flags]", cmd.Use)
+	assert.Equal(t, "mycli", cmd.Name())
+	assert.Contains(t, cmd.Example, "$ mycli auth login")
+	assert.Contains(t, cmd.Annotations["help:environment"], "'mycli help environment'")
+
+	flag := cmd.Flags().Lookup("version")
+	if assert.NotNil(t, flag) {
+		assert.Equal(t, "Show mycli version", flag.Usage)
+	}
+}
+
+func TestNewCmdRoot_configKeyOverrides(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{"protocol": "https", "editor": "ed"}, nil
+		},
+	}
+
+	cmd := NewCmdRoot(f, "1.0.0", "2020-01-01")
+	assert.NoError(t, cmd.PersistentFlags().Set("config-key", "protocol=ssh"))
+
+	cfg, err := f.Config()
+	assert.NoError(t, err)
+
+	value, err := cfg.Get("", "protocol")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh", value)
+
+	value, err = cfg.Get("", "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "ed", value)
+
+	assert.NoError(t, cmd.PersistentFlags().Set("config-key", "bogus=1"))
+	_, err = f.Config()
+	assert.EqualError(t, err, `invalid config key "bogus"`)
+}
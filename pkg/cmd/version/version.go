@@ -2,29 +2,160 @@ package version
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
 	"regexp"
+	"runtime/debug"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/instill-ai/cli/api"
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/internal/update"
 	"github.com/instill-ai/cli/pkg/cmdutil"
+	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
+// updateRepo is the GitHub repository queried for new releases.
+const updateRepo = "instill-ai/cli"
+
+type VersionOptions struct {
+	IO         *iostreams.IOStreams
+	HTTPClient func() (*http.Client, error)
+
+	Version   string
+	BuildDate string
+	Check     bool
+	Verbose   bool
+
+	// StateFilePath caches release lookups; when empty, a default under the
+	// state dir is used.
+	StateFilePath string
+}
+
 func NewCmdVersion(f *cmdutil.Factory, version, buildDate string) *cobra.Command {
+	opts := &VersionOptions{
+		IO:         f.IOStreams,
+		HTTPClient: f.HTTPClient,
+		Version:    version,
+		BuildDate:  buildDate,
+	}
+
 	cmd := &cobra.Command{
 		Use:    "version",
 		Hidden: true,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Fprint(f.IOStreams.Out, Format(version, buildDate))
-			// TODO check the VDP version
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return versionRun(opts)
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.Check, "check", false, "Check whether a newer release is available")
+	cmd.Flags().BoolVar(&opts.Verbose, "verbose", false, "Also show build and dependency details, useful in bug reports")
+
 	cmdutil.DisableAuthCheck(cmd)
 
 	return cmd
 }
 
+func versionRun(opts *VersionOptions) error {
+	fmt.Fprint(opts.IO.Out, Format(opts.Version, opts.BuildDate))
+	// TODO check the VDP version
+
+	if opts.Verbose {
+		printBuildInfo(opts.IO.Out)
+	}
+
+	if !opts.Check {
+		return nil
+	}
+
+	httpClient, err := opts.HTTPClient()
+	if err != nil {
+		return err
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	stateFilePath := opts.StateFilePath
+	if stateFilePath == "" {
+		stateFilePath = filepath.Join(config.StateDir(), "state.yml")
+	}
+
+	release, err := update.LatestRelease(client, stateFilePath, updateRepo)
+	if err != nil {
+		return fmt.Errorf("could not check for a new release: %w", err)
+	}
+
+	if update.IsUpdateAvailable(release.Version, opts.Version) {
+		fmt.Fprintf(opts.IO.Out, "\nA new release of Instill CLI is available: %s → %s\n%s\n",
+			strings.TrimPrefix(opts.Version, "v"),
+			strings.TrimPrefix(release.Version, "v"),
+			release.URL)
+	} else {
+		fmt.Fprintln(opts.IO.Out, "\nInstill CLI is up to date")
+	}
+
+	return nil
+}
+
+// swappable for tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// keyDependencies are the modules worth surfacing in bug reports.
+var keyDependencies = []string{
+	"github.com/spf13/cobra",
+	"github.com/AlecAivazis/survey/v2",
+	"gopkg.in/yaml.v3",
+}
+
+// printBuildInfo renders the `--verbose` details from the build metadata the
+// Go toolchain embeds in the binary. Binaries without it (e.g. `go run` from
+// outside a checkout) just say so instead of failing.
+func printBuildInfo(w io.Writer) {
+	info, ok := readBuildInfo()
+	if !ok {
+		fmt.Fprintln(w, "build information is not available in this binary")
+		return
+	}
+
+	fmt.Fprintf(w, "go version: %s\n", info.GoVersion)
+
+	var revision, modified, builtAt string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		case "vcs.time":
+			builtAt = setting.Value
+		}
+	}
+	if revision != "" {
+		if modified == "true" {
+			revision += " (dirty)"
+		}
+		fmt.Fprintf(w, "revision: %s\n", revision)
+	}
+	if builtAt != "" {
+		fmt.Fprintf(w, "built at: %s\n", builtAt)
+	}
+
+	for _, path := range keyDependencies {
+		for _, dep := range info.Deps {
+			if dep.Path != path {
+				continue
+			}
+			version := dep.Version
+			if dep.Replace != nil {
+				version = fmt.Sprintf("%s (replaced by %s)", dep.Replace.Version, dep.Replace.Path)
+			}
+			fmt.Fprintf(w, "%s: %s\n", dep.Path, version)
+		}
+	}
+}
+
 func Format(version, buildDate string) string {
 	version = strings.TrimPrefix(version, "v")
 
@@ -1,7 +1,15 @@
 package version
 
 import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
 	"testing"
+
+	"github.com/instill-ai/cli/pkg/httpmock"
+	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
 func TestFormat(t *testing.T) {
@@ -47,3 +55,129 @@ func TestChangelogURL(t *testing.T) {
 		t.Errorf("expected %s to create url %s but got %s", tag, url, result)
 	}
 }
+
+func Test_versionRun_verbose(t *testing.T) {
+	origReadBuildInfo := readBuildInfo
+	t.Cleanup(func() {
+		readBuildInfo = origReadBuildInfo
+	})
+
+	t.Run("with build info", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{
+				GoVersion: "go1.21.0",
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "abc1234"},
+					{Key: "vcs.modified", Value: "true"},
+					{Key: "vcs.time", Value: "2020-12-15T10:00:00Z"},
+				},
+				Deps: []*debug.Module{
+					{Path: "github.com/spf13/cobra", Version: "v1.2.1"},
+					{Path: "github.com/unrelated/module", Version: "v0.0.1"},
+				},
+			}, true
+		}
+
+		io, _, stdout, _ := iostreams.Test()
+		opts := &VersionOptions{
+			IO:        io,
+			Version:   "v1.4.0",
+			BuildDate: "2020-12-15",
+			Verbose:   true,
+		}
+
+		if err := versionRun(opts); err != nil {
+			t.Fatalf("versionRun() error: %v", err)
+		}
+
+		out := stdout.String()
+		for _, want := range []string{
+			"inst version 1.4.0 (2020-12-15)",
+			"go version: go1.21.0",
+			"revision: abc1234 (dirty)",
+			"built at: 2020-12-15T10:00:00Z",
+			"github.com/spf13/cobra: v1.2.1",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output %q does not contain %q", out, want)
+			}
+		}
+		if strings.Contains(out, "github.com/unrelated/module") {
+			t.Errorf("output %q lists a dependency that is not a key one", out)
+		}
+	})
+
+	t.Run("without build info", func(t *testing.T) {
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return nil, false
+		}
+
+		io, _, stdout, _ := iostreams.Test()
+		opts := &VersionOptions{
+			IO:      io,
+			Version: "v1.4.0",
+			Verbose: true,
+		}
+
+		if err := versionRun(opts); err != nil {
+			t.Fatalf("versionRun() error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "build information is not available in this binary") {
+			t.Errorf("output %q does not report missing build info", stdout.String())
+		}
+	})
+}
+
+func Test_versionRun_check(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		latestVersion string
+		wantOut       string
+	}{
+		{
+			name:          "update available",
+			version:       "v1.4.0",
+			latestVersion: "v1.5.0",
+			wantOut:       "A new release of Instill CLI is available: 1.4.0 → 1.5.0\nhttps://github.com/instill-ai/cli/releases/tag/v1.5.0\n",
+		},
+		{
+			name:          "up to date",
+			version:       "v1.4.0",
+			latestVersion: "v1.4.0",
+			wantOut:       "Instill CLI is up to date\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			reg.Register(
+				httpmock.REST("GET", "repos/instill-ai/cli/releases/latest"),
+				httpmock.StringResponse(fmt.Sprintf(`{
+					"tag_name": "%s",
+					"html_url": "https://github.com/instill-ai/cli/releases/tag/%s"
+				}`, tt.latestVersion, tt.latestVersion)),
+			)
+
+			io, _, stdout, _ := iostreams.Test()
+			opts := &VersionOptions{
+				IO: io,
+				HTTPClient: func() (*http.Client, error) {
+					return &http.Client{Transport: reg}, nil
+				},
+				Version:       tt.version,
+				BuildDate:     "2020-12-15",
+				Check:         true,
+				StateFilePath: filepath.Join(t.TempDir(), "state.yml"),
+			}
+
+			if err := versionRun(opts); err != nil {
+				t.Fatalf("versionRun() error: %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("output %q does not contain %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
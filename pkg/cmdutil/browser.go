@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/cli/browser"
+	"github.com/cli/safeexec"
+	shellquote "github.com/kballard/go-shellquote"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// swappable for tests
+var browserOpenURL = browser.OpenURL
+var browserFallbackOut io.Writer = os.Stderr
+
+// OpenInBrowser opens url with the launcher resolved from the INSTILL_BROWSER
+// environment variable, the `browser` config value, and the BROWSER
+// environment variable, in that order, falling back to the operating system's
+// default opener. Launcher values like `firefox --new-tab` are split using
+// shell-style quoting rules, mirroring DetermineEditor. On headless systems
+// where no opener exists, the URL is printed instead of failing.
+func OpenInBrowser(cfg config.Config, url string) error {
+	launcher := os.Getenv("INSTILL_BROWSER")
+	if launcher == "" {
+		launcher, _ = cfg.Get("", "browser")
+	}
+	if launcher == "" {
+		launcher = os.Getenv("BROWSER")
+	}
+
+	if launcher != "" {
+		args, err := shellquote.Split(launcher)
+		if err != nil {
+			return fmt.Errorf("invalid browser command %q: %w", launcher, err)
+		}
+		exe, err := safeexec.LookPath(args[0])
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command(exe, append(args[1:], url)...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+
+	if err := browserOpenURL(url); err != nil {
+		fmt.Fprintf(browserFallbackOut, "Open this URL in your browser: %s\n", url)
+	}
+	return nil
+}
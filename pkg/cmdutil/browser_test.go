@@ -0,0 +1,91 @@
+package cmdutil
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func TestOpenInBrowser_launcher(t *testing.T) {
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "out.txt")
+	launcher := filepath.Join(dir, "fake-browser")
+	script := "#!/bin/sh\necho \"$@\" > " + outFile + "\n"
+	require.NoError(t, os.WriteFile(launcher, []byte(script), 0755))
+
+	tests := []struct {
+		name       string
+		config     config.Config
+		browserEnv string
+	}{
+		{
+			name:   "browser from config",
+			config: config.ConfigStub{"browser": launcher},
+		},
+		{
+			name:       "BROWSER environment fallback",
+			config:     config.ConfigStub{},
+			browserEnv: launcher,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INSTILL_BROWSER", "")
+			t.Setenv("BROWSER", tt.browserEnv)
+			_ = os.Remove(outFile)
+
+			err := OpenInBrowser(tt.config, "https://example.com/login")
+			assert.NoError(t, err)
+
+			content, err := os.ReadFile(outFile)
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com/login\n", string(content))
+		})
+	}
+}
+
+func TestOpenInBrowser_osDefault(t *testing.T) {
+	t.Setenv("INSTILL_BROWSER", "")
+	t.Setenv("BROWSER", "")
+
+	var openedURL string
+	origOpenURL := browserOpenURL
+	browserOpenURL = func(url string) error {
+		openedURL = url
+		return nil
+	}
+	defer func() { browserOpenURL = origOpenURL }()
+
+	err := OpenInBrowser(config.ConfigStub{}, "https://example.com/login")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/login", openedURL)
+}
+
+func TestOpenInBrowser_headless(t *testing.T) {
+	t.Setenv("INSTILL_BROWSER", "")
+	t.Setenv("BROWSER", "")
+
+	origOpenURL := browserOpenURL
+	origOut := browserFallbackOut
+	browserOpenURL = func(url string) error {
+		return errors.New("exec: \"xdg-open\": executable file not found in $PATH")
+	}
+	out := &bytes.Buffer{}
+	browserFallbackOut = out
+	defer func() {
+		browserOpenURL = origOpenURL
+		browserFallbackOut = origOut
+	}()
+
+	err := OpenInBrowser(config.ConfigStub{}, "https://example.com/login")
+	assert.NoError(t, err)
+	assert.Equal(t, "Open this URL in your browser: https://example.com/login\n", out.String())
+}
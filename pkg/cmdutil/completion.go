@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// ConfigKeyCompletion completes a config key argument with the keys from
+// ConfigOptions, annotated with their descriptions.
+func ConfigKeyCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, co := range config.ConfigOptions() {
+		keys = append(keys, fmt.Sprintf("%s\t%s", co.Key, co.Description))
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// HostnameCompletion completes a hostname argument or flag value with the
+// hosts the user has authenticated with.
+func HostnameCompletion(f *Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, err := f.Config()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		hostnames, err := cfg.Hosts()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return hostnames, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ConfigKeyValueCompletion completes a config key argument followed by one of
+// the key's allowed values, if any are declared.
+func ConfigKeyValueCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return ConfigKeyCompletion(cmd, args, toComplete)
+	}
+	if len(args) == 1 {
+		for _, co := range config.ConfigOptions() {
+			if co.Key == args[0] {
+				return co.AllowedValues, cobra.ShellCompDirectiveNoFileComp
+			}
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,31 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigKeyCompletion(t *testing.T) {
+	keys, directive := ConfigKeyCompletion(&cobra.Command{}, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Contains(t, keys, "protocol\tthe protocol to use for git clone and push operations")
+
+	keys, _ = ConfigKeyCompletion(&cobra.Command{}, []string{"protocol"}, "")
+	assert.Empty(t, keys)
+}
+
+func TestConfigKeyValueCompletion(t *testing.T) {
+	values, directive := ConfigKeyValueCompletion(&cobra.Command{}, []string{"protocol"}, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []string{"https", "ssh"}, values)
+
+	// keys without declared values offer no suggestions
+	values, _ = ConfigKeyValueCompletion(&cobra.Command{}, []string{"editor"}, "")
+	assert.Empty(t, values)
+
+	// first argument falls back to key completion
+	keys, _ := ConfigKeyValueCompletion(&cobra.Command{}, nil, "")
+	assert.NotEmpty(t, keys)
+}
@@ -0,0 +1,40 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+
+	shellquote "github.com/kballard/go-shellquote"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// DetermineEditor resolves the editor program and its arguments from the
+// `editor` config value, falling back to the VISUAL and EDITOR environment
+// variables. Values like `code --wait` or quoted paths with spaces are split
+// using shell-style quoting rules.
+func DetermineEditor(cfg config.Config) (string, []string, error) {
+	editorCommand, err := cfg.Get("", "editor")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if editorCommand == "" {
+		if v := os.Getenv("VISUAL"); v != "" {
+			editorCommand = v
+		} else if e := os.Getenv("EDITOR"); e != "" {
+			editorCommand = e
+		}
+	}
+
+	if editorCommand == "" {
+		return "", nil, fmt.Errorf("no editor configured; set the `editor` config key or the EDITOR environment variable")
+	}
+
+	args, err := shellquote.Split(editorCommand)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid editor command %q: %w", editorCommand, err)
+	}
+
+	return args[0], args[1:], nil
+}
@@ -0,0 +1,83 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func TestDetermineEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	tests := []struct {
+		name      string
+		config    config.Config
+		visualEnv string
+		editorEnv string
+		wantProg  string
+		wantArgs  []string
+		wantsErr  bool
+	}{
+		{
+			name:     "plain program",
+			config:   config.ConfigStub{"editor": "vim"},
+			wantProg: "vim",
+			wantArgs: []string{},
+		},
+		{
+			name:     "program with arguments",
+			config:   config.ConfigStub{"editor": "code --wait"},
+			wantProg: "code",
+			wantArgs: []string{"--wait"},
+		},
+		{
+			name:     "quoted path with spaces",
+			config:   config.ConfigStub{"editor": `"C:\Program Files\Editor\ed.exe" --wait`},
+			wantProg: `C:\Program Files\Editor\ed.exe`,
+			wantArgs: []string{"--wait"},
+		},
+		{
+			name:      "falls back to VISUAL",
+			config:    config.ConfigStub{"editor": ""},
+			visualEnv: "emacs -nw",
+			wantProg:  "emacs",
+			wantArgs:  []string{"-nw"},
+		},
+		{
+			name:      "falls back to EDITOR",
+			config:    config.ConfigStub{"editor": ""},
+			editorEnv: "nano",
+			wantProg:  "nano",
+			wantArgs:  []string{},
+		},
+		{
+			name:     "nothing configured",
+			config:   config.ConfigStub{"editor": ""},
+			wantsErr: true,
+		},
+		{
+			name:     "unbalanced quotes",
+			config:   config.ConfigStub{"editor": `"unterminated`},
+			wantsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visualEnv)
+			t.Setenv("EDITOR", tt.editorEnv)
+
+			prog, args, err := DetermineEditor(tt.config)
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantProg, prog)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -33,6 +33,28 @@ func (fe *FlagError) Unwrap() error {
 // SilentError is an error that triggers exit code 1 without any error messaging
 var SilentError = errors.New("SilentError")
 
+// HTTPStatusError is a silent error that maps the class of a failed HTTP
+// response status to a distinguishable process exit code.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+// ExitCode returns 4 for 4xx responses, 5 for 5xx responses, and 1 otherwise.
+func (e HTTPStatusError) ExitCode() int {
+	switch {
+	case e.StatusCode >= 400 && e.StatusCode < 500:
+		return 4
+	case e.StatusCode >= 500 && e.StatusCode < 600:
+		return 5
+	default:
+		return 1
+	}
+}
+
 // CancelError signals user-initiated cancellation
 var CancelError = errors.New("CancelError")
 
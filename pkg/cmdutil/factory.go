@@ -18,6 +18,22 @@ type Factory struct {
 	HTTPClient func() (*http.Client, error)
 	Config     func() (config.Config, error)
 
+	// UnauthenticatedHTTPClient is HTTPClient without the Authorization
+	// middleware, for requests that must not carry the stored token
+	UnauthenticatedHTTPClient func() (*http.Client, error)
+
 	// Executable is the path to the currently invoked inst binary
 	Executable func() string
+
+	// HTTPMiddleware holds extra RoundTripper wrappers applied to every client
+	// built by HTTPClient, in registration order. Register via AddHTTPMiddleware.
+	HTTPMiddleware []func(http.RoundTripper) http.RoundTripper
+}
+
+// AddHTTPMiddleware registers a RoundTripper wrapper to be applied to every
+// HTTP client built by HTTPClient. Middleware runs in registration order, and
+// always after the built-in middleware, so it observes fully authenticated
+// requests.
+func (f *Factory) AddHTTPMiddleware(mw func(http.RoundTripper) http.RoundTripper) {
+	f.HTTPMiddleware = append(f.HTTPMiddleware, mw)
 }
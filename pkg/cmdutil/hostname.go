@@ -0,0 +1,13 @@
+package cmdutil
+
+import (
+	"github.com/instill-ai/cli/internal/instance"
+)
+
+// NormalizeHostname canonicalizes a user-supplied hostname for commands that
+// accept one, so that inputs like "https://My.Instance/" and "my.instance:443"
+// cannot create duplicate host entries. See instance.NormalizeHostname for
+// the exact rules.
+func NormalizeHostname(hostname string) (string, error) {
+	return instance.NormalizeHostname(hostname)
+}
@@ -0,0 +1,105 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "bare hostname",
+			input: "my.instance",
+			want:  "my.instance",
+		},
+		{
+			name:  "uppercase is lowered",
+			input: "My.Instance",
+			want:  "my.instance",
+		},
+		{
+			name:  "surrounding whitespace",
+			input: "  my.instance ",
+			want:  "my.instance",
+		},
+		{
+			name:  "trailing dot",
+			input: "my.instance.",
+			want:  "my.instance",
+		},
+		{
+			name:  "https URL",
+			input: "https://my.instance/",
+			want:  "my.instance",
+		},
+		{
+			name:  "URL with a path",
+			input: "https://my.instance/api/v1",
+			want:  "my.instance",
+		},
+		{
+			name:  "path without a scheme",
+			input: "my.instance/api",
+			want:  "my.instance",
+		},
+		{
+			name:  "default https port is dropped",
+			input: "my.instance:443",
+			want:  "my.instance",
+		},
+		{
+			name:  "non-default port is kept",
+			input: "my.instance:8080",
+			want:  "my.instance:8080",
+		},
+		{
+			name:  "default http port on a local instance",
+			input: "localhost:80",
+			want:  "localhost",
+		},
+		{
+			name:  "non-default port on a local instance",
+			input: "localhost:8080",
+			want:  "localhost:8080",
+		},
+		{
+			name:  "everything at once",
+			input: "HTTPS://My.Instance:443/path",
+			want:  "my.instance",
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "not a hostname",
+			input:   "not@valid",
+			wantErr: true,
+		},
+		{
+			name:    "URL without a host",
+			input:   "https:///path",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeHostname(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/internal/instance"
+	"github.com/instill-ai/cli/pkg/export"
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/jsoncolor"
+)
+
+// ListOptions holds the state of a generic resource list command.
+type ListOptions struct {
+	IO         *iostreams.IOStreams
+	Config     func() (config.Config, error)
+	HTTPClient func() (*http.Client, error)
+
+	// Endpoint is the API path to request, e.g. "vdp/v1alpha/pipelines".
+	Endpoint string
+	// ResultsKey is the response key holding the array of resources, e.g. "pipelines".
+	ResultsKey string
+
+	Hostname     string
+	Limit        int
+	Delay        time.Duration
+	Jitter       time.Duration
+	FilterOutput string
+	Template     string
+}
+
+// NewListCommand returns a `list` command for a resource, collecting items
+// from the `resultsKey` array of each response page. It handles --limit,
+// pagination, and jq/template formatting uniformly, so concrete list commands
+// can be thin wrappers around an endpoint.
+func NewListCommand(f *Factory, resource, endpoint, resultsKey string, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		HTTPClient: f.HTTPClient,
+		Endpoint:   endpoint,
+		ResultsKey: resultsKey,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List %s", resource),
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Limit < 1 {
+				return FlagErrorf("invalid limit: %v", opts.Limit)
+			}
+
+			if err := MutuallyExclusive(
+				"only one of `--template` or `--jq` may be used",
+				opts.FilterOutput != "",
+				opts.Template != "",
+			); err != nil {
+				return err
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return RunList(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Hostname, "hostname", "", "Target instance")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 30, fmt.Sprintf("Maximum number of %s to fetch", resource))
+	cmd.Flags().DurationVar(&opts.Delay, "delay", 0, "Pause between consecutive page requests, e.g. \"500ms\"")
+	cmd.Flags().DurationVar(&opts.Jitter, "jitter", 0, "Random extra pause of up to this duration added to --delay")
+	cmd.Flags().StringVarP(&opts.FilterOutput, "jq", "q", "", "Query to select values from the response using jq syntax")
+	cmd.Flags().StringVarP(&opts.Template, "template", "t", "", "Format the response using a Go template")
+
+	return cmd
+}
+
+// RunList fetches pages from the endpoint until the limit is reached or no
+// further page token is returned, then prints the collected resources.
+func RunList(opts *ListOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+	hostname := opts.Hostname
+	if hostname == "" {
+		hostname = cfg.DefaultHostname()
+	}
+
+	httpClient, err := opts.HTTPClient()
+	if err != nil {
+		return err
+	}
+
+	items, err := fetchList(httpClient, hostname, opts.Endpoint, opts.ResultsKey, opts.Limit, NewPacer(opts.Delay, opts.Jitter))
+	if err != nil {
+		return err
+	}
+
+	if err := opts.IO.StartPager(); err != nil {
+		return err
+	}
+	defer opts.IO.StopPager()
+
+	body, err := json.Marshal(map[string]interface{}{opts.ResultsKey: items})
+	if err != nil {
+		return err
+	}
+
+	if opts.FilterOutput != "" {
+		return export.FilterJSON(opts.IO.Out, strings.NewReader(string(body)), opts.FilterOutput)
+	}
+	if opts.Template != "" {
+		template := export.NewTemplate(opts.IO, opts.Template)
+		if err := template.Execute(strings.NewReader(string(body))); err != nil {
+			return err
+		}
+		return template.End()
+	}
+	if opts.IO.ColorEnabled() {
+		return jsoncolor.Write(opts.IO.Out, strings.NewReader(string(body)), "  ")
+	}
+	_, err = fmt.Fprintln(opts.IO.Out, string(body))
+	return err
+}
+
+func fetchList(client *http.Client, hostname, endpoint, resultsKey string, limit int, pacer *Pacer) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	pageToken := ""
+
+	for {
+		pacer.Wait()
+		pageSize := limit - len(items)
+		if pageSize > 100 {
+			pageSize = 100
+		}
+
+		requestURL := instance.GetProtocol(hostname) + strings.TrimPrefix(endpoint, "/")
+		query := url.Values{}
+		query.Add("page_size", strconv.Itoa(pageSize))
+		if pageToken != "" {
+			query.Add("page_token", pageToken)
+		}
+		sep := "?"
+		if strings.ContainsRune(requestURL, '?') {
+			sep = "&"
+		}
+
+		resp, err := client.Get(requestURL + sep + query.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			NextPageToken string `json:"next_page_token"`
+		}
+		raw := map[string]json.RawMessage{}
+		err = json.NewDecoder(resp.Body).Decode(&raw)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode > 299 {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, requestURL)
+		}
+
+		var pageItems []json.RawMessage
+		if rawItems, ok := raw[resultsKey]; ok {
+			if err := json.Unmarshal(rawItems, &pageItems); err != nil {
+				return nil, fmt.Errorf("could not parse %q in the response: %w", resultsKey, err)
+			}
+		}
+		if rawToken, ok := raw["next_page_token"]; ok {
+			_ = json.Unmarshal(rawToken, &page.NextPageToken)
+		}
+
+		items = append(items, pageItems...)
+		if page.NextPageToken == "" || len(items) >= limit || len(pageItems) == 0 {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
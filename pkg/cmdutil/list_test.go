@@ -0,0 +1,103 @@
+package cmdutil
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/internal/config"
+	"github.com/instill-ai/cli/pkg/httpmock"
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestRunList(t *testing.T) {
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.REST("GET", "vdp/v1alpha/pipelines"),
+		httpmock.StringResponse(`{"pipelines": [{"id": "a"}], "next_page_token": "tok"}`),
+	)
+	reg.Register(
+		httpmock.REST("GET", "vdp/v1alpha/pipelines"),
+		httpmock.StringResponse(`{"pipelines": [{"id": "b"}], "next_page_token": ""}`),
+	)
+
+	io, _, stdout, _ := iostreams.Test()
+	opts := &ListOptions{
+		IO: io,
+		Config: func() (config.Config, error) {
+			return config.ConfigStub{}, nil
+		},
+		HTTPClient: func() (*http.Client, error) {
+			return &http.Client{Transport: reg}, nil
+		},
+		Endpoint:     "vdp/v1alpha/pipelines",
+		ResultsKey:   "pipelines",
+		Limit:        2,
+		FilterOutput: ".pipelines[].id",
+	}
+
+	err := RunList(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nb\n", stdout.String())
+	reg.Verify(t)
+	assert.Len(t, reg.Requests, 2)
+}
+
+func TestNewListCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		output   ListOptions
+		wantsErr bool
+	}{
+		{
+			name:   "defaults",
+			args:   []string{},
+			output: ListOptions{Limit: 30},
+		},
+		{
+			name:   "custom limit",
+			args:   []string{"--limit", "5"},
+			output: ListOptions{Limit: 5},
+		},
+		{
+			name:     "invalid limit",
+			args:     []string{"--limit", "0"},
+			wantsErr: true,
+		},
+		{
+			name:     "jq and template together",
+			args:     []string{"--jq", ".x", "--template", "{{.x}}"},
+			wantsErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, _ := iostreams.Test()
+			f := &Factory{
+				IOStreams: io,
+				Config:    config.ConfigStubFactory,
+			}
+
+			var gotOpts *ListOptions
+			cmd := NewListCommand(f, "pipelines", "vdp/v1alpha/pipelines", "pipelines", func(opts *ListOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Out)
+			cmd.SetErr(io.ErrOut)
+
+			_, err := cmd.ExecuteC()
+			if tt.wantsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output.Limit, gotOpts.Limit)
+		})
+	}
+}
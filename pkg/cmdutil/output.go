@@ -2,6 +2,7 @@ package cmdutil
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -9,10 +10,56 @@ import (
 	"github.com/MakeNowJust/heredoc"
 	"github.com/charmbracelet/glamour"
 	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/instill-ai/cli/pkg/iostreams"
 )
 
+// OutputFormats are the values accepted by the --output flag.
+var OutputFormats = []string{"table", "json", "yaml"}
+
+// AddFormatFlag registers the --output flag on commands that render lists.
+func AddFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVarP(format, "output", "o", "table",
+		fmt.Sprintf("Output format: one of {%s}", strings.Join(OutputFormats, "|")))
+}
+
+// ValidateFormat checks a --output value against OutputFormats.
+func ValidateFormat(format string) error {
+	for _, f := range OutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return FlagErrorf("invalid value %q for `--output`: valid values are %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// RenderOutput writes data in the requested format: a Markdown table built
+// from columns and rows, or data serialized as JSON or YAML.
+func RenderOutput(io *iostreams.IOStreams, format string, columns []string, rows [][]string, data interface{}) error {
+	switch format {
+	case "", "table":
+		return PrintMarkdown(io, GenTable(columns, rows))
+	case "json":
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(io.Out, "%s\n", out)
+		return err
+	case "yaml":
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(io.Out, "%s", out)
+		return err
+	default:
+		return ValidateFormat(format)
+	}
+}
+
 func PrintMarkdown(io *iostreams.IOStreams, md string) error {
 	tr, _ := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
@@ -0,0 +1,68 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+)
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range OutputFormats {
+		assert.NoError(t, ValidateFormat(format))
+	}
+	err := ValidateFormat("xml")
+	assert.EqualError(t, err, "invalid value \"xml\" for `--output`: valid values are table, json, yaml")
+}
+
+func TestRenderOutput(t *testing.T) {
+	type row struct {
+		Name  string `json:"name" yaml:"name"`
+		Count int    `json:"count" yaml:"count"`
+	}
+	data := []row{{Name: "foo", Count: 2}}
+
+	tests := []struct {
+		name    string
+		format  string
+		stdout  string
+		wantErr string
+	}{
+		{
+			name:   "json",
+			format: "json",
+			stdout: "[\n  {\n    \"name\": \"foo\",\n    \"count\": 2\n  }\n]\n",
+		},
+		{
+			name:   "yaml",
+			format: "yaml",
+			stdout: "- name: foo\n  count: 2\n",
+		},
+		{
+			name:    "invalid format",
+			format:  "xml",
+			wantErr: "invalid value \"xml\" for `--output`: valid values are table, json, yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			err := RenderOutput(io, tt.format, []string{"Name", "Count"}, [][]string{{"foo", "2"}}, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stdout, stdout.String())
+		})
+	}
+}
+
+func TestRenderOutput_table(t *testing.T) {
+	io, _, stdout, _ := iostreams.Test()
+	err := RenderOutput(io, "table", []string{"Name"}, [][]string{{"foo"}}, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, stdout.String(), "foo")
+}
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Pacer spaces out dispatched requests in bulk operations, pausing between
+// dispatches rather than per worker. An optional jitter adds a random extra
+// pause of up to the configured duration to avoid synchronized load spikes.
+type Pacer struct {
+	Delay  time.Duration
+	Jitter time.Duration
+
+	// sleep is swappable for tests
+	sleep      func(time.Duration)
+	dispatched bool
+}
+
+// NewPacer returns a Pacer that pauses for delay (plus up to jitter) between
+// consecutive dispatches.
+func NewPacer(delay, jitter time.Duration) *Pacer {
+	return &Pacer{
+		Delay:  delay,
+		Jitter: jitter,
+		sleep:  time.Sleep,
+	}
+}
+
+// Wait pauses before a dispatch. The first dispatch is never delayed.
+func (p *Pacer) Wait() {
+	if !p.dispatched {
+		p.dispatched = true
+		return
+	}
+	pause := p.Delay
+	if p.Jitter > 0 {
+		pause += time.Duration(rand.Int63n(int64(p.Jitter)))
+	}
+	if pause > 0 {
+		p.sleep(pause)
+	}
+}
@@ -0,0 +1,48 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacerWait(t *testing.T) {
+	var pauses []time.Duration
+	p := NewPacer(100*time.Millisecond, 0)
+	p.sleep = func(d time.Duration) {
+		pauses = append(pauses, d)
+	}
+
+	// three dispatches: no pause before the first, then one per dispatch
+	p.Wait()
+	p.Wait()
+	p.Wait()
+
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}, pauses)
+}
+
+func TestPacerWaitJitter(t *testing.T) {
+	var pauses []time.Duration
+	p := NewPacer(100*time.Millisecond, 50*time.Millisecond)
+	p.sleep = func(d time.Duration) {
+		pauses = append(pauses, d)
+	}
+
+	p.Wait()
+	p.Wait()
+
+	assert.Len(t, pauses, 1)
+	assert.GreaterOrEqual(t, pauses[0], 100*time.Millisecond)
+	assert.Less(t, pauses[0], 150*time.Millisecond)
+}
+
+func TestPacerWaitZero(t *testing.T) {
+	p := NewPacer(0, 0)
+	p.sleep = func(time.Duration) {
+		t.Fatal("unexpected sleep")
+	}
+
+	p.Wait()
+	p.Wait()
+}
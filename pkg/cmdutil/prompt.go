@@ -0,0 +1,25 @@
+package cmdutil
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+// Confirm asks a yes/no question on the terminal. When prompting is disabled
+// (no TTY, `--no-prompt`, or the `prompt` setting), the default answer is
+// returned instead of blocking. Commands that offer a `--yes` flag should skip
+// the call entirely when the flag is set.
+func Confirm(io *iostreams.IOStreams, message string, defaultYes bool) (bool, error) {
+	if !io.CanPrompt() {
+		return defaultYes, nil
+	}
+
+	var confirmed bool
+	err := prompt.SurveyAskOne(&survey.Confirm{
+		Message: message,
+		Default: defaultYes,
+	}, &confirmed)
+	return confirmed, err
+}
@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/instill-ai/cli/pkg/iostreams"
+	"github.com/instill-ai/cli/pkg/prompt"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Run("returns the default when prompting is disabled", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+
+		confirmed, err := Confirm(io, "Proceed?", true)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+
+		confirmed, err = Confirm(io, "Proceed?", false)
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+
+	t.Run("returns the default when prompts are turned off explicitly", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		io.SetStdinTTY(true)
+		io.SetStdoutTTY(true)
+		io.SetNeverPrompt(true)
+
+		confirmed, err := Confirm(io, "Proceed?", true)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("asks interactively", func(t *testing.T) {
+		io, _, _, _ := iostreams.Test()
+		io.SetStdinTTY(true)
+		io.SetStdoutTTY(true)
+
+		as, teardown := prompt.InitAskStubber()
+		defer teardown()
+		as.StubOne(false)
+
+		confirmed, err := Confirm(io, "Proceed?", true)
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+}
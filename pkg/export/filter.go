@@ -7,6 +7,12 @@ import (
 	"io"
 )
 
+// ValidateFilter checks that a jq query compiles, without running it.
+func ValidateFilter(queryStr string) error {
+	_, err := gojq.Parse(queryStr)
+	return err
+}
+
 func FilterJSON(w io.Writer, input io.Reader, queryStr string) error {
 	query, err := gojq.Parse(queryStr)
 	if err != nil {
@@ -22,6 +22,7 @@ type Template struct {
 	tablePrinter utils.TablePrinter
 	template     *template.Template
 	templateStr  string
+	name         string
 }
 
 func NewTemplate(io *iostreams.IOStreams, template string) Template {
@@ -31,6 +32,16 @@ func NewTemplate(io *iostreams.IOStreams, template string) Template {
 	}
 }
 
+// NewTemplateWithName names the template so that parse errors reference its
+// source, e.g. the file it was loaded from.
+func NewTemplateWithName(io *iostreams.IOStreams, template, name string) Template {
+	return Template{
+		io:          io,
+		templateStr: template,
+		name:        name,
+	}
+}
+
 func (t *Template) parseTemplate(tpl string) (*template.Template, error) {
 	now := time.Now()
 
@@ -52,6 +63,20 @@ func (t *Template) parseTemplate(tpl string) (*template.Template, error) {
 			}
 			return timeAgo(now.Sub(t)), nil
 		},
+		"isotime": func(input string) (string, error) {
+			t, err := time.Parse(time.RFC3339, input)
+			if err != nil {
+				return "", err
+			}
+			return t.UTC().Format(time.RFC3339), nil
+		},
+		"localtime": func(input string) (string, error) {
+			t, err := time.Parse(time.RFC3339, input)
+			if err != nil {
+				return "", err
+			}
+			return t.Local().Format("2006-01-02 15:04:05 MST"), nil
+		},
 
 		"pluck":       templatePluck,
 		"join":        templateJoin,
@@ -66,7 +91,21 @@ func (t *Template) parseTemplate(tpl string) (*template.Template, error) {
 		}
 	}
 
-	return template.New("").Funcs(templateFuncs).Parse(tpl)
+	return template.New(t.name).Funcs(templateFuncs).Parse(tpl)
+}
+
+// Validate parses the template without executing it, so callers can surface
+// syntax errors ahead of making a request.
+func (t *Template) Validate() error {
+	if t.template != nil {
+		return nil
+	}
+	template, err := t.parseTemplate(t.templateStr)
+	if err != nil {
+		return err
+	}
+	t.template = template
+	return nil
 }
 
 func (t *Template) Execute(input io.Reader) error {
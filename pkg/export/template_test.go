@@ -122,6 +122,22 @@ func Test_executeTemplate(t *testing.T) {
 			},
 			wantW: "5 minutes ago",
 		},
+		{
+			name: "isotime",
+			args: args{
+				json:     strings.NewReader(`{"created_at":"2008-02-25T20:18:33+01:00"}`),
+				template: `{{.created_at | isotime}}`,
+			},
+			wantW: "2008-02-25T19:18:33Z",
+		},
+		{
+			name: "localtime",
+			args: args{
+				json:     strings.NewReader(`{"created_at":"2008-02-25T20:18:33Z"}`),
+				template: `{{.created_at | localtime}}`,
+			},
+			wantW: time.Date(2008, 2, 25, 20, 18, 33, 0, time.UTC).Local().Format("2006-01-02 15:04:05 MST"),
+		},
 		{
 			name: "pluck",
 			args: args{
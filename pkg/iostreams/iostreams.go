@@ -52,6 +52,8 @@ type IOStreams struct {
 	pagerProcess *os.Process
 
 	neverPrompt bool
+	quiet       bool
+	logFormat   string
 
 	TempFileOverride *os.File
 }
@@ -106,6 +108,23 @@ func (s *IOStreams) SetColorEnabled(colorEnabled bool) {
 	s.colorEnabled = colorEnabled
 }
 
+// SetColorFromConfig applies the user's `color` preference: "always", "never",
+// or "auto". Environment variables win over the preference: NO_COLOR keeps
+// colors off and CLICOLOR_FORCE keeps them on. With "auto", terminal detection
+// stays in charge.
+func (s *IOStreams) SetColorFromConfig(pref string) {
+	switch pref {
+	case "always":
+		if !EnvColorDisabled() {
+			s.colorEnabled = true
+		}
+	case "never":
+		if !EnvColorForced() {
+			s.colorEnabled = false
+		}
+	}
+}
+
 func (s *IOStreams) SetStdinTTY(isTTY bool) {
 	s.stdinTTYOverride = true
 	s.stdinIsTTY = isTTY
@@ -221,6 +240,24 @@ func (s *IOStreams) CanPrompt() bool {
 	return s.IsStdinTTY() && s.IsStdoutTTY()
 }
 
+// SetInteractive overrides terminal auto-detection for all three streams at
+// once, for environments where detection is wrong (some CI runners, tmux).
+// Forcing interactivity on also enables color and progress indicators, and
+// forcing it off disables them; the NO_COLOR and CLICOLOR_FORCE environment
+// variables still take precedence over the override.
+func (s *IOStreams) SetInteractive(interactive bool) {
+	s.SetStdinTTY(interactive)
+	s.SetStdoutTTY(interactive)
+	s.SetStderrTTY(interactive)
+	if interactive {
+		s.colorEnabled = !EnvColorDisabled()
+		s.progressIndicatorEnabled = true
+	} else {
+		s.colorEnabled = EnvColorForced()
+		s.progressIndicatorEnabled = false
+	}
+}
+
 func (s *IOStreams) GetNeverPrompt() bool {
 	return s.neverPrompt
 }
@@ -229,12 +266,29 @@ func (s *IOStreams) SetNeverPrompt(v bool) {
 	s.neverPrompt = v
 }
 
+// IsQuiet reports whether purely informational output — progress spinners,
+// warnings, status lines — should be suppressed. Actual errors are always
+// printed regardless.
+func (s *IOStreams) IsQuiet() bool {
+	return s.quiet
+}
+
+func (s *IOStreams) SetQuiet(v bool) {
+	s.quiet = v
+}
+
 func (s *IOStreams) StartProgressIndicator() {
 	s.StartProgressIndicatorWithLabel("")
 }
 
+// SetProgressIndicatorEnabled forces the progress indicator on or off,
+// overriding the TTY detection done in System().
+func (s *IOStreams) SetProgressIndicatorEnabled(v bool) {
+	s.progressIndicatorEnabled = v
+}
+
 func (s *IOStreams) StartProgressIndicatorWithLabel(label string) {
-	if !s.progressIndicatorEnabled {
+	if !s.progressIndicatorEnabled || s.quiet || s.neverPrompt {
 		return
 	}
 
@@ -271,6 +325,16 @@ func (s *IOStreams) StopProgressIndicator() {
 	s.progressIndicator = nil
 }
 
+// RestoreTerminal resets terminal state after an interrupted command: it
+// stops any running progress indicator, re-enables the cursor, and resets
+// colors so the next shell prompt is not left garbled.
+func (s *IOStreams) RestoreTerminal() {
+	s.StopProgressIndicator()
+	if s.IsStderrTTY() {
+		fmt.Fprint(s.ErrOut, "\x1b[0m\x1b[?25h")
+	}
+}
+
 // TerminalWidth returns the width of the terminal that stdout is attached to.
 // TODO: investigate whether ProcessTerminalWidth could replace all this.
 func (s *IOStreams) TerminalWidth() int {
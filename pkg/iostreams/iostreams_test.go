@@ -2,6 +2,7 @@ package iostreams
 
 import (
 	"errors"
+	"os"
 	"testing"
 )
 
@@ -66,3 +67,161 @@ func TestIOStreams_ForceTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestIOStreams_SetColorFromConfig(t *testing.T) {
+	origNoColor := os.Getenv("NO_COLOR")
+	origForce := os.Getenv("CLICOLOR_FORCE")
+	t.Cleanup(func() {
+		os.Setenv("NO_COLOR", origNoColor)
+		os.Setenv("CLICOLOR_FORCE", origForce)
+	})
+
+	tests := []struct {
+		name           string
+		pref           string
+		initialEnabled bool
+		NO_COLOR       string
+		CLICOLOR_FORCE string
+		want           bool
+	}{
+		{
+			name:           "always enables color without a TTY",
+			pref:           "always",
+			initialEnabled: false,
+			want:           true,
+		},
+		{
+			name:           "never disables color on a TTY",
+			pref:           "never",
+			initialEnabled: true,
+			want:           false,
+		},
+		{
+			name:           "auto leaves detection in charge",
+			pref:           "auto",
+			initialEnabled: true,
+			want:           true,
+		},
+		{
+			name:           "NO_COLOR overrides always",
+			pref:           "always",
+			initialEnabled: false,
+			NO_COLOR:       "1",
+			want:           false,
+		},
+		{
+			name:           "CLICOLOR_FORCE overrides never",
+			pref:           "never",
+			initialEnabled: true,
+			CLICOLOR_FORCE: "1",
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("NO_COLOR", tt.NO_COLOR)
+			os.Setenv("CLICOLOR_FORCE", tt.CLICOLOR_FORCE)
+
+			io := &IOStreams{colorEnabled: tt.initialEnabled}
+			io.SetColorFromConfig(tt.pref)
+			if got := io.ColorEnabled(); got != tt.want {
+				t.Errorf("IOStreams.ColorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIOStreams_SetQuiet(t *testing.T) {
+	io, _, _, stderr := Test()
+	if io.IsQuiet() {
+		t.Error("expected quiet mode to be off by default")
+	}
+
+	io.SetQuiet(true)
+	if !io.IsQuiet() {
+		t.Error("expected quiet mode to be on after SetQuiet(true)")
+	}
+
+	// quiet mode must keep the progress indicator from writing to stderr
+	io.progressIndicatorEnabled = true
+	io.StartProgressIndicator()
+	io.StopProgressIndicator()
+	if got := stderr.String(); got != "" {
+		t.Errorf("expected no progress output in quiet mode, got %q", got)
+	}
+}
+
+func TestIOStreams_SetInteractive(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+
+	t.Run("forcing interactive enables prompts and color", func(t *testing.T) {
+		io, _, _, _ := Test()
+		io.SetInteractive(true)
+		if !io.IsStdinTTY() || !io.IsStdoutTTY() || !io.IsStderrTTY() {
+			t.Error("expected all streams to report a terminal")
+		}
+		if !io.CanPrompt() {
+			t.Error("expected prompting to be allowed")
+		}
+		if !io.ColorEnabled() {
+			t.Error("expected color to be enabled")
+		}
+		if !io.progressIndicatorEnabled {
+			t.Error("expected the progress indicator to be enabled")
+		}
+	})
+
+	t.Run("forcing non-interactive disables prompts and color", func(t *testing.T) {
+		io, _, _, _ := Test()
+		io.SetStdinTTY(true)
+		io.SetStdoutTTY(true)
+		io.SetInteractive(false)
+		if io.IsStdinTTY() || io.IsStdoutTTY() || io.IsStderrTTY() {
+			t.Error("expected no stream to report a terminal")
+		}
+		if io.CanPrompt() {
+			t.Error("expected prompting to be disallowed")
+		}
+		if io.ColorEnabled() {
+			t.Error("expected color to be disabled")
+		}
+		if io.progressIndicatorEnabled {
+			t.Error("expected the progress indicator to be disabled")
+		}
+	})
+
+	t.Run("NO_COLOR still wins over a forced terminal", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		io, _, _, _ := Test()
+		io.SetInteractive(true)
+		if io.ColorEnabled() {
+			t.Error("expected NO_COLOR to keep color disabled")
+		}
+	})
+}
+
+func TestIOStreams_progressIndicator(t *testing.T) {
+	t.Run("SetProgressIndicatorEnabled(false) disables the spinner", func(t *testing.T) {
+		io, _, _, stderr := Test()
+		io.progressIndicatorEnabled = true
+		io.SetProgressIndicatorEnabled(false)
+		io.StartProgressIndicatorWithLabel("working")
+		io.StopProgressIndicator()
+		if got := stderr.String(); got != "" {
+			t.Errorf("expected no progress output, got %q", got)
+		}
+	})
+
+	t.Run("never-prompt mode disables the spinner", func(t *testing.T) {
+		io, _, _, stderr := Test()
+		io.progressIndicatorEnabled = true
+		io.SetNeverPrompt(true)
+		io.StartProgressIndicatorWithLabel("working")
+		io.StopProgressIndicator()
+		if got := stderr.String(); got != "" {
+			t.Errorf("expected no progress output, got %q", got)
+		}
+	})
+}
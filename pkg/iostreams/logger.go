@@ -0,0 +1,69 @@
+package iostreams
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Log formats for diagnostic output written to ErrOut.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// SetLogFormat selects how diagnostic messages are written to ErrOut: "text"
+// (the default) for human-readable output, or "json" for one JSON object per
+// line, which log collectors can parse.
+func (s *IOStreams) SetLogFormat(format string) error {
+	switch format {
+	case "", LogFormatText, LogFormatJSON:
+		s.logFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q: valid formats are %s, %s", format, LogFormatText, LogFormatJSON)
+	}
+}
+
+// LogInfof writes an informational message to ErrOut. It is suppressed in
+// quiet mode.
+func (s *IOStreams) LogInfof(format string, args ...interface{}) {
+	if s.quiet {
+		return
+	}
+	s.log("info", "", fmt.Sprintf(format, args...))
+}
+
+// LogWarningf writes a warning to ErrOut, prefixed with the warning icon in
+// text format. It is suppressed in quiet mode.
+func (s *IOStreams) LogWarningf(format string, args ...interface{}) {
+	if s.quiet {
+		return
+	}
+	s.log("warning", s.ColorScheme().WarningIcon(), fmt.Sprintf(format, args...))
+}
+
+// LogErrorf writes an error message to ErrOut. Errors are printed even in
+// quiet mode.
+func (s *IOStreams) LogErrorf(format string, args ...interface{}) {
+	s.log("error", s.ColorScheme().FailureIcon(), fmt.Sprintf(format, args...))
+}
+
+func (s *IOStreams) log(level, icon, message string) {
+	if s.logFormat == LogFormatJSON {
+		line, err := json.Marshal(struct {
+			Level   string `json:"level"`
+			Message string `json:"message"`
+		}{level, message})
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(s.ErrOut, "%s\n", line)
+		return
+	}
+
+	if icon != "" {
+		fmt.Fprintf(s.ErrOut, "%s %s\n", icon, message)
+		return
+	}
+	fmt.Fprintln(s.ErrOut, message)
+}
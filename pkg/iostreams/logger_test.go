@@ -0,0 +1,41 @@
+package iostreams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOStreams_logFormat(t *testing.T) {
+	t.Run("text format prefixes warnings with the icon", func(t *testing.T) {
+		io, _, _, stderr := Test()
+		io.LogWarningf("invalid value %q", "nope")
+		assert.Equal(t, "! invalid value \"nope\"\n", stderr.String())
+	})
+
+	t.Run("json format emits one object per line", func(t *testing.T) {
+		io, _, _, stderr := Test()
+		require.NoError(t, io.SetLogFormat(LogFormatJSON))
+		io.LogWarningf("invalid value %q", "nope")
+		io.LogInfof("retrying")
+		assert.Equal(t, `{"level":"warning","message":"invalid value \"nope\""}
+{"level":"info","message":"retrying"}
+`, stderr.String())
+	})
+
+	t.Run("quiet suppresses warnings but not errors", func(t *testing.T) {
+		io, _, _, stderr := Test()
+		io.SetQuiet(true)
+		io.LogWarningf("ignored")
+		io.LogInfof("ignored")
+		io.LogErrorf("broken")
+		assert.Equal(t, "X broken\n", stderr.String())
+	})
+
+	t.Run("invalid format is rejected", func(t *testing.T) {
+		io, _, _, _ := Test()
+		err := io.SetLogFormat("xml")
+		assert.EqualError(t, err, `invalid log format "xml": valid formats are text, json`)
+	})
+}